@@ -0,0 +1,140 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"os"
+	"os/exec"
+	"slices"
+	"time"
+)
+
+// Defaults for Exec.
+const (
+	defaultExecTimeout   = time.Minute
+	defaultExecMaxOutput = 64 << 10
+)
+
+// ActionExec returns an action that runs the external program cmd with the
+// passed in arguments and extra environment variables, using the defaults of
+// Exec for all other settings.
+func ActionExec(cmd string, args []string, env map[string]string) ActionFunc {
+	return Exec{Cmd: cmd, Args: args, Env: env}.Action()
+}
+
+// Exec describe an external program to run in response to an evaluation. The
+// evaluation result is JSON encoded and passed to the program via stdin.
+type Exec struct {
+	// Cmd is the name or path of the program to run.
+	Cmd string
+
+	// Args lists the arguments to pass to the program.
+	Args []string
+
+	// Env lists environment variables to set for the program in addition to
+	// the environment of the current process.
+	Env map[string]string
+
+	// Timeout sets for how long the program is allowed to run before it's
+	// killed. The default is one minute.
+	Timeout time.Duration
+
+	// MaxOutput sets the maximum number of bytes of combined output from the
+	// program to keep for logging. Remaining output is discarded. The default
+	// is 64 KiB.
+	MaxOutput int
+}
+
+// Action returns an action that runs the program. The combined output of the
+// program is logged. If the program fails, the action returns false. In
+// dry-run mode, the program is not started, and the action returns true.
+func (e Exec) Action() ActionFunc {
+	timeout := e.Timeout
+	if timeout <= 0 {
+		timeout = defaultExecTimeout
+	}
+	maxOutput := e.MaxOutput
+	if maxOutput <= 0 {
+		maxOutput = defaultExecMaxOutput
+	}
+
+	return func(ctx context.Context, cfg *Config, result *EvaluateResult) bool {
+		logger := cfg.Logger().With(slog.String("cmd", e.Cmd))
+		if cfg.DryRun() {
+			logger.LogAttrs(ctx, slog.LevelInfo, "Dry-run: skipping exec")
+			return true
+		}
+
+		stdin, err := json.Marshal(result)
+		if err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "Failed to encode evaluation result", AttrError(err))
+			return false
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		output := &limitedBuffer{max: maxOutput}
+		c := exec.CommandContext(ctx, e.Cmd, e.Args...)
+		c.Env = os.Environ()
+		for _, k := range slices.Sorted(maps.Keys(e.Env)) {
+			c.Env = append(c.Env, k+"="+e.Env[k])
+		}
+		c.Stdin = bytes.NewReader(stdin)
+		c.Stdout = output
+		c.Stderr = output
+		// Don't wait for the output of child processes that outlive a killed
+		// program.
+		c.WaitDelay = time.Second
+		if err := c.Run(); err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "Exec failed", append(output.attrs(), AttrError(err))...)
+			return false
+		}
+		logger.LogAttrs(ctx, slog.LevelDebug, "Exec completed", output.attrs()...)
+		return true
+	}
+}
+
+// limitedBuffer is an io.Writer that keeps the first max bytes written to it,
+// and discards the rest.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if room := b.max - b.buf.Len(); len(p) > room {
+		p = p[:room]
+		b.truncated = true
+	}
+	b.buf.Write(p)
+	return n, nil
+}
+
+// attrs returns log attributes for the kept output.
+func (b *limitedBuffer) attrs() []slog.Attr {
+	attrs := []slog.Attr{slog.String("output", b.buf.String())}
+	if b.truncated {
+		attrs = append(attrs, slog.Bool("output_truncated", true))
+	}
+	return attrs
+}
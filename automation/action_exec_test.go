@@ -0,0 +1,86 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestActionExec(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not found")
+	}
+	out := filepath.Join(t.TempDir(), "out")
+	t0 := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	result := automation.EvaluateResult{
+		Annotations: fields.Annotations{"k": "v"},
+		Data:        views.DataFrame{"a": {t0: 1}},
+	}
+	cfg := automation.NewConfig(clarify.NewClient("integration", nil)).WithLogger(nil)
+
+	test := func(cfg *automation.Config, script string, expectOK bool, expectOut string) func(t *testing.T) {
+		return func(t *testing.T) {
+			os.Remove(out)
+			action := automation.ActionExec("sh", []string{"-c", script}, map[string]string{"OUT": out})
+			if ok := action(context.Background(), cfg, &result); ok != expectOK {
+				t.Errorf("Unexpected action result:\n got: %v\nwant: %v", ok, expectOK)
+			}
+			b, _ := os.ReadFile(out)
+			if s := string(b); s != expectOut {
+				t.Errorf("Unexpected output:\n got: %s\nwant: %s", s, expectOut)
+			}
+		}
+	}
+	t.Run("Success", test(cfg, `cat > "$OUT"`, true,
+		`{"annotations":{"k":"v"},"data":{"times":["2024-01-01T00:00:00Z"],"series":{"a":[1]}}}`,
+	))
+	t.Run("Failure", test(cfg, `echo failed > "$OUT"; exit 1`, false, "failed\n"))
+	t.Run("DryRun", test(cfg.WithDryRun(true), `echo run > "$OUT"`, true, ""))
+	t.Run("Env", func(t *testing.T) {
+		// The environment is read when the action runs.
+		action := automation.ActionExec("sh", []string{"-c", `echo "$ACTION_EXEC_TEST" > "$OUT"`}, map[string]string{"OUT": out})
+		t.Setenv("ACTION_EXEC_TEST", "set")
+		if !action(context.Background(), cfg, &result) {
+			t.Fatalf("Unexpected action result: false")
+		}
+		if b, _ := os.ReadFile(out); string(b) != "set\n" {
+			t.Errorf("Unexpected output:\n got: %s\nwant: %s", b, "set\n")
+		}
+	})
+	t.Run("Timeout", func(t *testing.T) {
+		action := automation.Exec{
+			Cmd:     "sh",
+			Args:    []string{"-c", "exec sleep 10"},
+			Timeout: 10 * time.Millisecond,
+		}.Action()
+		start := time.Now()
+		if action(context.Background(), cfg, &result) {
+			t.Errorf("Unexpected action result: true")
+		}
+		if d := time.Since(start); d > 5*time.Second {
+			t.Errorf("Program not killed after timeout; ran for %s", d)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automationcli
+
+import (
+	"fmt"
+	"io"
+	"runtime/debug"
+
+	"github.com/clarify/clarify-go/automation"
+)
+
+const modulePath = "github.com/clarify/clarify-go"
+
+// List writes the names of the routines matching cfg.Patterns to w.
+func (cfg *Config) List(w io.Writer) error {
+	routines := cfg.selectedRoutines()
+	if len(routines) == 0 && len(cfg.Patterns) > 0 {
+		return fmt.Errorf("no routines match patterns %q", cfg.Patterns)
+	}
+	routines.Print(w, "")
+	return nil
+}
+
+// Validate checks that credentials can be loaded, that option values are
+// valid, and that cfg.Patterns match at least one routine, without running any
+// routines or sending any requests. This allows deployments to be validated,
+// e.g. as a CI step.
+func (cfg *Config) Validate() error {
+	creds, err := cfg.credentials()
+	if err != nil {
+		return err
+	}
+	if err := creds.Validate(); err != nil {
+		return err
+	}
+	if cfg.Output != "" {
+		var f automation.ExportFormat
+		if err := f.UnmarshalText([]byte(cfg.Output)); err != nil {
+			return fmt.Errorf("-output: %w", err)
+		}
+	}
+	if len(cfg.Patterns) > 0 && len(cfg.selectedRoutines()) == 0 {
+		return fmt.Errorf("no routines match patterns %q", cfg.Patterns)
+	}
+	return nil
+}
+
+// PrintVersion writes version information for the running program, the
+// clarify-go module and the Go runtime to w.
+func PrintVersion(w io.Writer) {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		fmt.Fprintln(w, "version information not available")
+		return
+	}
+	fmt.Fprintf(w, "%s %s\n", bi.Main.Path, bi.Main.Version)
+	if bi.Main.Path != modulePath {
+		for _, dep := range bi.Deps {
+			if dep.Path == modulePath {
+				fmt.Fprintf(w, "%s %s\n", dep.Path, dep.Version)
+			}
+		}
+	}
+	fmt.Fprintf(w, "go %s\n", bi.GoVersion)
+}
@@ -1,4 +1,4 @@
-// Copyright 2023-2024 Searis AS
+// Copyright 2023-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -18,15 +18,20 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/clarify/clarify-go"
 	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/automation/health"
 	"github.com/clarify/clarify-go/internal/logging"
 	"github.com/clarify/clarify-go/jsonrpc"
 )
@@ -40,32 +45,80 @@ func init() {
 }
 
 const (
-	usageCredentials = "Specify the path to your Clarify Integration's credentials file."
-	usageUsername    = "Clarify integration ID to use as username; alternative to providing -credentials."
-	usagePassword    = "Clarify integration password; required when username is set, ignored otherwise."
-	usageVerbose     = "Set to true for printing logs at level DEBUG (the default is to log at INFO level)."
-	usageJSON        = "Set to true to output logs in compact JSON format."
-	usageDryRun      = "Signal to routines that they should mot write or persist changes."
-	usageEarlyOut    = "Signal to routines that they should abort at the first error."
+	usageConfig       = "Specify the path to a JSON configuration file with option values keyed by option name."
+	usageCredentials  = "Specify the path to your Clarify Integration's credentials file."
+	usageUsername     = "Clarify integration ID to use as username; alternative to providing -credentials."
+	usagePassword     = "Clarify integration password; required when username is set, ignored otherwise."
+	usageVerbose      = "Set to true for printing logs at level DEBUG (the default is to log at INFO level)."
+	usageJSON         = "Set to true to output logs in compact JSON format."
+	usageDryRun       = "Signal to routines that they should mot write or persist changes."
+	usageEarlyOut     = "Signal to routines that they should abort at the first error."
+	usageGracePeriod  = "Time to let in-flight requests complete after an interrupt before they are canceled; routines stop at their next checkpoint."
+	usageInterval     = "Run the selected routines repeatedly, waiting the specified duration between the end of one iteration and the start of the next; 0 runs routines once."
+	usageJitter       = "Add a random duration in the range [0,jitter) to each wait when -interval is set."
+	usageCircuit      = "Number of consecutive request failures before requests are rejected for a cool-down period; 0 disables the circuit breaker."
+	usageOutput       = "Set the output format for routines that write results, such as export routines; one of \"jsonl\", \"csv\" or \"table\". The default is chosen by each routine."
+	usageRunLog       = "Specify the path to a file where routine runs are appended in JSON Lines format for auditing."
+	usageLogAllowKeys = "Label and annotation keys to log values for in clear text when -v is set; \"*\" allows all keys. Other values are redacted. Can be repeated or comma-separated."
+	usageLogHash      = "Set to true to log redacted label and annotation values as truncated SHA-256 hashes, allowing equal values to be correlated."
+	usageHealthAddr   = "Serve liveness (/livez) and readiness (/readyz) probes over HTTP on the specified address, such as \":8080\"."
+	usageHealthStale  = "Fail the liveness probe when no routine run has completed within the specified duration; should exceed the longest expected iteration plus the wait between iterations. The default is three times -interval plus -jitter."
+	usageSet          = "Set a routine parameter value on the format <key>=<value>; keys can be prefixed with a routine path and dot, such as \"evaluate/detect-fire.threshold\". Can be repeated or comma-separated."
 )
 
-const usageFmt = `Usage: %[1]s [OPTIONS] [PATTERNS...]
+const usageFmt = `Usage: %[1]s [COMMAND] [OPTIONS] [PATTERNS...]
+
+COMMAND is one of:
+- run: Run routines matching PATTERNS (default).
+- list: List routines matching PATTERNS.
+- validate: Validate options, credentials and PATTERNS without running any
+  routines.
+- version: Print version information.
 
 PATTERNS are expected to match routine or sub-routine names. Sub-routines are
 matched via the slash character (/). The asterisk (*) can be used for wildcard
-matching of a single path level.
+matching of a single path level. To match a routine with the same name as a
+command, specify the command explicitly, e.g. "run list".
 
 Example: Given routines "a/b/b", "a/b/c" and "b/b/c", then:
 - "a/b" will match "a/b/b" and "a/b/c"
 - "*/b/c" will match "a/b/c" and "b/b/c"
+
+OPTIONS can be set from command-line flags, environment variables or a JSON
+configuration file (-config). Command-line flags take precedence over
+environment variables, which take precedence over the configuration file.
 `
 
+// Commands supported by ParseArguments.
+const (
+	CommandRun      = "run"
+	CommandList     = "list"
+	CommandValidate = "validate"
+	CommandVersion  = "version"
+)
+
 // Config describe a set of command-line options.
+//
+// Options can be set from three sources, listed in order of precedence:
+//
+//  1. Command-line flags.
+//  2. Environment variables; the flag name in upper case with dash (-)
+//     replaced by underscore (_), prefixed by "CLARIFY_".
+//  3. A JSON configuration file, where keys match the flag names.
 type Config struct {
+	// Command holds the command to perform; one of CommandRun, CommandList,
+	// CommandValidate or CommandVersion. The default is to run routines.
+	Command string
+
 	// AppName holds the app-name to use in automation. The default is set to
 	// match the Go main module import path.
 	AppName string
 
+	// ConfigFile describes the path to an optional JSON configuration file.
+	// The file must contain a JSON object where keys match flag names; values
+	// can be strings, numbers, booleans or arrays of strings.
+	ConfigFile string
+
 	// CredentialsFile describes the path to a valid Clarify integration JSON
 	// credentials file. This property is required if Username is set.
 	CredentialsFile string
@@ -98,6 +151,65 @@ type Config struct {
 	// EarlyOut, if set, signals the program to abort at the first routine
 	// error. The default is to continue to the next routine.
 	EarlyOut bool
+
+	// GracePeriod sets how long in-flight requests are allowed to complete after
+	// the context passed to Run is canceled. Routines are signaled to stop at
+	// their next checkpoint immediately. The default is to cancel in-flight
+	// requests immediately.
+	GracePeriod time.Duration
+
+	// Interval, if set, makes Run run the selected routines repeatedly until
+	// the context passed to Run is canceled, waiting Interval between the end
+	// of one iteration and the start of the next. Errors are logged per
+	// iteration. The default is to run routines once.
+	Interval time.Duration
+
+	// Jitter, if set, adds a random duration in the range [0,Jitter) to each
+	// wait when Interval is set. This can avoid many instances from sending
+	// requests at the same time.
+	Jitter time.Duration
+
+	// CircuitBreaker, if set, sets the number of consecutive request failures
+	// before further requests are rejected for a cool-down period. This
+	// protects long runs from hammering a degraded endpoint. The default (0)
+	// disables the circuit breaker.
+	CircuitBreaker int
+
+	// Output, if set, describes the output format for routines that write
+	// results, such as automation.ExportItems. The value is passed on to
+	// routines as the automation.OutputValueKey configuration value.
+	Output string
+
+	// RunLogFile, if set, describes the path to a file where a record of each
+	// routine run is appended in the JSON Lines format.
+	RunLogFile string
+
+	// LogAllowKeys lists label and annotation keys with values that are
+	// logged in clear text when Verbose is set. Other label and annotation
+	// values are redacted from logged requests. The special key "*" allows
+	// all keys.
+	LogAllowKeys []string
+
+	// LogHash, if set, replaces redacted values in logged requests with a
+	// truncated SHA-256 hash, rather than a fixed placeholder.
+	LogHash bool
+
+	// HealthAddr, if set, describes a TCP address for serving liveness and
+	// readiness probes over HTTP while routines are run; see the
+	// automation/health package. See HealthStaleAfter.
+	HealthAddr string
+
+	// HealthStaleAfter, if set, makes the liveness probe fail if no routine
+	// run has completed within the duration. As runs are recorded when they
+	// complete, the duration should exceed the longest expected iteration
+	// plus the wait between iterations. When Interval is set, the default is
+	// three times Interval plus Jitter. Otherwise, the default is to not
+	// check for stale runs.
+	HealthStaleAfter time.Duration
+
+	// Values holds routine parameter values that are passed to routines via
+	// automation.Config.WithValues.
+	Values map[string]string
 }
 
 // ParseArguments parses command-line arguments into a Config structure using
@@ -105,17 +217,32 @@ type Config struct {
 // method, the Patterns property is set from the remaining command-line
 // arguments after all flags (options) have been parsed.
 //
+// If the first argument is a command name, such as "run" or "validate", the
+// Command property is set and the argument is skipped. Otherwise, Command is
+// set to CommandRun.
+//
 // This function can be used by users who need to customize the configuration
 // before it's run, but do not need to customize command-line flags.
 func ParseArguments(routines automation.Routines, arguments []string) (*Config, error) {
 	cfg := Config{
+		Command:  CommandRun,
 		Routines: routines,
 	}
+	if len(arguments) > 0 {
+		switch arguments[0] {
+		case CommandRun, CommandList, CommandValidate, CommandVersion:
+			cfg.Command = arguments[0]
+			arguments = arguments[1:]
+		}
+	}
 	set := cfg.FlagSet(defaultProgName, flag.ContinueOnError)
 	err := set.Parse(arguments)
 	if err != nil {
 		return nil, err
 	}
+	if err := LoadConfigFile(set, cfg.ConfigFile); err != nil {
+		return nil, err
+	}
 	cfg.Patterns = append(cfg.Patterns, set.Args()...)
 	return &cfg, nil
 }
@@ -125,13 +252,14 @@ func ParseArguments(routines automation.Routines, arguments []string) (*Config,
 // is no flag for the AppName and Patterns property.
 //
 // This method can be used by users who need to customize the which command-line
-// flags are available in their application.
+// flags are available in their application. To respect the -config option, such
+// users should call LoadConfigFile after the flag set is parsed.
 func (cfg *Config) FlagSet(progName string, errorHandling flag.ErrorHandling) *flag.FlagSet {
 	if progName == "" {
 		progName = defaultProgName
 	}
 	adder := flagSetAdder{
-		envPrefix: "CLARIFY_",
+		envPrefix: envPrefix,
 		set:       flag.NewFlagSet(progName, errorHandling),
 	}
 	adder.set.Usage = func() {
@@ -143,6 +271,7 @@ func (cfg *Config) FlagSet(progName string, errorHandling flag.ErrorHandling) *f
 		adder.set.PrintDefaults()
 	}
 
+	adder.StringVar(&cfg.ConfigFile, "config", "", usageConfig)
 	adder.StringVar(&cfg.CredentialsFile, "credentials", "credentials.json", usageCredentials)
 	adder.StringVar(&cfg.Username, "username", "", usageUsername)
 	adder.StringVar(&cfg.Password.value, "password", "", usagePassword)
@@ -150,12 +279,35 @@ func (cfg *Config) FlagSet(progName string, errorHandling flag.ErrorHandling) *f
 	adder.BoolVar(&cfg.JSON, "json", false, usageJSON)
 	adder.BoolVar(&cfg.DryRun, "dry-run", false, usageDryRun)
 	adder.BoolVar(&cfg.EarlyOut, "early-out", false, usageEarlyOut)
+	adder.DurationVar(&cfg.GracePeriod, "grace-period", 0, usageGracePeriod)
+	adder.DurationVar(&cfg.Interval, "interval", 0, usageInterval)
+	adder.DurationVar(&cfg.Jitter, "jitter", 0, usageJitter)
+	adder.IntVar(&cfg.CircuitBreaker, "circuit-breaker", 0, usageCircuit)
+	adder.StringVar(&cfg.Output, "output", "", usageOutput)
+	adder.StringVar(&cfg.RunLogFile, "run-log", "", usageRunLog)
+	adder.StringSliceVar(&cfg.LogAllowKeys, "log-allow-keys", nil, usageLogAllowKeys)
+	adder.BoolVar(&cfg.LogHash, "log-hash", false, usageLogHash)
+	adder.StringVar(&cfg.HealthAddr, "health-addr", "", usageHealthAddr)
+	adder.DurationVar(&cfg.HealthStaleAfter, "health-stale-after", 0, usageHealthStale)
+	adder.KeyValuesVar(&cfg.Values, "set", usageSet)
 	return adder.set
 }
 
 // Run runs configuration from routines using configuration from cfg in
-// an arbitrary order.
+// an arbitrary order. If cfg.Interval is set, routines are run repeatedly until
+// ctx is canceled.
+//
+// When ctx is canceled, routines are signaled to stop at their next
+// checkpoint, while in-flight requests are given cfg.GracePeriod to complete.
 func (cfg *Config) Run(ctx context.Context) error {
+	stop := ctx
+	ctx, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+	defer cancel(nil)
+	unregister := context.AfterFunc(stop, func() {
+		time.AfterFunc(cfg.GracePeriod, func() { cancel(context.Cause(stop)) })
+	})
+	defer unregister()
+
 	opts := &slog.HandlerOptions{}
 	if cfg.Verbose {
 		opts.Level = slog.LevelDebug
@@ -179,51 +331,191 @@ func (cfg *Config) Run(ctx context.Context) error {
 	}
 
 	runCfg := automation.NewConfig(client).
+		WithStopContext(stop).
 		WithLogger(logger).
 		WithDryRun(cfg.DryRun).
 		WithEarlyOut(cfg.EarlyOut)
 	if cfg.AppName != "" {
 		runCfg = runCfg.WithAppName(cfg.AppName).WithLogger(logger)
 	}
+	if cfg.RunLogFile != "" {
+		runCfg = runCfg.WithRunRecorder(automation.NewFileRunRecorder(cfg.RunLogFile))
+	}
+	if cfg.HealthAddr != "" {
+		mon := health.NewMonitor(runCfg.RunRecorder())
+		switch {
+		case cfg.HealthStaleAfter > 0:
+			mon.StaleAfter = cfg.HealthStaleAfter
+		case cfg.Interval > 0:
+			mon.StaleAfter = 3 * (cfg.Interval + cfg.Jitter)
+		}
+		runCfg = runCfg.WithRunRecorder(mon)
+		shutdown, err := serveHealth(cfg.HealthAddr, mon, logger)
+		if err != nil {
+			return err
+		}
+		defer shutdown()
+	}
+	if cfg.Output != "" {
+		runCfg = runCfg.WithValues(map[string]any{automation.OutputValueKey: cfg.Output})
+	}
+	if len(cfg.Values) > 0 {
+		values := make(map[string]any, len(cfg.Values))
+		for k, v := range cfg.Values {
+			values[k] = v
+		}
+		runCfg = runCfg.WithValues(values)
+	}
 
-	var routines automation.Routines
+	routines := cfg.selectedRoutines()
+	if cfg.Interval <= 0 {
+		return routines.Do(ctx, runCfg)
+	}
+	return cfg.loop(ctx, stop, logger, routines, runCfg)
+}
+
+// loop runs routines repeatedly until stop is done, waiting on the timer
+// configured in runCfg between iterations.
+func (cfg *Config) loop(ctx, stop context.Context, logger *slog.Logger, routines automation.Routines, runCfg *automation.Config) error {
+	for i := 1; ; i++ {
+		start := runCfg.Now()
+		err := routines.Do(ctx, runCfg)
+		if stop.Err() != nil {
+			return context.Cause(stop)
+		}
+		attrs := []slog.Attr{
+			slog.Int("iteration", i),
+			slog.Duration("duration", runCfg.Now().Sub(start)),
+		}
+		if err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "Iteration failed", append(attrs, automation.AttrError(err))...)
+		} else {
+			logger.LogAttrs(ctx, slog.LevelInfo, "Iteration completed", attrs...)
+		}
+
+		wait := cfg.Interval
+		if cfg.Jitter > 0 {
+			wait += rand.N(cfg.Jitter)
+		}
+		select {
+		case <-stop.Done():
+			return context.Cause(stop)
+		case <-runCfg.After(wait):
+		}
+	}
+}
+
+// serveHealth starts serving health probes for mon on addr, and returns a
+// function for shutting down the server.
+func serveHealth(addr string, mon *health.Monitor, logger *slog.Logger) (func(), error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("health: %w", err)
+	}
+	srv := &http.Server{
+		Handler:           mon.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := srv.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.LogAttrs(context.Background(), slog.LevelError, "Health server failed", automation.AttrError(err))
+		}
+	}()
+	logger.LogAttrs(context.Background(), slog.LevelDebug, "Serving health probes", slog.String("addr", l.Addr().String()))
+	return func() { _ = srv.Close() }, nil
+}
+
+// selectedRoutines returns the routines matching cfg.Patterns.
+func (cfg *Config) selectedRoutines() automation.Routines {
 	if len(cfg.Patterns) == 0 {
-		routines = cfg.Routines
-	} else {
-		routines = cfg.Routines.SubRoutines(cfg.Patterns...)
+		return cfg.Routines
 	}
-	return routines.Do(ctx, runCfg)
+	return cfg.Routines.SubRoutines(cfg.Patterns...)
 }
 
-func (cfg *Config) client(ctx context.Context, logger *slog.Logger) (*clarify.Client, error) {
-	var creds *clarify.Credentials
+func (cfg *Config) credentials() (*clarify.Credentials, error) {
 	switch {
 	case cfg.Username != "" && cfg.Password.value == "":
 		return nil, fmt.Errorf("-password: required when -username is specified")
 	case cfg.CredentialsFile == "":
 		return nil, fmt.Errorf("-credentials: required when -username is not specified")
 	case cfg.Username != "":
-		creds = clarify.BasicAuthCredentials(cfg.Username, cfg.Password.value)
+		return clarify.BasicAuthCredentials(cfg.Username, cfg.Password.value), nil
 	default:
-		var err error
-		creds, err = clarify.CredentialsFromFile(cfg.CredentialsFile)
-		if err != nil {
-			return nil, err
-		}
+		return clarify.CredentialsFromFile(cfg.CredentialsFile)
+	}
+}
+
+func (cfg *Config) client(ctx context.Context, logger *slog.Logger) (*clarify.Client, error) {
+	creds, err := cfg.credentials()
+	if err != nil {
+		return nil, err
 	}
 
 	h, err := creds.HTTPHandler(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if logger != nil {
+		h.UnknownFieldsLogger = func(request jsonrpc.Request, trace string, err error) {
+			logger.LogAttrs(ctx, slog.LevelWarn, "Response contain unknown fields",
+				slog.String("method", request.Method),
+				slog.String("trace", trace),
+				automation.AttrError(err),
+			)
+		}
+	}
+	if logger != nil {
+		h.ResponseMetaLogger = func(request jsonrpc.Request, meta jsonrpc.ResponseMeta) {
+			if meta.Deprecated() || len(meta.Warnings) > 0 {
+				logger.LogAttrs(ctx, slog.LevelWarn, "Server reported deprecation or warnings",
+					slog.String("method", request.Method),
+					slog.String("apiVersion", meta.APIVersion),
+					slog.String("trace", meta.Trace),
+					slog.String("deprecation", meta.Deprecation),
+					slog.String("sunset", meta.Sunset),
+					slog.Any("warnings", meta.Warnings),
+				)
+			}
+		}
+	}
 	if cfg.Verbose && logger != nil {
+		h.ConnLogger = func(request jsonrpc.Request, info jsonrpc.ConnInfo) {
+			logger.LogAttrs(ctx, slog.LevelDebug, "HTTP connection",
+				slog.String("method", request.Method),
+				slog.String("id", request.RequestID),
+				slog.Bool("reused", info.Reused),
+				slog.Duration("idleTime", info.IdleTime),
+				slog.String("proto", info.Proto),
+			)
+		}
+		redactor := jsonrpc.Redactor{AllowKeys: cfg.LogAllowKeys, Hash: cfg.LogHash}
 		h.RequestLogger = func(request jsonrpc.Request, trace string, latency time.Duration, err error) {
 			var b bytes.Buffer
 			enc := json.NewEncoder(&b)
-			_ = enc.Encode(request)
+			_ = enc.Encode(redactor.Request(request))
 			logger.Debug("Performing JSON RPC request", "trace", trace, "latency", latency, "err", err, "body", json.RawMessage(b.Bytes()))
 		}
 	}
 
+	if cfg.CircuitBreaker > 0 {
+		cb := &jsonrpc.CircuitBreaker{
+			Handler:   h,
+			Threshold: cfg.CircuitBreaker,
+		}
+		if logger != nil {
+			cb.StateLogger = func(from, to jsonrpc.CircuitState, err error) {
+				attrs := []slog.Attr{
+					slog.String("from", string(from)),
+					slog.String("to", string(to)),
+				}
+				if err != nil {
+					attrs = append(attrs, automation.AttrError(err))
+				}
+				logger.LogAttrs(ctx, slog.LevelWarn, "Circuit breaker state changed", attrs...)
+			}
+		}
+		return clarify.NewClient(creds.Integration, cb), nil
+	}
 	return clarify.NewClient(creds.Integration, h), nil
 }
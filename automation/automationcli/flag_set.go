@@ -1,4 +1,4 @@
-// Copyright 2023-2024 Searis AS
+// Copyright 2023-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -16,12 +16,103 @@ package automationcli
 
 import (
 	"encoding"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
+const envPrefix = "CLARIFY_"
+
+// LoadConfigFile reads a JSON configuration file from name, and sets flags in
+// set that have neither been set via the command-line nor via an environment
+// variable. The file must contain a JSON object where keys match flag names.
+// Values can be strings, numbers, booleans or arrays of strings; arrays are
+// joined by comma (,).
+//
+// When an environment variable is set for a key in the file, the environment
+// value takes precedence. As invalid environment values are otherwise ignored
+// in favour of the flag default, an error is returned if such a value can't be
+// parsed. Environment values for boolean flags are parsed the same way as when
+// no configuration file is used, where "true" and "1" are true, and other
+// values are false.
+//
+// If name is empty, no action is taken.
+func LoadConfigFile(set *flag.FlagSet, name string) error {
+	if name == "" {
+		return nil
+	}
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("-config: %w", err)
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(b, &values); err != nil {
+		return fmt.Errorf("-config: %w", err)
+	}
+
+	explicit := make(map[string]bool)
+	set.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+	for k, raw := range values {
+		if set.Lookup(k) == nil {
+			return fmt.Errorf("-config: %s: no such option", k)
+		}
+		if explicit[k] {
+			continue
+		}
+		if env := envKey(envPrefix, k); os.Getenv(env) != "" {
+			v := os.Getenv(env)
+			if isBoolFlag(set.Lookup(k)) {
+				// Parse bool values like flagSetAdder.BoolVar.
+				v = strconv.FormatBool(parseEnvBool(v))
+			}
+			if err := set.Set(k, v); err != nil {
+				return fmt.Errorf("-config: %s: %s: %w", k, env, err)
+			}
+			continue
+		}
+		v, err := configValue(raw)
+		if err != nil {
+			return fmt.Errorf("-config: %s: %w", k, err)
+		}
+		if err := set.Set(k, v); err != nil {
+			return fmt.Errorf("-config: %s: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// configValue converts a JSON value to the string format accepted by flags.
+func configValue(raw json.RawMessage) (string, error) {
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return "", err
+	}
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case bool, float64:
+		return string(raw), nil
+	case []any:
+		elems := make([]string, 0, len(v))
+		for _, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return "", fmt.Errorf("array values must be strings")
+			}
+			elems = append(elems, s)
+		}
+		return strings.Join(elems, ","), nil
+	}
+	return "", fmt.Errorf("unsupported value type %T", v)
+}
+
 type flagSetAdder struct {
 	envPrefix string
 	set       *flag.FlagSet
@@ -46,15 +137,62 @@ func (set flagSetAdder) StringSliceVar(target *[]string, name string, fallback [
 	set.set.Var(stringSlice{target: target}, name, usage)
 }
 
+func (set flagSetAdder) DurationVar(target *time.Duration, name string, fallback time.Duration, usage string) {
+	k := envKey(set.envPrefix, name)
+	usage = fmt.Sprintf("%s (env: %s)", usage, k)
+	if v, err := time.ParseDuration(os.Getenv(k)); err == nil {
+		fallback = v
+	}
+	set.set.DurationVar(target, name, fallback, usage)
+}
+
+func (set flagSetAdder) IntVar(target *int, name string, fallback int, usage string) {
+	k := envKey(set.envPrefix, name)
+	usage = fmt.Sprintf("%s (env: %s)", usage, k)
+	if v, err := strconv.Atoi(os.Getenv(k)); err == nil {
+		fallback = v
+	}
+	set.set.IntVar(target, name, fallback, usage)
+}
+
 func (set flagSetAdder) BoolVar(target *bool, name string, fallback bool, usage string) {
 	k := envKey(set.envPrefix, name)
 	usage = fmt.Sprintf("%s (env: %s)", usage, k)
-	if v := strings.ToLower(os.Getenv(k)); v != "" {
-		fallback = (v == "true" || v == "1")
+	if v := os.Getenv(k); v != "" {
+		fallback = parseEnvBool(v)
 	}
 	set.set.BoolVar(target, name, fallback, usage)
 }
 
+// parseEnvBool returns true if v is "true" or "1", ignoring case. Other values
+// are interpreted as false.
+func parseEnvBool(v string) bool {
+	v = strings.ToLower(v)
+	return v == "true" || v == "1"
+}
+
+// isBoolFlag returns true if f is a boolean flag.
+func isBoolFlag(f *flag.Flag) bool {
+	bf, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && bf.IsBoolFlag()
+}
+
+// KeyValuesVar adds a flag that can be repeated, where each value is a comma
+// separated list of entries on the format <key>=<value>.
+func (set flagSetAdder) KeyValuesVar(target *map[string]string, name string, usage string) {
+	k := envKey(set.envPrefix, name)
+	usage = fmt.Sprintf("%s (env: %s)", usage, k)
+	if *target == nil {
+		*target = make(map[string]string)
+	}
+	value := keyValues{target: target}
+	if v := os.Getenv(k); v != "" {
+		// Like for other options, invalid environment values are ignored.
+		_ = value.Set(v)
+	}
+	set.set.Var(value, name, usage)
+}
+
 func envKey(prefix, name string) string {
 	return prefix + strings.ReplaceAll(strings.ToUpper(name), "-", "_")
 }
@@ -80,6 +218,41 @@ func (tf stringSlice) Set(v string) error {
 	return nil
 }
 
+type keyValues struct {
+	target *map[string]string
+}
+
+var _ flag.Value = keyValues{}
+
+func (kv keyValues) String() string {
+	if kv.target == nil {
+		return ""
+	}
+	entries := make([]string, 0, len(*kv.target))
+	for k, v := range *kv.target {
+		entries = append(entries, k+"="+v)
+	}
+	slices.Sort(entries)
+	return strings.Join(entries, ",")
+}
+
+func (kv keyValues) Set(v string) error {
+	if v == "" {
+		return nil
+	}
+	if *kv.target == nil {
+		*kv.target = make(map[string]string)
+	}
+	for _, entry := range strings.Split(v, ",") {
+		k, v, ok := strings.Cut(entry, "=")
+		if !ok || k == "" {
+			return fmt.Errorf("%q: must be on format <key>=<value>", entry)
+		}
+		(*kv.target)[k] = v
+	}
+	return nil
+}
+
 // Password helps prevent a string value from being exposed in logs or
 // marshalled as text or JSON.
 type Password struct {
@@ -0,0 +1,178 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automationcli_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/automation/automationcli"
+)
+
+func TestParseArgumentsConfigFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "config.json")
+	data := `{"credentials":"from-file.json","username":"file-user","v":true,"dry-run":true}`
+	if err := os.WriteFile(name, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CLARIFY_USERNAME", "env-user")
+
+	cfg, err := automationcli.ParseArguments(nil, []string{"-config", name, "-dry-run=false", "a/b"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.CredentialsFile != "from-file.json" {
+		t.Errorf("Unexpected CredentialsFile:\n got: %q\nwant: %q", cfg.CredentialsFile, "from-file.json")
+	}
+	if cfg.Username != "env-user" {
+		t.Errorf("Unexpected Username (env should take precedence):\n got: %q\nwant: %q", cfg.Username, "env-user")
+	}
+	if !cfg.Verbose {
+		t.Errorf("Unexpected Verbose:\n got: false\nwant: true")
+	}
+	if cfg.DryRun {
+		t.Errorf("Unexpected DryRun (flag should take precedence):\n got: true\nwant: false")
+	}
+	if len(cfg.Patterns) != 1 || cfg.Patterns[0] != "a/b" {
+		t.Errorf("Unexpected Patterns:\n got: %v\nwant: [a/b]", cfg.Patterns)
+	}
+
+	if _, err := automationcli.ParseArguments(nil, []string{"-config", name + ".missing"}); err == nil {
+		t.Errorf("Expected error for missing config file")
+	}
+}
+
+func TestParseArgumentsConfigFileBadEnv(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "config.json")
+	data := `{"interval":"5m"}`
+	if err := os.WriteFile(name, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CLARIFY_INTERVAL", "5 minutes")
+
+	_, err := automationcli.ParseArguments(nil, []string{"-config", name})
+	if err == nil {
+		t.Fatalf("Expected error for invalid CLARIFY_INTERVAL")
+	}
+	if expect := "CLARIFY_INTERVAL"; !strings.Contains(err.Error(), expect) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: error mentioning %s", err, expect)
+	}
+}
+
+func TestParseArgumentsBoolEnv(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(name, []byte(`{"dry-run":true}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	test := func(env string, expect, expectWithFile bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			t.Setenv("CLARIFY_DRY_RUN", env)
+
+			cfg, err := automationcli.ParseArguments(nil, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if cfg.DryRun != expect {
+				t.Errorf("Unexpected DryRun:\n got: %t\nwant: %t", cfg.DryRun, expect)
+			}
+
+			cfg, err = automationcli.ParseArguments(nil, []string{"-config", name})
+			if err != nil {
+				t.Fatalf("Unexpected error with config file: %v", err)
+			}
+			if cfg.DryRun != expectWithFile {
+				t.Errorf("Unexpected DryRun with config file:\n got: %t\nwant: %t", cfg.DryRun, expectWithFile)
+			}
+		}
+	}
+
+	t.Run("1", test("1", true, true))
+	t.Run("true", test("true", true, true))
+	t.Run("TRUE", test("TRUE", true, true))
+	t.Run("yes", test("yes", false, false))
+	t.Run("empty", test("", false, true))
+}
+
+func TestParseArgumentsInterval(t *testing.T) {
+	cfg, err := automationcli.ParseArguments(nil, []string{"-interval", "5m", "-jitter", "30s", "-health-stale-after", "1h"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Interval != 5*time.Minute {
+		t.Errorf("Unexpected Interval:\n got: %v\nwant: %v", cfg.Interval, 5*time.Minute)
+	}
+	if cfg.Jitter != 30*time.Second {
+		t.Errorf("Unexpected Jitter:\n got: %v\nwant: %v", cfg.Jitter, 30*time.Second)
+	}
+	if cfg.HealthStaleAfter != time.Hour {
+		t.Errorf("Unexpected HealthStaleAfter:\n got: %v\nwant: %v", cfg.HealthStaleAfter, time.Hour)
+	}
+}
+
+func TestParseArgumentsCommand(t *testing.T) {
+	test := func(args []string, expectCommand string, expectPatterns []string) func(t *testing.T) {
+		return func(t *testing.T) {
+			cfg, err := automationcli.ParseArguments(nil, args)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if cfg.Command != expectCommand {
+				t.Errorf("Unexpected Command:\n got: %q\nwant: %q", cfg.Command, expectCommand)
+			}
+			if !slices.Equal(cfg.Patterns, expectPatterns) {
+				t.Errorf("Unexpected Patterns:\n got: %v\nwant: %v", cfg.Patterns, expectPatterns)
+			}
+		}
+	}
+	t.Run("Flat", test([]string{"-dry-run", "a/b"}, automationcli.CommandRun, []string{"a/b"}))
+	t.Run("Run", test([]string{"run", "-dry-run", "list"}, automationcli.CommandRun, []string{"list"}))
+	t.Run("List", test([]string{"list", "a"}, automationcli.CommandList, []string{"a"}))
+	t.Run("Validate", test([]string{"validate"}, automationcli.CommandValidate, nil))
+	t.Run("Version", test([]string{"version"}, automationcli.CommandVersion, nil))
+}
+
+func TestConfigValidate(t *testing.T) {
+	routines := automation.Routines{
+		"a": automation.LogInfo("a"),
+		"b": automation.LogInfo("b"),
+	}
+	creds := filepath.Join(t.TempDir(), "credentials.json")
+	data := `{"apiUrl":"https://api.clarify.io/v1/","integration":"i1","credentials":{"type":"basic-auth","clientId":"i1","clientSecret":"s"}}`
+	if err := os.WriteFile(creds, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	test := func(cfg automationcli.Config, expectErr bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			cfg.Routines = routines
+			err := cfg.Validate()
+			if expectErr && err == nil {
+				t.Errorf("Expected error")
+			} else if !expectErr && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}
+	}
+	t.Run("Valid", test(automationcli.Config{CredentialsFile: creds, Patterns: []string{"a"}}, false))
+	t.Run("NoMatch", test(automationcli.Config{CredentialsFile: creds, Patterns: []string{"c"}}, true))
+	t.Run("MissingCredentials", test(automationcli.Config{CredentialsFile: creds + ".missing"}, true))
+	t.Run("BadOutput", test(automationcli.Config{CredentialsFile: creds, Output: "xml"}, true))
+}
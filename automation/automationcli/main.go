@@ -1,4 +1,4 @@
-// Copyright 2023-2024 Searis AS
+// Copyright 2023-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -26,9 +26,9 @@ import (
 	"github.com/clarify/clarify-go/automation"
 )
 
-// ParseAndRun parses command-line arguments and runs content from routines. On
-// completion, the function return an exit status that should be passed on to
-// os.Exit.
+// ParseAndRun parses command-line arguments and runs the selected command for
+// routines. On completion, the function return an exit status that should be
+// passed on to os.Exit.
 func ParseAndRun(routines automation.Routines) int {
 	cfg, err := ParseArguments(routines, os.Args[1:])
 	switch {
@@ -38,10 +38,32 @@ func ParseAndRun(routines automation.Routines) int {
 		fmt.Fprintf(os.Stderr, "%s: %s", os.Args[0], err.Error())
 		return 2
 	}
+
+	switch cfg.Command {
+	case CommandList:
+		err = cfg.List(os.Stdout)
+	case CommandValidate:
+		err = cfg.Validate()
+		if err == nil {
+			fmt.Fprintln(os.Stdout, "Configuration is valid.")
+		}
+	case CommandVersion:
+		PrintVersion(os.Stdout)
+	default:
+		return cfg.runWithSignals()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s", os.Args[0], err.Error())
+		return 1
+	}
+	return 0
+}
+
+func (cfg *Config) runWithSignals() int {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 	defer stop()
 
-	err = cfg.Run(ctx)
+	err := cfg.Run(ctx)
 	switch {
 	case errors.Is(err, context.Canceled):
 		fmt.Fprintf(os.Stderr, "%s: interrupt", os.Args[0])
@@ -0,0 +1,431 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package automationhttp offers a HTTP handler for triggering automation
+// routines on-demand, such as to let operators start an ad-hoc republish job
+// without shell access to the automation host.
+//
+// Serve the handler for your routines and configuration, and shut it down
+// before exiting to cancel runs in progress:
+//
+//	h := automationhttp.Handler(routines, cfg)
+//	go http.ListenAndServe(":8080", h)
+//	...
+//	err := h.Shutdown(ctx)
+//
+// The handler exposes the following endpoints:
+//
+//   - POST /run/{path...}: Start a run of the routines matching path, see
+//     automation.Routines.SubRoutines. Set the query parameter dry-run=true
+//     to run in dry-run mode. Responds with status 202 and the run state.
+//   - GET /runs: List the state of all tracked runs.
+//   - GET /runs/{id}: Get the state of a single run.
+//   - GET /runs/{id}/logs: Stream the log records for a run in the JSON
+//     Lines format, until the run completes.
+//
+// The handler does not perform any authentication, and should either be
+// served on a private network, or wrapped by an authenticating handler.
+package automationhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/clarify/clarify-go/automation"
+)
+
+// Run statuses used in Run, in addition to automation.RunStatusSucceeded and
+// automation.RunStatusFailed.
+const (
+	RunStatusRunning = "running"
+)
+
+const (
+	// maxRuns is the maximum number of runs to track. When exceeded, the
+	// oldest completed runs are forgotten.
+	maxRuns = 100
+
+	// maxLogSize is the maximum number of bytes of log output to keep per
+	// run. Log records written after the limit is reached are dropped.
+	maxLogSize = 1 << 20
+)
+
+// Run describe the state of a single run triggered via HTTP.
+type Run struct {
+	ID      string    `json:"id"`
+	Routine string    `json:"routine"`
+	Status  string    `json:"status"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Error   string    `json:"error,omitempty"`
+	DryRun  bool      `json:"dryRun,omitempty"`
+
+	// LogTruncated is set when log records for the run are dropped because
+	// the log output exceeds the size limit.
+	LogTruncated bool `json:"logTruncated,omitempty"`
+}
+
+// Server is a HTTP handler for triggering routines on-demand, and for
+// querying the status and logs of triggered runs. Runs are performed with a
+// context owned by the server, that is canceled by Shutdown.
+type Server struct {
+	routines automation.Routines
+	cfg      *automation.Config
+	mux      *http.ServeMux
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	lock   sync.Mutex
+	closed bool
+	lastID int
+	runs   map[string]*run
+}
+
+// Handler returns a HTTP handler for triggering routines on-demand using cfg,
+// and for querying the status and logs of triggered runs. Runs that include
+// any of the same routines can not be in progress at the same time. See the
+// package documentation for a list of endpoints.
+func Handler(routines automation.Routines, cfg *automation.Config) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Server{
+		routines: routines,
+		cfg:      cfg,
+		mux:      http.NewServeMux(),
+		ctx:      ctx,
+		cancel:   cancel,
+		runs:     make(map[string]*run),
+	}
+	s.mux.HandleFunc("POST /run/{path...}", s.startRun)
+	s.mux.HandleFunc("GET /runs", s.listRuns)
+	s.mux.HandleFunc("GET /runs/{id}", s.getRun)
+	s.mux.HandleFunc("GET /runs/{id}/logs", s.streamLogs)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Shutdown stops s from starting new runs, cancels the context of runs in
+// progress, and waits for them to complete. If ctx is done before the runs
+// complete, the context error is returned.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.lock.Lock()
+	s.closed = true
+	s.lock.Unlock()
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Server) startRun(w http.ResponseWriter, r *http.Request) {
+	path := r.PathValue("path")
+	routines := s.routines.SubRoutines(path)
+	if path == "" || len(routines) == 0 {
+		writeError(w, http.StatusNotFound, "no routines match path")
+		return
+	}
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry-run"))
+	names := routineNames(routines, "")
+
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		writeError(w, http.StatusServiceUnavailable, "server is shutting down")
+		return
+	}
+	for _, existing := range s.runs {
+		if st := existing.state(); st.Status == RunStatusRunning && overlaps(existing.names, names) {
+			s.lock.Unlock()
+			writeError(w, http.StatusConflict, "run "+st.ID+" of overlapping routines is already in progress")
+			return
+		}
+	}
+	s.lastID++
+	rn := newRun(Run{
+		ID:      strconv.Itoa(s.lastID),
+		Routine: path,
+		Status:  RunStatusRunning,
+		Start:   s.cfg.Now(),
+		DryRun:  dryRun || s.cfg.DryRun(),
+	}, names)
+	s.runs[rn.run.ID] = rn
+	s.evict()
+	s.wg.Add(1)
+	s.lock.Unlock()
+
+	cfg := s.cfg.WithLogger(rn.logger(s.cfg.BaseLogger()))
+	if dryRun {
+		cfg = cfg.WithDryRun(true)
+	}
+	go func() {
+		defer s.wg.Done()
+		err := routines.Do(s.ctx, cfg)
+		rn.finish(cfg.Now(), err)
+	}()
+
+	w.Header().Set("Location", "/runs/"+rn.run.ID)
+	writeJSON(w, http.StatusAccepted, rn.state())
+}
+
+// evict removes the oldest completed runs while there are more than maxRuns
+// runs tracked. Must be called with s.lock held.
+func (s *Server) evict() {
+	if len(s.runs) <= maxRuns {
+		return
+	}
+	states := make([]Run, 0, len(s.runs))
+	for _, rn := range s.runs {
+		if s := rn.state(); s.Status != RunStatusRunning {
+			states = append(states, s)
+		}
+	}
+	slices.SortFunc(states, func(a, b Run) int { return a.Start.Compare(b.Start) })
+	for _, st := range states {
+		if len(s.runs) <= maxRuns {
+			break
+		}
+		delete(s.runs, st.ID)
+	}
+}
+
+// routineNames returns the full names of the leaf routines in routines, where
+// nested names are joined by slash (/) and prefixed by prefix.
+func routineNames(routines automation.Routines, prefix string) []string {
+	var names []string
+	for k, r := range routines {
+		if sub, ok := r.(automation.Routines); ok {
+			names = append(names, routineNames(sub, prefix+k+"/")...)
+			continue
+		}
+		names = append(names, prefix+k)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// overlaps returns true if the sorted name lists a and b share a name.
+func overlaps(a, b []string) bool {
+	return slices.ContainsFunc(a, func(name string) bool {
+		_, found := slices.BinarySearch(b, name)
+		return found
+	})
+}
+
+func (s *Server) lookup(id string) (*run, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	rn, ok := s.runs[id]
+	return rn, ok
+}
+
+func (s *Server) listRuns(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	runs := slices.Collect(maps.Values(s.runs))
+	s.lock.Unlock()
+
+	states := make([]Run, 0, len(runs))
+	for _, rn := range runs {
+		states = append(states, rn.state())
+	}
+	slices.SortFunc(states, func(a, b Run) int { return a.Start.Compare(b.Start) })
+	writeJSON(w, http.StatusOK, states)
+}
+
+func (s *Server) getRun(w http.ResponseWriter, r *http.Request) {
+	rn, ok := s.lookup(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "run not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, rn.state())
+}
+
+func (s *Server) streamLogs(w http.ResponseWriter, r *http.Request) {
+	rn, ok := s.lookup(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "run not found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/jsonl")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+
+	var offset int
+	for {
+		b, changed, done := rn.logs(offset)
+		if len(b) > 0 {
+			if _, err := w.Write(b); err != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+			offset += len(b)
+			continue
+		}
+		if done {
+			return
+		}
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// run tracks the state and log output of a single run.
+type run struct {
+	names []string
+
+	lock    sync.Mutex
+	run     Run
+	log     bytes.Buffer
+	changed chan struct{}
+}
+
+func newRun(r Run, names []string) *run {
+	return &run{
+		names:   names,
+		run:     r,
+		changed: make(chan struct{}),
+	}
+}
+
+// logger returns a logger that writes records to rn's log buffer, and also
+// to base if it's not nil.
+func (rn *run) logger(base *slog.Logger) *slog.Logger {
+	var h slog.Handler = slog.NewJSONHandler(rn, nil)
+	if base != nil {
+		h = teeHandler{base.Handler(), h}
+	}
+	return slog.New(h)
+}
+
+// Write appends p to the log buffer, and notifies log readers. The log handler
+// writes one record per call, so that records exceeding maxLogSize can be
+// dropped without leaving partial lines.
+func (rn *run) Write(p []byte) (int, error) {
+	rn.lock.Lock()
+	defer rn.lock.Unlock()
+	if rn.log.Len()+len(p) > maxLogSize {
+		rn.run.LogTruncated = true
+		return len(p), nil
+	}
+	n, err := rn.log.Write(p)
+	rn.notify()
+	return n, err
+}
+
+// notify wakes up all log readers. Must be called with rn.lock held.
+func (rn *run) notify() {
+	close(rn.changed)
+	rn.changed = make(chan struct{})
+}
+
+func (rn *run) finish(end time.Time, err error) {
+	rn.lock.Lock()
+	defer rn.lock.Unlock()
+	rn.run.End = end
+	rn.run.Status = automation.RunStatusSucceeded
+	if err != nil {
+		rn.run.Status = automation.RunStatusFailed
+		rn.run.Error = err.Error()
+	}
+	rn.notify()
+}
+
+func (rn *run) state() Run {
+	rn.lock.Lock()
+	defer rn.lock.Unlock()
+	return rn.run
+}
+
+// logs returns a copy of the log output after offset, a channel that is
+// closed on the next change, and whether the run has completed.
+func (rn *run) logs(offset int) (_ []byte, changed <-chan struct{}, done bool) {
+	rn.lock.Lock()
+	defer rn.lock.Unlock()
+	b := bytes.Clone(rn.log.Bytes()[offset:])
+	return b, rn.changed, rn.run.Status != RunStatusRunning
+}
+
+// teeHandler passes log records to all enabled handlers.
+type teeHandler []slog.Handler
+
+func (th teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return slices.ContainsFunc(th, func(h slog.Handler) bool { return h.Enabled(ctx, level) })
+}
+
+func (th teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range th {
+		if h.Enabled(ctx, r.Level) {
+			errs = append(errs, h.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (th teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := make(teeHandler, len(th))
+	for i, h := range th {
+		out[i] = h.WithAttrs(attrs)
+	}
+	return out
+}
+
+func (th teeHandler) WithGroup(name string) slog.Handler {
+	out := make(teeHandler, len(th))
+	for i, h := range th {
+		out[i] = h.WithGroup(name)
+	}
+	return out
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, struct {
+		Error string `json:"error"`
+	}{Error: msg})
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automationhttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/automation/automationhttp"
+)
+
+func TestHandler(t *testing.T) {
+	release := make(chan struct{})
+	routines := automation.Routines{
+		"publish": automation.RoutineFunc(func(ctx context.Context, cfg *automation.Config) error {
+			cfg.Logger().InfoContext(ctx, "Publishing", "dryRun", cfg.DryRun())
+			<-release
+			return nil
+		}),
+		"fail": automation.RoutineFunc(func(ctx context.Context, cfg *automation.Config) error {
+			return errors.New("boom")
+		}),
+	}
+	cfg := automation.NewConfig(nil).WithLogger(nil)
+	srv := httptest.NewServer(automationhttp.Handler(routines, cfg))
+	defer srv.Close()
+
+	do := func(method, path string, expectStatus int, v any) {
+		t.Helper()
+		req, err := http.NewRequest(method, srv.URL+path, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != expectStatus {
+			t.Fatalf("Unexpected status for %s %s:\n got: %d\nwant: %d", method, path, resp.StatusCode, expectStatus)
+		}
+		if v != nil {
+			if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+	}
+
+	do(http.MethodPost, "/run/missing", http.StatusNotFound, nil)
+	do(http.MethodGet, "/run/publish", http.StatusMethodNotAllowed, nil)
+
+	var run automationhttp.Run
+	do(http.MethodPost, "/run/publish?dry-run=true", http.StatusAccepted, &run)
+	if run.Status != automationhttp.RunStatusRunning || run.Routine != "publish" || !run.DryRun {
+		t.Errorf("Unexpected run: %+v", run)
+	}
+	do(http.MethodPost, "/run/publish", http.StatusConflict, nil)
+
+	// Stream logs while the run completes.
+	resp, err := http.Get(srv.URL + "/runs/" + run.ID + "/logs")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	close(release)
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var record struct {
+		Level   slog.Level `json:"level"`
+		Msg     string     `json:"msg"`
+		Routine string     `json:"routine"`
+		DryRun  bool       `json:"dryRun"`
+	}
+	line, _, _ := strings.Cut(string(b), "\n")
+	if err := json.Unmarshal([]byte(line), &record); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if record.Msg != "Publishing" || !record.DryRun {
+		t.Errorf("Unexpected log record:\n got: %s", line)
+	}
+
+	do(http.MethodGet, "/runs/"+run.ID, http.StatusOK, &run)
+	if run.Status != automation.RunStatusSucceeded || run.End.IsZero() {
+		t.Errorf("Unexpected run: %+v", run)
+	}
+	do(http.MethodGet, "/runs/missing", http.StatusNotFound, nil)
+
+	var failed automationhttp.Run
+	do(http.MethodPost, "/run/fail", http.StatusAccepted, &failed)
+	resp2, err := http.Get(srv.URL + "/runs/" + failed.ID + "/logs")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_, _ = io.Copy(io.Discard, resp2.Body)
+	resp2.Body.Close()
+	do(http.MethodGet, "/runs/"+failed.ID, http.StatusOK, &failed)
+	if failed.Status != automation.RunStatusFailed || failed.Error == "" {
+		t.Errorf("Unexpected run: %+v", failed)
+	}
+
+	var runs []automationhttp.Run
+	do(http.MethodGet, "/runs", http.StatusOK, &runs)
+	if len(runs) != 2 || runs[0].ID != run.ID {
+		t.Errorf("Unexpected runs: %+v", runs)
+	}
+}
+
+func TestHandlerShutdown(t *testing.T) {
+	started := make(chan struct{})
+	routines := automation.Routines{
+		"jobs": automation.Routines{
+			"wait": automation.RoutineFunc(func(ctx context.Context, cfg *automation.Config) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			}),
+			"other": automation.RoutineFunc(func(ctx context.Context, cfg *automation.Config) error {
+				return nil
+			}),
+		},
+	}
+	cfg := automation.NewConfig(nil).WithLogger(nil)
+	h := automationhttp.Handler(routines, cfg)
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	post := func(path string, expectStatus int) {
+		t.Helper()
+		resp, err := http.Post(srv.URL+path, "", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != expectStatus {
+			t.Fatalf("Unexpected status for %s:\n got: %d\nwant: %d", path, resp.StatusCode, expectStatus)
+		}
+	}
+
+	post("/run/jobs/wait", http.StatusAccepted)
+	<-started
+	post("/run/jobs", http.StatusConflict)
+	post("/run/jobs/other", http.StatusAccepted)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := h.Shutdown(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	post("/run/jobs/other", http.StatusServiceUnavailable)
+
+	var runs []automationhttp.Run
+	resp, err := http.Get(srv.URL + "/runs")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&runs); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, run := range runs {
+		if run.Status == automationhttp.RunStatusRunning {
+			t.Errorf("Unexpected run in progress after shutdown: %+v", run)
+		}
+	}
+}
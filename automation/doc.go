@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -23,8 +23,34 @@
 //   - PublishSignals: Define filters that inspect signals in your organization,
 //     tracks changes to signal input, and publish them as new or existing items.
 //     Apply custom transforms to improve your item meta-data before save.
+//     Use LoadPublishSignals to declare the routines in a JSON file, with
+//     transforms referenced by name from a TransformRegistry.
+//   - UpdateItems: Apply transforms to already published items in bulk, e.g.
+//     to clean up labels or annotations.
+//   - SetVisibility: Show or hide published items in bulk.
 //   - EvaluateActions: Run the powerful evaluate method against your Clarify
 //     instance to detect conditions and trigger custom actions.
+//   - EvaluateCompare: Evaluate the current and a time-shifted window, e.g.
+//     the same hours last week, and trigger actions on the difference.
+//   - Hysteresis: Track an alert state for an evaluated series, and only
+//     trigger actions on state transitions.
+//   - BackfillData: Copy historical data from existing items into signals of
+//     another integration, resuming from the last completed time window.
+//   - PollSource: Poll current values from an external system, such as an OPC
+//     UA server, and insert them to Clarify. Implement the Source interface,
+//     or use NodeSource, to connect your system.
+//   - Heartbeat: Insert keep-alive values for idle inputs at a configurable
+//     interval, to avoid triggering gap detection downstream.
+//   - SelfMetrics: Wrap a routine to insert run durations, failures and
+//     reported counts as signals, to monitor your automation in Clarify.
+//   - ExportItems,ExportSignals: Write items or signals matching a filter to
+//     a writer in the JSONL or CSV format, e.g. for inventory reports.
+//   - InsertJournal: Not a routine, but a helper for custom backfills that
+//     records completed time windows per input, and reports failed inserts.
+//   - ReportDuplicateItems: Scan signals across integrations, and report
+//     published items that are likely duplicates.
+//   - ReportDataQuality: Report gaps and data coverage for items matching a
+//     filter, and optionally insert the coverage as signals.
 //   - LogDebug,LogInfo,LogWarn,LogError: Log a message to the console; useful
 //     for debugging and testing.
 package automation
@@ -0,0 +1,185 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package health offers HTTP handlers for reporting the state of long-running
+// automation binaries, suitable for Kubernetes liveness and readiness probes.
+//
+// Register a Monitor as the run recorder of your automation configuration,
+// and serve its handler:
+//
+//	mon := health.NewMonitor(nil)
+//	mon.StaleAfter = 15 * time.Minute
+//	cfg = cfg.WithRunRecorder(mon)
+//	go http.ListenAndServe(":8080", mon.Handler())
+//
+// The handler exposes /livez, reporting if routines are still being run, and
+// /readyz, reporting if the last run of each routine succeeded. Both
+// endpoints respond with the per-routine state in JSON.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/clarify/clarify-go/automation"
+)
+
+// RoutineState describe the tracked state of a single routine.
+type RoutineState struct {
+	LastStart   time.Time `json:"lastStart"`
+	LastEnd     time.Time `json:"lastEnd"`
+	LastStatus  string    `json:"lastStatus"`
+	LastError   string    `json:"lastError,omitempty"`
+	LastSuccess time.Time `json:"lastSuccess"`
+	Runs        int       `json:"runs"`
+	Failures    int       `json:"failures"`
+}
+
+var _ automation.RunRecorder = (*Monitor)(nil)
+
+// Monitor is a run recorder that tracks the state of each routine, and
+// reports it via HTTP. It's safe for concurrent use.
+type Monitor struct {
+	// StaleAfter, if set, makes the liveness endpoint fail when no routine
+	// run has completed within the duration. It should be set to a
+	// duration well above the expected interval between runs. The default is
+	// to report live as long as the process responds.
+	StaleAfter time.Duration
+
+	next    automation.RunRecorder
+	started time.Time
+
+	lock     sync.Mutex
+	routines map[string]RoutineState
+	lastEnd  time.Time
+}
+
+// NewMonitor returns a new monitor. If next is not nil, all records are
+// passed on to next, e.g. to also write a run log.
+func NewMonitor(next automation.RunRecorder) *Monitor {
+	return &Monitor{
+		next:     next,
+		started:  time.Now(),
+		routines: make(map[string]RoutineState),
+	}
+}
+
+// RecordRun updates the state of the routine in r.
+func (m *Monitor) RecordRun(ctx context.Context, r automation.RunRecord) error {
+	m.lock.Lock()
+	s := m.routines[r.Routine]
+	s.LastStart = r.Start
+	s.LastEnd = r.End
+	s.LastStatus = r.Status
+	s.LastError = r.Error
+	s.Runs++
+	if r.Status == automation.RunStatusSucceeded {
+		s.LastSuccess = r.End
+	} else {
+		s.Failures++
+	}
+	m.routines[r.Routine] = s
+	if r.End.After(m.lastEnd) {
+		m.lastEnd = r.End
+	}
+	m.lock.Unlock()
+
+	if m.next != nil {
+		return m.next.RecordRun(ctx, r)
+	}
+	return nil
+}
+
+// State returns a copy of the current state, keyed by routine path.
+func (m *Monitor) State() map[string]RoutineState {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return maps.Clone(m.routines)
+}
+
+// Live returns true if a routine run has completed within StaleAfter, or if
+// less than StaleAfter has passed since the monitor was created. If
+// StaleAfter is not set, Live always returns true.
+func (m *Monitor) Live() bool {
+	if m.StaleAfter <= 0 {
+		return true
+	}
+	m.lock.Lock()
+	last := m.lastEnd
+	m.lock.Unlock()
+	if last.Before(m.started) {
+		last = m.started
+	}
+	return time.Since(last) < m.StaleAfter
+}
+
+// Ready returns true if at least one routine run has been recorded, and the
+// last run of each routine succeeded.
+func (m *Monitor) Ready() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if len(m.routines) == 0 {
+		return false
+	}
+	for _, s := range m.routines {
+		if s.LastStatus != automation.RunStatusSucceeded {
+			return false
+		}
+	}
+	return true
+}
+
+// LivenessHandler returns a handler that responds with status 200 if m is
+// live, and 503 otherwise. See Live.
+func (m *Monitor) LivenessHandler() http.Handler {
+	return m.handler(m.Live)
+}
+
+// ReadinessHandler returns a handler that responds with status 200 if m is
+// ready, and 503 otherwise. See Ready.
+func (m *Monitor) ReadinessHandler() http.Handler {
+	return m.handler(m.Ready)
+}
+
+// Handler returns a handler serving LivenessHandler at /livez and
+// ReadinessHandler at /readyz.
+func (m *Monitor) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/livez", m.LivenessHandler())
+	mux.Handle("/readyz", m.ReadinessHandler())
+	return mux
+}
+
+func (m *Monitor) handler(ok func() bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := http.StatusOK
+		if !ok() {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(struct {
+			OK       bool                    `json:"ok"`
+			Routines map[string]RoutineState `json:"routines"`
+		}{
+			OK:       status == http.StatusOK,
+			Routines: m.State(),
+		})
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/automation/health"
+)
+
+func TestMonitor(t *testing.T) {
+	mon := health.NewMonitor(nil)
+	mon.StaleAfter = time.Hour
+	h := mon.Handler()
+
+	expectStatus := func(path string, expect int) func(t *testing.T) {
+		return func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+			if w.Code != expect {
+				t.Errorf("Unexpected status for %s:\n got: %d\nwant: %d", path, w.Code, expect)
+			}
+		}
+	}
+	record := func(routine, status string, end time.Time) {
+		err := mon.RecordRun(context.Background(), automation.RunRecord{
+			Routine: routine,
+			Start:   end.Add(-time.Second),
+			End:     end,
+			Status:  status,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	t.Run("Initial/livez", expectStatus("/livez", http.StatusOK))
+	t.Run("Initial/readyz", expectStatus("/readyz", http.StatusServiceUnavailable))
+
+	now := time.Now()
+	record("a", automation.RunStatusSucceeded, now)
+	record("b", automation.RunStatusFailed, now)
+	t.Run("Failed/livez", expectStatus("/livez", http.StatusOK))
+	t.Run("Failed/readyz", expectStatus("/readyz", http.StatusServiceUnavailable))
+
+	record("b", automation.RunStatusSucceeded, now)
+	t.Run("Succeeded/readyz", expectStatus("/readyz", http.StatusOK))
+	if s := mon.State()["b"]; s.Runs != 2 || s.Failures != 1 || !s.LastSuccess.Equal(now) {
+		t.Errorf("Unexpected state for b: %+v", s)
+	}
+
+	mon.StaleAfter = time.Nanosecond
+	t.Run("Stale/livez", expectStatus("/livez", http.StatusServiceUnavailable))
+}
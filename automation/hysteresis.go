@@ -0,0 +1,189 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+)
+
+// AnnotationHysteresisState is set on the evaluation result by a Hysteresis
+// action to the new alert state on state transitions.
+const AnnotationHysteresisState = AnnotationPrefix + "hysteresis/state"
+
+// Alert states used by Hysteresis.
+const (
+	AlertStateOK    = "ok"
+	AlertStateAlert = "alert"
+)
+
+// Hysteresis describe an alert state machine that is driven by the values of a
+// single series in an evaluation result. The state is persisted in the
+// configured state store, and actions are only triggered on state transitions.
+// This avoids repeated notifications when a value flaps around a single
+// threshold.
+//
+// The state transitions from OK to ALERT when values have been greater than or
+// equal to OnThreshold for at least MinOnDuration, and from ALERT to OK when
+// values have been less than or equal to OffThreshold for at least
+// MinOffDuration. Values in-between the two thresholds keep the current state.
+type Hysteresis struct {
+	// Series is the series key (alias) in the evaluation result to inspect.
+	Series string
+
+	// OnThreshold and OffThreshold describe the thresholds for entering and
+	// leaving the alert state. OffThreshold should be less than or equal to
+	// OnThreshold.
+	OnThreshold, OffThreshold float64
+
+	// MinOnDuration and MinOffDuration describe for how long a threshold must
+	// be crossed before a state transition occurs. The duration is measured
+	// between timestamps in the series. The default is to transition on the
+	// first value that crosses the threshold.
+	MinOnDuration, MinOffDuration time.Duration
+
+	// StateKey sets the key used to store the alert state in the state store.
+	// The default is "hysteresis/" followed by the routine path and series
+	// name.
+	StateKey string
+
+	// OnAlert and OnOK lists chains of actions to run on transition to the
+	// alert and OK state respectively.
+	OnAlert, OnOK []ActionFunc
+}
+
+// hysteresisState describe the stored state of a Hysteresis action.
+type hysteresisState struct {
+	State        string           `json:"state"`
+	PendingSince fields.Timestamp `json:"pendingSince,omitempty"`
+	LastTime     fields.Timestamp `json:"lastTime,omitempty"`
+}
+
+// Action returns an action that updates the alert state from the evaluation
+// result, and runs the OnAlert or OnOK actions on state transitions. The
+// action returns true if a state transition occurred.
+//
+// The new state is not stored when the DryRun configuration is set.
+func (h Hysteresis) Action() ActionFunc {
+	return func(ctx context.Context, cfg *Config, result *EvaluateResult) bool {
+		logger := cfg.Logger()
+		state := cfg.StateStore()
+
+		stateKey := h.StateKey
+		if stateKey == "" {
+			stateKey = "hysteresis/" + cfg.RoutinePath() + "/" + h.Series
+		}
+
+		prev, err := h.load(ctx, state, stateKey)
+		if err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "Failed to load alert state", AttrError(err))
+			return false
+		}
+		next := h.next(prev, result)
+
+		if !cfg.DryRun() {
+			if err := h.store(ctx, state, stateKey, next); err != nil {
+				logger.LogAttrs(ctx, slog.LevelError, "Failed to store alert state", AttrError(err))
+				return false
+			}
+		}
+		if next.State == prev.State {
+			return false
+		}
+
+		logger.LogAttrs(ctx, slog.LevelInfo, "Alert state changed",
+			slog.String("series", h.Series),
+			slog.String("from", prev.State),
+			slog.String("to", next.State),
+		)
+		result.Annotations.Set(AnnotationHysteresisState, next.State)
+		actions := h.OnOK
+		if next.State == AlertStateAlert {
+			actions = h.OnAlert
+		}
+		for _, action := range actions {
+			if !action(ctx, cfg, result) {
+				break
+			}
+		}
+		return true
+	}
+}
+
+// next returns the state after processing all values in the result series
+// that are newer than the last processed time.
+func (h Hysteresis) next(s hysteresisState, result *EvaluateResult) hysteresisState {
+	series := result.Data[h.Series]
+	for _, t := range series.Timestamps() {
+		if t <= s.LastTime {
+			continue
+		}
+		s.LastTime = t
+		v := series[t]
+
+		var crossed bool
+		var minDuration time.Duration
+		switch s.State {
+		case AlertStateAlert:
+			crossed, minDuration = v <= h.OffThreshold, h.MinOffDuration
+		default:
+			crossed, minDuration = v >= h.OnThreshold, h.MinOnDuration
+		}
+		switch {
+		case !crossed:
+			s.PendingSince = 0
+			continue
+		case s.PendingSince == 0:
+			s.PendingSince = t
+		}
+		if t.Sub(s.PendingSince) >= minDuration {
+			s.PendingSince = 0
+			if s.State == AlertStateAlert {
+				s.State = AlertStateOK
+			} else {
+				s.State = AlertStateAlert
+			}
+		}
+	}
+	return s
+}
+
+func (h Hysteresis) load(ctx context.Context, state StateStore, key string) (hysteresisState, error) {
+	s := hysteresisState{State: AlertStateOK}
+	v, found, err := state.Load(ctx, key)
+	switch {
+	case err != nil:
+		return s, err
+	case !found:
+		return s, nil
+	}
+	if err := json.Unmarshal([]byte(v), &s); err != nil {
+		return s, fmt.Errorf("decode state: %w", err)
+	}
+	return s, nil
+}
+
+func (h Hysteresis) store(ctx context.Context, state StateStore, key string, s hysteresisState) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return state.Store(ctx, key, string(b))
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestHysteresis(t *testing.T) {
+	ctx := context.Background()
+	cfg := automation.NewConfig(clarify.NewClient("integration", nil)).
+		WithLogger(nil).
+		WithStateStore(automation.NewMemoryStateStore())
+
+	var events []string
+	record := func(event string) automation.ActionFunc {
+		return func(ctx context.Context, cfg *automation.Config, result *automation.EvaluateResult) bool {
+			events = append(events, event)
+			return true
+		}
+	}
+	action := automation.Hysteresis{
+		Series:        "temp",
+		OnThreshold:   10,
+		OffThreshold:  5,
+		MinOnDuration: time.Minute,
+		StateKey:      "test",
+		OnAlert:       []automation.ActionFunc{record("alert")},
+		OnOK:          []automation.ActionFunc{record("ok")},
+	}.Action()
+
+	t0 := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	run := func(values ...float64) bool {
+		series := make(views.DataSeries, len(values))
+		for _, v := range values {
+			series[t0] = v
+			t0 = t0.Add(time.Minute)
+		}
+		return action(ctx, cfg, &automation.EvaluateResult{
+			Data: views.DataFrame{"temp": series},
+		})
+	}
+
+	// A single value above the on threshold is not enough to trigger an alert
+	// when a minimum duration is set.
+	if run(1, 11, 6) {
+		t.Errorf("Expected no transition for short spike")
+	}
+	// Values between the thresholds does not cause a flap back to OK.
+	if !run(11, 12, 7, 8) {
+		t.Errorf("Expected transition to alert")
+	}
+	if run(9, 6, 12) {
+		t.Errorf("Expected no transition while above off threshold")
+	}
+	if !run(4) {
+		t.Errorf("Expected transition to OK")
+	}
+	if expect := []string{"alert", "ok"}; !slices.Equal(events, expect) {
+		t.Errorf("Unexpected events:\n got: %v\nwant: %v", events, expect)
+	}
+}
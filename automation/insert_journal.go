@@ -0,0 +1,363 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+// InsertJournal records which time windows have been inserted for each signal
+// input in a state store. This allows jobs that insert data for many inputs
+// and time windows, such as backfills, to be restarted safely: windows that
+// are already completed are skipped, and inserts that were interrupted are
+// reported, so that their data can be inspected or cleaned up.
+//
+// The journal is stored under several state keys with the journal key as a
+// prefix: the completed windows are stored per input, so that each insert
+// only rewrites the entries for the inserted inputs.
+//
+// Use NewInsertJournal to initialize a journal. A journal is safe for
+// concurrent use, but a state key should not be shared between journals.
+type InsertJournal struct {
+	lock     sync.Mutex
+	store    StateStore
+	key      string
+	inflight map[journalReservation]chan struct{}
+}
+
+// journalReservation identifies an input and time window that is being
+// inserted.
+type journalReservation struct {
+	input   string
+	gte, lt fields.Timestamp
+}
+
+// JournalWindow describe the time range [Gte,Lt).
+type JournalWindow struct {
+	Gte time.Time `json:"gte"`
+	Lt  time.Time `json:"lt"`
+}
+
+// PendingInsert describe an insert that was started, but not recorded as
+// completed.
+type PendingInsert struct {
+	Gte    time.Time `json:"gte"`
+	Lt     time.Time `json:"lt"`
+	Inputs []string  `json:"inputs"`
+}
+
+// JournalReport describe the state of an insert journal.
+type JournalReport struct {
+	// Completed holds the completed time windows per input key. Adjacent and
+	// overlapping windows are merged.
+	Completed map[string][]JournalWindow `json:"completed"`
+
+	// Pending holds inserts that were interrupted, e.g. because the process
+	// was stopped. Data for these inputs and windows may be partially written.
+	Pending []PendingInsert `json:"pending"`
+}
+
+// NewInsertJournal returns a journal that records progress in store under
+// state keys prefixed by the passed in key.
+func NewInsertJournal(store StateStore, key string) *InsertJournal {
+	return &InsertJournal{store: store, key: key}
+}
+
+// Insert inserts the data in df for the time range [gte,lt), skipping inputs
+// for which the window is already completed. Samples outside of the time
+// range are not inserted. Before the insert, the inputs are recorded as
+// pending; on success, they are recorded as completed. If the insert fails,
+// the pending record is removed again. If all inputs are already completed,
+// or there are no samples to insert, no request is sent, and a nil result is
+// returned.
+//
+// Concurrent inserts for the same input and time range are serialized, so
+// that only one of them sends the data.
+//
+// Data is inserted using cfg.Client(). Requests that are rejected due to rate
+// limiting are retried with an exponential back-off, waiting on the timer
+// configured in cfg. The caller is responsible for respecting DryRun.
+func (j *InsertJournal) Insert(ctx context.Context, cfg *Config, gte, lt time.Time, df views.DataFrame) (*clarify.InsertResult, error) {
+	pending, done, err := j.reserve(ctx, slices.Sorted(maps.Keys(df)), gte, lt)
+	if err != nil || done == nil {
+		return nil, err
+	}
+
+	lo, hi := fields.AsTimestamp(gte), fields.AsTimestamp(lt)
+	data := make(views.DataFrame, len(pending.Inputs))
+	for _, input := range pending.Inputs {
+		series := make(views.DataSeries)
+		for t, v := range df[input] {
+			if t >= lo && t < hi {
+				series[t] = v
+			}
+		}
+		if len(series) > 0 {
+			data[input] = series
+		}
+	}
+
+	var result *clarify.InsertResult
+	if len(data) > 0 {
+		result, err = doTryAgain(ctx, cfg, 0, cfg.Client().Insert(data).Do)
+	}
+	if releaseErr := j.release(ctx, pending, done, err == nil); err == nil {
+		err = releaseErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// reserve waits for concurrent inserts for the same inputs and time range to
+// complete, and then reserves and records the inputs that are not completed
+// as pending, all under a single lock. If all inputs are completed, done is
+// nil. Otherwise, the caller must call release with the returned values.
+func (j *InsertJournal) reserve(ctx context.Context, inputs []string, gte, lt time.Time) (_ PendingInsert, done chan struct{}, _ error) {
+	lo, hi := fields.AsTimestamp(gte), fields.AsTimestamp(lt)
+	for {
+		j.lock.Lock()
+		var wait chan struct{}
+		for _, input := range inputs {
+			if c, ok := j.inflight[journalReservation{input, lo, hi}]; ok {
+				wait = c
+				break
+			}
+		}
+		if wait != nil {
+			j.lock.Unlock()
+			select {
+			case <-ctx.Done():
+				return PendingInsert{}, nil, ctx.Err()
+			case <-wait:
+			}
+			continue
+		}
+
+		pending, err := j.reserveLocked(ctx, inputs, gte, lt)
+		if err != nil || len(pending.Inputs) == 0 {
+			j.lock.Unlock()
+			return PendingInsert{}, nil, err
+		}
+		done = make(chan struct{})
+		if j.inflight == nil {
+			j.inflight = make(map[journalReservation]chan struct{})
+		}
+		for _, input := range pending.Inputs {
+			j.inflight[journalReservation{input, lo, hi}] = done
+		}
+		j.lock.Unlock()
+		return pending, done, nil
+	}
+}
+
+// reserveLocked records the inputs for which [gte,lt) is not completed as
+// pending. The caller must hold the lock.
+func (j *InsertJournal) reserveLocked(ctx context.Context, inputs []string, gte, lt time.Time) (PendingInsert, error) {
+	pending := PendingInsert{Gte: gte, Lt: lt}
+	for _, input := range inputs {
+		var windows []JournalWindow
+		if err := j.load(ctx, j.completedKey(input), &windows); err != nil {
+			return PendingInsert{}, err
+		}
+		if !windowsCover(windows, gte, lt) {
+			pending.Inputs = append(pending.Inputs, input)
+		}
+	}
+	if len(pending.Inputs) == 0 {
+		return pending, nil
+	}
+	err := j.updatePending(ctx, func(p []PendingInsert) []PendingInsert {
+		return append(p, pending)
+	})
+	if err != nil {
+		return PendingInsert{}, err
+	}
+	return pending, nil
+}
+
+// release records the pending inputs as completed if ok is true, removes the
+// pending record, and releases the reservation. If recording the inputs as
+// completed fails, the pending record is kept.
+func (j *InsertJournal) release(ctx context.Context, pending PendingInsert, done chan struct{}, ok bool) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	defer func() {
+		lo, hi := fields.AsTimestamp(pending.Gte), fields.AsTimestamp(pending.Lt)
+		for _, input := range pending.Inputs {
+			delete(j.inflight, journalReservation{input, lo, hi})
+		}
+		close(done)
+	}()
+
+	if ok {
+		if err := j.complete(ctx, pending.Inputs, JournalWindow{Gte: pending.Gte, Lt: pending.Lt}); err != nil {
+			return err
+		}
+	}
+	return j.updatePending(ctx, func(p []PendingInsert) []PendingInsert {
+		return slices.DeleteFunc(p, pending.equal)
+	})
+}
+
+// Completed returns true if the time range [gte,lt) is recorded as completed
+// for input.
+func (j *InsertJournal) Completed(ctx context.Context, input string, gte, lt time.Time) (bool, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	var windows []JournalWindow
+	if err := j.load(ctx, j.completedKey(input), &windows); err != nil {
+		return false, err
+	}
+	return windowsCover(windows, gte, lt), nil
+}
+
+// Report returns the current state of the journal.
+func (j *InsertJournal) Report(ctx context.Context) (JournalReport, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	r := JournalReport{
+		Completed: make(map[string][]JournalWindow),
+		Pending:   []PendingInsert{},
+	}
+	var inputs []string
+	if err := j.load(ctx, j.key+"/inputs", &inputs); err != nil {
+		return r, err
+	}
+	for _, input := range inputs {
+		var windows []JournalWindow
+		if err := j.load(ctx, j.completedKey(input), &windows); err != nil {
+			return r, err
+		}
+		r.Completed[input] = windows
+	}
+	if err := j.load(ctx, j.key+"/pending", &r.Pending); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// complete records w as completed for inputs. The caller must hold the lock.
+func (j *InsertJournal) complete(ctx context.Context, inputs []string, w JournalWindow) error {
+	var known []string
+	if err := j.load(ctx, j.key+"/inputs", &known); err != nil {
+		return err
+	}
+	var added bool
+	for _, input := range inputs {
+		var windows []JournalWindow
+		if err := j.load(ctx, j.completedKey(input), &windows); err != nil {
+			return err
+		}
+		if err := j.save(ctx, j.completedKey(input), mergeWindows(append(windows, w))); err != nil {
+			return err
+		}
+		if i, found := slices.BinarySearch(known, input); !found {
+			known = slices.Insert(known, i, input)
+			added = true
+		}
+	}
+	if added {
+		return j.save(ctx, j.key+"/inputs", known)
+	}
+	return nil
+}
+
+// updatePending replaces the pending inserts with the result of f. The caller
+// must hold the lock.
+func (j *InsertJournal) updatePending(ctx context.Context, f func([]PendingInsert) []PendingInsert) error {
+	var pending []PendingInsert
+	if err := j.load(ctx, j.key+"/pending", &pending); err != nil {
+		return err
+	}
+	return j.save(ctx, j.key+"/pending", f(pending))
+}
+
+func (j *InsertJournal) completedKey(input string) string {
+	return j.key + "/completed/" + input
+}
+
+// load decodes the JSON value stored for key into v. If no value is stored, v
+// is left unchanged. The caller must hold the lock.
+func (j *InsertJournal) load(ctx context.Context, key string, v any) error {
+	s, found, err := j.store.Load(ctx, key)
+	switch {
+	case err != nil:
+		return fmt.Errorf("load journal: %w", err)
+	case found:
+		if err := json.Unmarshal([]byte(s), v); err != nil {
+			return fmt.Errorf("load journal: %w", err)
+		}
+	}
+	return nil
+}
+
+// save stores v JSON encoded for key. The caller must hold the lock.
+func (j *InsertJournal) save(ctx context.Context, key string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := j.store.Store(ctx, key, string(b)); err != nil {
+		return fmt.Errorf("store journal: %w", err)
+	}
+	return nil
+}
+
+// windowsCover returns true if one of windows cover the time range [gte,lt).
+func windowsCover(windows []JournalWindow, gte, lt time.Time) bool {
+	for _, w := range windows {
+		if !w.Gte.After(gte) && !w.Lt.Before(lt) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p PendingInsert) equal(other PendingInsert) bool {
+	return p.Gte.Equal(other.Gte) && p.Lt.Equal(other.Lt) && slices.Equal(p.Inputs, other.Inputs)
+}
+
+// mergeWindows returns windows sorted by start time, where adjacent and
+// overlapping windows are merged.
+func mergeWindows(windows []JournalWindow) []JournalWindow {
+	slices.SortFunc(windows, func(a, b JournalWindow) int {
+		return a.Gte.Compare(b.Gte)
+	})
+	merged := windows[:0]
+	for _, w := range windows {
+		if n := len(merged); n > 0 && !w.Gte.After(merged[n-1].Lt) {
+			if w.Lt.After(merged[n-1].Lt) {
+				merged[n-1].Lt = w.Lt
+			}
+			continue
+		}
+		merged = append(merged, w)
+	}
+	return merged
+}
@@ -0,0 +1,178 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestInsertJournal(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	t2 := t1.Add(time.Hour)
+
+	var fail bool
+	var inserted []views.DataFrame
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		if req.Method != "integration.insert" {
+			return errors.New("unexpected method")
+		}
+		if fail {
+			return errors.New("connection reset")
+		}
+		inserted = append(inserted, req.Params.(map[string]any)["data"].(views.DataFrame))
+		return decodeResult(`{"signalsByInput":{}}`, result)
+	})
+	cfg := automation.NewConfig(clarify.NewClient("i1", h))
+	store := automation.NewMemoryStateStore()
+	journal := automation.NewInsertJournal(store, "journal")
+
+	frame := func(t time.Time, inputs ...string) views.DataFrame {
+		df := make(views.DataFrame)
+		for _, input := range inputs {
+			df[input] = views.DataSeries{fields.AsTimestamp(t): 1}
+		}
+		return df
+	}
+
+	if _, err := journal.Insert(ctx, cfg, t0, t1, frame(t0, "a", "b")); err != nil {
+		t.Fatalf("journal.Insert() error: %v", err)
+	}
+	fail = true
+	if _, err := journal.Insert(ctx, cfg, t1, t2, frame(t1, "a", "b")); err == nil {
+		t.Fatalf("journal.Insert() expected error")
+	}
+
+	report, err := journal.Report(ctx)
+	if err != nil {
+		t.Fatalf("journal.Report() error: %v", err)
+	}
+	// A failed insert is not left behind as pending.
+	if expectPending := []automation.PendingInsert{}; !reflect.DeepEqual(report.Pending, expectPending) {
+		t.Errorf("unexpected pending:\n got: %v\nwant: %v", report.Pending, expectPending)
+	}
+
+	// Resume: the first window must be skipped, and only new inputs inserted.
+	fail = false
+	inserted = nil
+	result, err := journal.Insert(ctx, cfg, t0, t1, frame(t0, "a", "b"))
+	if err != nil || result != nil {
+		t.Errorf("journal.Insert() for completed window:\n got: %v, %v\nwant: <nil>, <nil>", result, err)
+	}
+	if _, err := journal.Insert(ctx, cfg, t0, t1, frame(t0, "a", "c")); err != nil {
+		t.Fatalf("journal.Insert() error: %v", err)
+	}
+	if _, err := journal.Insert(ctx, cfg, t1, t2, frame(t1, "a", "b")); err != nil {
+		t.Fatalf("journal.Insert() error: %v", err)
+	}
+	expectInserted := []views.DataFrame{frame(t0, "c"), frame(t1, "a", "b")}
+	if !reflect.DeepEqual(inserted, expectInserted) {
+		t.Errorf("unexpected inserts:\n got: %v\nwant: %v", inserted, expectInserted)
+	}
+
+	report, err = journal.Report(ctx)
+	if err != nil {
+		t.Fatalf("journal.Report() error: %v", err)
+	}
+	expect := automation.JournalReport{
+		Completed: map[string][]automation.JournalWindow{
+			"a": {{Gte: t0, Lt: t2}},
+			"b": {{Gte: t0, Lt: t2}},
+			"c": {{Gte: t0, Lt: t1}},
+		},
+		Pending: []automation.PendingInsert{},
+	}
+	if !reflect.DeepEqual(report, expect) {
+		t.Errorf("unexpected report:\n got: %+v\nwant: %+v", report, expect)
+	}
+
+	if ok, err := journal.Completed(ctx, "c", t1, t2); err != nil || ok {
+		t.Errorf("journal.Completed(c, t1, t2):\n got: %v, %v\nwant: false, <nil>", ok, err)
+	}
+
+	// Completed windows are stored per input.
+	if v, _, _ := store.Load(ctx, "journal/completed/c"); v == "" {
+		t.Errorf("Expected completed windows for c to be stored under their own key")
+	}
+
+	// Samples outside of the window are not inserted.
+	inserted = nil
+	df := frame(t2, "d")
+	df["d"][fields.AsTimestamp(t2.Add(time.Hour))] = 2
+	df["d"][fields.AsTimestamp(t1)] = 3
+	if _, err := journal.Insert(ctx, cfg, t2, t2.Add(time.Hour), df); err != nil {
+		t.Fatalf("journal.Insert() error: %v", err)
+	}
+	expectInserted = []views.DataFrame{frame(t2, "d")}
+	if !reflect.DeepEqual(inserted, expectInserted) {
+		t.Errorf("unexpected inserts:\n got: %v\nwant: %v", inserted, expectInserted)
+	}
+}
+
+func TestInsertJournalConcurrent(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+
+	var lock sync.Mutex
+	var inserts int
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		lock.Lock()
+		inserts++
+		lock.Unlock()
+		// Give concurrent inserts a chance to pass the completed check.
+		time.Sleep(time.Millisecond)
+		return decodeResult(`{"signalsByInput":{}}`, result)
+	})
+	cfg := automation.NewConfig(clarify.NewClient("i1", h))
+	journal := automation.NewInsertJournal(automation.NewMemoryStateStore(), "journal")
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			df := views.DataFrame{"a": {fields.AsTimestamp(t0): 1}}
+			if _, err := journal.Insert(ctx, cfg, t0, t1, df); err != nil {
+				t.Errorf("journal.Insert() error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if inserts != 1 {
+		t.Errorf("unexpected number of inserts:\n got: %d\nwant: 1", inserts)
+	}
+	report, err := journal.Report(ctx)
+	if err != nil {
+		t.Fatalf("journal.Report() error: %v", err)
+	}
+	if len(report.Pending) != 0 {
+		t.Errorf("unexpected pending:\n got: %v\nwant: []", report.Pending)
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright 2023 Searis AS
+// Copyright 2023-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -15,28 +15,38 @@
 package automation
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/jsonrpc"
 	"github.com/clarify/clarify-go/views"
 )
 
 // Clarify annotations used by automation routines.
 const (
-	AnnotationPrefix = "clarify/clarify-go/"
+	AnnotationPrefix = fields.AnnotationKeyPrefix
 
 	AnnotationPublisherName             = AnnotationPrefix + "publisher/name"
 	AnnotationPublisherTransformVersion = AnnotationPrefix + "publisher/transform-version"
 	AnnotationPublisherSignalID         = AnnotationPrefix + "publisher/signal-id"
 	AnnotationPublisherSignalAttributes = AnnotationPrefix + "publisher/signal-attributes"
+	AnnotationPublisherUnitConversion   = AnnotationPrefix + "publisher/unit-conversion"
 )
 
-// AttrError return a log attribute for err.
+// AttrError return a log attribute for err. If err wraps an
+// jsonrpc.InvalidParamsError, the parameter issues are included.
 func AttrError(err error) slog.Attr {
-	return slog.Group("error",
+	attrs := []any{
 		slog.String("message", err.Error()),
 		slog.String("type", fmt.Sprintf("%T", err)),
-	)
+	}
+	var paramsErr jsonrpc.InvalidParamsError
+	if errors.As(err, &paramsErr) {
+		attrs = append(attrs, slog.Any("issues", paramsErr.Issues))
+	}
+	return slog.Group("error", attrs...)
 }
 
 // AttrDataFrame returns a log attribute for data.
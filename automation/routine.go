@@ -1,4 +1,4 @@
-// Copyright 2023 Searis AS
+// Copyright 2023-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -18,9 +18,13 @@ import (
 	"context"
 	"io"
 	"log/slog"
+	"maps"
 	"runtime/debug"
+	"strings"
+	"time"
 
 	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/jsonrpc"
 )
 
 var defaultAppName string
@@ -56,19 +60,27 @@ type Config struct {
 	routinePath string
 	logger      *slog.Logger
 	client      *clarify.Client
+	state       StateStore
+	recorder    RunRecorder
+	counter     func(key string, n int)
+	values      map[string]any
+	stop        context.Context
+	now         func() time.Time
+	after       func(time.Duration) <-chan time.Time
 	dryRun      bool
 	earlyOut    bool
 }
 
 // NewConfig returns a new configuration for the passed in clients, using
 // sensible defaults for all optional configuration. This means using slog
-// package default logger, and setting the application name based on the main
-// module's import path.
+// package default logger, an in-memory state store, and setting the
+// application name based on the main module's import path.
 func NewConfig(client *clarify.Client) *Config {
 	return &Config{
 		appName: defaultAppName,
 		logger:  slog.Default(),
 		client:  client,
+		state:   NewMemoryStateStore(),
 	}
 }
 
@@ -126,11 +138,147 @@ func (cfg Config) WithLogger(l *slog.Logger) *Config {
 	return &cfg
 }
 
+// WithStateStore returns a new configuration with the specified state store.
+// The state store is used by routines that need to persist state between runs.
+func (cfg Config) WithStateStore(s StateStore) *Config {
+	cfg.state = s
+	return &cfg
+}
+
+// WithRunRecorder returns a new configuration with the specified run recorder.
+// When set, Routines.Do records the start, end and status of each routine it
+// runs. If nil, runs are not recorded.
+func (cfg Config) WithRunRecorder(r RunRecorder) *Config {
+	cfg.recorder = r
+	return &cfg
+}
+
+// WithCounter returns a new configuration where counter is called for each
+// count reported by routines via AddCount. Counts are reported once per run,
+// and for a given key, the reported values should be summed. If nil, counts
+// are discarded.
+func (cfg Config) WithCounter(counter func(key string, n int)) *Config {
+	cfg.counter = counter
+	return &cfg
+}
+
+// WithValues returns a new configuration where the passed in values are merged
+// with existing values. Values can be used to pass parameters to routines at
+// run-time, and should be keyed by either "<key>", "<routine path>.<key>" or
+// "<parent path>.<key>". See the Value method for lookup rules.
+func (cfg Config) WithValues(values map[string]any) *Config {
+	merged := make(map[string]any, len(cfg.values)+len(values))
+	maps.Copy(merged, cfg.values)
+	maps.Copy(merged, values)
+	cfg.values = merged
+	return &cfg
+}
+
+// WithStopContext returns a new configuration where routines are signaled to
+// stop at their next checkpoint once stop is done. Unlike canceling the context
+// passed to Do, this allows in-flight requests to complete, which makes it
+// possible to shut down gracefully.
+func (cfg Config) WithStopContext(stop context.Context) *Config {
+	cfg.stop = stop
+	return &cfg
+}
+
+// WithClock returns a new configuration where now is used for getting the
+// current time, as returned by the Now method. This allows deterministic tests
+// of routines that calculate time ranges relative to the current time. If nil,
+// the clock of the Clarify client is used.
+func (cfg Config) WithClock(now func() time.Time) *Config {
+	cfg.now = now
+	return &cfg
+}
+
+// WithTimer returns a new configuration where after is used for waiting, as
+// returned by the After method. This allows tests of routines that wait, such
+// as Routines with a stagger, to run without delay. If nil, time.After is used.
+func (cfg Config) WithTimer(after func(d time.Duration) <-chan time.Time) *Config {
+	cfg.after = after
+	return &cfg
+}
+
 // Client returns the Clarify client contained within options.
 func (cfg Config) Client() *clarify.Client {
 	return cfg.client
 }
 
+// StateStore returns the configured state store. If no state store is
+// configured, an in-memory store that is not persisted between calls is
+// returned.
+func (cfg *Config) StateStore() StateStore {
+	if cfg == nil || cfg.state == nil {
+		return NewMemoryStateStore()
+	}
+	return cfg.state
+}
+
+// Now returns the current time according to the configured clock. If no clock
+// is configured, the clock of the Clarify client is used, which default to
+// time.Now.
+func (cfg *Config) Now() time.Time {
+	switch {
+	case cfg != nil && cfg.now != nil:
+		return cfg.now()
+	case cfg != nil && cfg.client != nil:
+		return cfg.client.Now()
+	}
+	return time.Now()
+}
+
+// After returns a channel that receives the current time after d has passed,
+// according to the configured timer. If no timer is configured, time.After is
+// used.
+func (cfg *Config) After(d time.Duration) <-chan time.Time {
+	if cfg != nil && cfg.after != nil {
+		return cfg.after(d)
+	}
+	return time.After(d)
+}
+
+// RunRecorder returns the configured run recorder, or nil if runs should not be
+// recorded.
+func (cfg *Config) RunRecorder() RunRecorder {
+	if cfg == nil {
+		return nil
+	}
+	return cfg.recorder
+}
+
+// AddCount reports n for the counter named key, such as "publish_count", to
+// the configured counter. Routines should report each count once per run,
+// typically when logging that the run has completed.
+func (cfg *Config) AddCount(key string, n int) {
+	if cfg == nil || cfg.counter == nil {
+		return
+	}
+	cfg.counter(key, n)
+}
+
+// Value looks up the value for key, using the most specific match according to
+// the current routine path. Given a routine path "a/b" and key "k", the
+// following keys are looked up in order: "a/b.k", "a.k", "k".
+func (cfg *Config) Value(key string) (any, bool) {
+	if cfg == nil {
+		return nil, false
+	}
+	path := cfg.routinePath
+	for path != "" {
+		if v, ok := cfg.values[path+"."+key]; ok {
+			return v, true
+		}
+		i := strings.LastIndexByte(path, '/')
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+	v, ok := cfg.values[key]
+	return v, ok
+}
+
 // AppName returns the app name.
 func (cfg *Config) AppName() string {
 	if cfg == nil {
@@ -147,6 +295,19 @@ func (cfg *Config) RoutinePath() string {
 	return cfg.routinePath
 }
 
+// Checkpoint returns a non-nil error if ctx is done, or if routines has been
+// signaled to stop via the stop context. Routines should call Checkpoint
+// between units of work, and return any error.
+func (cfg *Config) Checkpoint(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if cfg != nil && cfg.stop != nil && cfg.stop.Err() != nil {
+		return context.Cause(cfg.stop)
+	}
+	return nil
+}
+
 // EarlyOut returns the value of the early-out option. When true, routines with
 // sub-routines should abort at the first error.
 func (cfg *Config) EarlyOut() bool {
@@ -159,13 +320,26 @@ func (cfg *Config) DryRun() bool {
 	return cfg.dryRun
 }
 
-// Logger returns a structured logger instance.
+// BaseLogger returns the logger as configured via WithLogger, without the
+// attributes added by Logger. This allows wrapping the logger, such as to
+// capture log records elsewhere. If logs are disabled, nil is returned.
+func (cfg *Config) BaseLogger() *slog.Logger {
+	if cfg == nil {
+		return nil
+	}
+	return cfg.logger
+}
+
+// Logger returns a structured logger instance. Log attributes stored in the
+// context passed to the logger, see jsonrpc.ContextWithLogAttrs, are included
+// in each log record.
 func (cfg *Config) Logger() *slog.Logger {
 	logger := cfg.logger
 	if logger == nil {
 		// Return a logger that discards all entries.
 		return slog.New(slog.NewJSONHandler(io.Discard, nil))
 	}
+	logger = slog.New(jsonrpc.NewContextLogHandler(logger.Handler()))
 
 	if cfg.appName != "" {
 		logger = logger.With(attrAppName(cfg.appName))
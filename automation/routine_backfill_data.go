@@ -0,0 +1,230 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+const (
+	selectItemsPageSize = 1000
+
+	defaultBackfillWindow = 24 * time.Hour
+
+	tryAgainAttempts     = 5
+	defaultTryAgainDelay = time.Second
+)
+
+// BackfillData allows copying historical data from existing items into
+// signals of the integration that is used by the configured client. Data is
+// copied one time window at a time, and progress is recorded in the configured
+// state store so that an interrupted backfill can resume where it left off.
+//
+// Requests that are rejected due to rate limiting are retried with an
+// exponential back-off. The routine respects the DryRun configuration.
+type BackfillData struct {
+	// ItemsFilter selects the source items to copy data from. If nil, all items
+	// are matched. The filter is evaluated for each time window.
+	ItemsFilter fields.ResourceFilterType
+
+	// InputKey maps a source item ID to the input key to insert data into. If
+	// nil, the item ID is used as input key.
+	InputKey func(itemID string) string
+
+	// Start and End describe the time range [Start,End) to copy data for.
+	Start, End time.Time
+
+	// Window describe the width of each time window that is copied per
+	// request. The default is 24 hours. Make sure to respect API limits for the
+	// number of data points returned per request.
+	Window time.Duration
+
+	// StateKey sets the key used to record progress in the state store. The
+	// default is "backfill/" followed by the routine path.
+	StateKey string
+
+	// RetryDelay sets the delay before the first retry of a rate limited
+	// request. The delay is doubled for each following retry. The default is
+	// 1 second.
+	RetryDelay time.Duration
+}
+
+var _ Routine = BackfillData{}
+
+func (b BackfillData) Do(ctx context.Context, cfg *Config) error {
+	logger := cfg.Logger()
+	client := cfg.Client()
+	state := cfg.StateStore()
+	dryRun := cfg.DryRun()
+
+	window := b.Window
+	if window <= 0 {
+		window = defaultBackfillWindow
+	}
+	stateKey := b.StateKey
+	if stateKey == "" {
+		stateKey = "backfill/" + cfg.RoutinePath()
+	}
+
+	start := b.Start
+	switch v, found, err := state.Load(ctx, stateKey); {
+	case err != nil:
+		return fmt.Errorf("load state: %w", err)
+	case found:
+		var resume time.Time
+		if err := resume.UnmarshalText([]byte(v)); err != nil {
+			return fmt.Errorf("load state: %w", err)
+		}
+		if resume.After(start) {
+			logger.LogAttrs(ctx, slog.LevelInfo, "Resume backfill", slog.Time("from", resume))
+			start = resume
+		}
+	}
+	if !start.Before(b.End) {
+		cfg.AddCount("window_count", 0)
+		logger.LogAttrs(ctx, slog.LevelInfo, "Backfill completed", slog.Int("window_count", 0))
+		return nil
+	}
+
+	var windowCount, insertCount int
+	for gte := start; gte.Before(b.End); {
+		if err := cfg.Checkpoint(ctx); err != nil {
+			return err
+		}
+		lt := gte.Add(window)
+		if lt.After(b.End) {
+			lt = b.End
+		}
+
+		df, err := dataFrameByFilter(ctx, cfg, b.ItemsFilter, gte, lt, b.InputKey, b.RetryDelay)
+		if err != nil {
+			return fmt.Errorf("data frame [%s,%s): %w", gte.Format(time.RFC3339), lt.Format(time.RFC3339), err)
+		}
+		logger.LogAttrs(ctx, slog.LevelDebug, "Backfill window",
+			slog.Time("gte", gte),
+			slog.Time("lt", lt),
+			slog.Int("series_count", len(df)),
+		)
+
+		if !dryRun {
+			if len(df) > 0 {
+				_, err := doTryAgain(ctx, cfg, b.RetryDelay, client.Insert(df).Do)
+				if err != nil {
+					return fmt.Errorf("insert [%s,%s): %w", gte.Format(time.RFC3339), lt.Format(time.RFC3339), err)
+				}
+			}
+			if err := state.Store(ctx, stateKey, lt.Format(time.RFC3339Nano)); err != nil {
+				return fmt.Errorf("store state: %w", err)
+			}
+		}
+		windowCount++
+		insertCount += len(df)
+		gte = lt
+	}
+
+	cfg.AddCount("window_count", windowCount)
+	cfg.AddCount("insert_count", insertCount)
+	logger.LogAttrs(ctx, slog.LevelInfo, "Backfill completed",
+		slog.Int("window_count", windowCount),
+		slog.Int("insert_count", insertCount),
+	)
+	return nil
+}
+
+// selectItemIDs returns the IDs of all items matching filter. If filter is nil,
+// all items are matched. Rate limited requests are retried, see doTryAgain.
+func selectItemIDs(ctx context.Context, cfg *Config, filter fields.ResourceFilterType, retryDelay time.Duration) ([]string, error) {
+	query := fields.Query().Sort("id").Limit(selectItemsPageSize)
+	if filter != nil {
+		query = query.Where(filter)
+	}
+
+	var ids []string
+	for {
+		results, err := doTryAgain(ctx, cfg, retryDelay, cfg.Client().Clarify().SelectItems(query).Do)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range results.Data {
+			ids = append(ids, item.ID)
+		}
+		if len(results.Data) < query.GetLimit() {
+			return ids, nil
+		}
+		query = query.NextPage()
+	}
+}
+
+// dataFrameByFilter returns a data frame for the items matching filter in the
+// time range [gte,lt), using DataFrameRequest.DoChunked. If filter is nil, all
+// items are matched. Series are keyed by inputKey(itemID), or by item ID if
+// inputKey is nil. Rate limited requests are retried, see doTryAgain.
+func dataFrameByFilter(ctx context.Context, cfg *Config, filter fields.ResourceFilterType, gte, lt time.Time, inputKey func(itemID string) string, retryDelay time.Duration) (views.DataFrame, error) {
+	query := fields.Query()
+	if filter != nil {
+		query = query.Where(filter)
+	}
+	data := fields.Data().Where(fields.TimeRange(gte, lt))
+	results, err := doTryAgain(ctx, cfg, retryDelay, func(ctx context.Context) (*clarify.DataFrameResult, error) {
+		return cfg.Client().Clarify().DataFrame(query, data).DoChunked(ctx, 0, 1)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	df := make(views.DataFrame, len(results.Data))
+	for id, series := range results.Data {
+		if len(series) == 0 {
+			continue
+		}
+		key := id
+		if inputKey != nil {
+			key = inputKey(id)
+		}
+		df[key] = series
+	}
+	return df, nil
+}
+
+// doTryAgain calls f, and retries with an exponential back-off starting at
+// delay for as long as f returns an error that indicates rate limiting. If delay
+// is zero, a default delay of 1 second is used. The back-off waits on the
+// configured timer, see Config.After.
+func doTryAgain[R any](ctx context.Context, cfg *Config, delay time.Duration, f func(context.Context) (*R, error)) (*R, error) {
+	if delay <= 0 {
+		delay = defaultTryAgainDelay
+	}
+	for attempt := 1; ; attempt++ {
+		result, err := f(jsonrpc.ContextWithAttempt(ctx, attempt))
+		if err == nil || attempt >= tryAgainAttempts || !jsonrpc.IsTryAgain(err) {
+			return result, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-cfg.After(delay):
+		}
+		delay *= 2
+	}
+}
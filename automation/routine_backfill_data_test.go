@@ -0,0 +1,125 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+// testutil.HandlerFunc allows using a function as a jsonrpc.Handler in tests.
+func decodeResult(raw string, result any) error {
+	return json.Unmarshal([]byte(raw), result)
+}
+
+func TestBackfillData(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+
+	var inserted []views.DataFrame
+	var dataFrameCalls int
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		params := req.Params.(map[string]any)
+		switch req.Method {
+		case "clarify.selectItems":
+			return decodeResult(`{"meta":{"total":-1},"data":[{"type":"items","id":"a"}],"included":{}}`, result)
+		case "clarify.dataFrame":
+			dataFrameCalls++
+			if dataFrameCalls <= 2 {
+				// Simulate rate limiting on the first two calls.
+				return &clarify.ServerError{Code: clarify.CodeTryAgain, Message: "Try again"}
+			}
+			b, _ := json.Marshal(params["data"])
+			var data struct {
+				Filter struct {
+					Times struct {
+						GTE time.Time `json:"$gte"`
+					} `json:"times"`
+				} `json:"filter"`
+			}
+			if err := json.Unmarshal(b, &data); err != nil {
+				return err
+			}
+			return decodeResult(fmt.Sprintf(
+				`{"meta":{"total":-1},"data":{"times":[%q],"series":{"a":[1]}},"included":{}}`,
+				data.Filter.Times.GTE.Format(time.RFC3339),
+			), result)
+		case "integration.insert":
+			inserted = append(inserted, params["data"].(views.DataFrame))
+			return decodeResult(`{"signalsByInput":{}}`, result)
+		}
+		return fmt.Errorf("unexpected method %q", req.Method)
+	})
+
+	var waits []time.Duration
+	after := func(d time.Duration) <-chan time.Time {
+		waits = append(waits, d)
+		c := make(chan time.Time, 1)
+		c <- start
+		return c
+	}
+
+	state := automation.NewMemoryStateStore()
+	ctx := context.Background()
+	cfg := automation.NewConfig(clarify.NewClient("integration", h)).
+		WithLogger(nil).
+		WithStateStore(state).
+		WithTimer(after)
+
+	routine := automation.BackfillData{
+		InputKey:   func(id string) string { return "copy/" + id },
+		Start:      start,
+		End:        end,
+		Window:     time.Hour,
+		StateKey:   "test",
+		RetryDelay: time.Minute,
+	}
+	if err := routine.Do(ctx, cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expect := []time.Duration{time.Minute, 2 * time.Minute}; !slices.Equal(waits, expect) {
+		t.Errorf("Unexpected retry waits:\n got: %v\nwant: %v", waits, expect)
+	}
+	if len(inserted) != 3 {
+		t.Fatalf("Unexpected insert count:\n got: %d\nwant: 3", len(inserted))
+	}
+	if _, ok := inserted[0]["copy/a"]; !ok {
+		t.Errorf("Expected inserted data frame to contain key %q, got: %v", "copy/a", inserted[0])
+	}
+	v, _, _ := state.Load(ctx, "test")
+	if expect := end.Format(time.RFC3339Nano); v != expect {
+		t.Errorf("Unexpected state:\n got: %s\nwant: %s", v, expect)
+	}
+
+	// Running the routine again should resume from the stored state and not
+	// insert anything.
+	inserted = nil
+	if err := routine.Do(ctx, cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(inserted) != 0 {
+		t.Errorf("Unexpected insert count after resume:\n got: %d\nwant: 0", len(inserted))
+	}
+}
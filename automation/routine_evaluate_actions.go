@@ -1,4 +1,4 @@
-// Copyright 2023-2024 Searis AS
+// Copyright 2023-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -16,7 +16,9 @@ package automation
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/clarify/clarify-go/fields"
@@ -36,6 +38,55 @@ type Evaluation struct {
 	// SeriesIn filters which series keys (aliases) to return in the server
 	// result. If the value is nil, all series are returned.
 	SeriesIn []string
+
+	// DataQueryOptions holds optional data query settings, such as the
+	// time-zone to use for calendar aligned rollups.
+	DataQueryOptions
+}
+
+// DataQueryOptions describe optional settings for the data query of an
+// evaluation. Set these to align rollup buckets with the calendar of a
+// non-UTC organization.
+type DataQueryOptions struct {
+	// TimeZone sets the TZ database name of the time-zone to use, such as
+	// "Europe/Oslo". If empty, UTC is used. See fields.DataQuery.TimeZone.
+	TimeZone string
+
+	// Origin, if set, sets a custom rollup bucket origin. This takes
+	// precedence over FirstDayOfWeek. See fields.DataQuery.Origin.
+	Origin time.Time
+
+	// FirstDayOfWeek sets the first day of the week used for aligning fixed
+	// duration rollups as an ISO 8601 weekday, from 1 (Monday) to 7
+	// (Sunday). If zero, Monday is used.
+	FirstDayOfWeek int
+}
+
+// dataQuery returns a data query for the time range [gte,lt), with bucket as
+// the rollup. A zero bucket gives a window rollup.
+func (e Evaluation) dataQuery(gte, lt time.Time, bucket fields.CalendarDuration) fields.DataQuery {
+	firstDayOfWeek := time.Monday
+	if e.FirstDayOfWeek > 0 {
+		firstDayOfWeek = time.Weekday(e.FirstDayOfWeek % 7)
+	}
+
+	dataQuery := fields.Data().Where(fields.TimeRange(gte, lt))
+	switch {
+	case bucket.Months() > 0:
+		dataQuery = dataQuery.RollupMonths(bucket.Months())
+	case bucket.Duration() > 0:
+		dataQuery = dataQuery.RollupDuration(bucket.Duration(), firstDayOfWeek)
+	}
+	if e.TimeZone != "" {
+		dataQuery = dataQuery.TimeZone(e.TimeZone)
+	}
+	if !e.Origin.IsZero() {
+		dataQuery = dataQuery.Origin(e.Origin)
+	}
+	if e.SeriesIn != nil {
+		dataQuery = dataQuery.Where(fields.SeriesIn(e.SeriesIn...))
+	}
+	return dataQuery
 }
 
 // EvaluateActions allows running a single evaluation and pass the result onto
@@ -46,7 +97,8 @@ type EvaluateActions struct {
 
 	// TimeFunc is provided the current time, and should return a time range to
 	// evaluate. If not specified, a default window containing the last hour
-	// will be evaluated.
+	// will be evaluated. See fields.TimeRangeFunc for calendar aware helpers,
+	// such as fields.Today and fields.LastCompleteHours.
 	TimeFunc func(time.Time) (gte, lt time.Time)
 
 	// RollupBucket describe the rollup bucket to use for the evaluation. If not
@@ -68,11 +120,15 @@ func (e EvaluateActions) Do(ctx context.Context, cfg *Config) error {
 	logger := cfg.Logger()
 	client := cfg.Client()
 
+	// Use the configured clock to allow controlling the current time in tests.
+	now := cfg.Now()
 	var gte, lt time.Time
-	dataQuery := fields.Data().Where(fields.TimeRange(gte, lt))
-	if e.Evaluation.SeriesIn != nil {
-		dataQuery = dataQuery.Where(fields.SeriesIn(e.Evaluation.SeriesIn...))
+	if e.TimeFunc != nil {
+		gte, lt = e.TimeFunc(now)
+	} else {
+		gte, lt = now.Add(-time.Hour), now
 	}
+	dataQuery := e.Evaluation.dataQuery(gte, lt, e.RollupBucket)
 
 	selection, err := client.Clarify().
 		Evaluate(dataQuery).
@@ -84,7 +140,8 @@ func (e EvaluateActions) Do(ctx context.Context, cfg *Config) error {
 	}
 
 	result := EvaluateResult{
-		Data: selection.Data,
+		Data:       selection.Data,
+		Evaluation: e.Evaluation,
 	}
 	logger.LogAttrs(
 		ctx, slog.LevelDebug, "Evaluation result",
@@ -144,6 +201,48 @@ func ActionRoutine(r Routine) ActionFunc {
 
 // EvaluateResult describe the result of an evaluation.
 type EvaluateResult struct {
-	Annotations fields.Annotations
-	Data        views.DataFrame
+	Annotations fields.Annotations `json:"annotations"`
+	Data        views.DataFrame    `json:"data"`
+
+	// Evaluation holds the evaluation that produced the result. This allows
+	// actions to introspect which aliases were requested.
+	Evaluation Evaluation `json:"-"`
+}
+
+// Series returns the data series for the passed in alias, and true if the
+// alias is present in the result data.
+func (r EvaluateResult) Series(alias string) (views.DataSeries, bool) {
+	s, ok := r.Data[alias]
+	return s, ok
+}
+
+// MustSeries returns the data series for the passed in alias, or panics if
+// the alias is not present in the result data. It's intended for actions where
+// a missing alias is a programming error.
+func (r EvaluateResult) MustSeries(alias string) views.DataSeries {
+	s, ok := r.Data[alias]
+	if !ok {
+		panic(fmt.Sprintf("automation: alias %q not in evaluate result", alias))
+	}
+	return s
+}
+
+// Aliases returns the aliases of the evaluation's items and calculations, in
+// the order they are declared. If the evaluation specifies SeriesIn, only
+// aliases listed there are included.
+func (r EvaluateResult) Aliases() []string {
+	e := r.Evaluation
+	aliases := make([]string, 0, len(e.Items)+len(e.Calculations))
+	add := func(alias string) {
+		if e.SeriesIn == nil || slices.Contains(e.SeriesIn, alias) {
+			aliases = append(aliases, alias)
+		}
+	}
+	for _, item := range e.Items {
+		add(item.Alias)
+	}
+	for _, calc := range e.Calculations {
+		add(calc.Alias)
+	}
+	return aliases
 }
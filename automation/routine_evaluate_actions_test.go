@@ -0,0 +1,158 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestEvaluateResultAccessors(t *testing.T) {
+	t0 := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	result := automation.EvaluateResult{
+		Data: views.DataFrame{"has_fire": {t0: 1}},
+		Evaluation: automation.Evaluation{
+			Items:        []fields.EvaluateItem{{Alias: "fire_rate", ID: "i1"}},
+			Calculations: []fields.Calculation{{Alias: "has_fire", Formula: "fire_rate > 0"}},
+		},
+	}
+
+	if s, ok := result.Series("has_fire"); !ok || s[t0] != 1 {
+		t.Errorf("Unexpected series:\n got: %v, %v\nwant: %v, true", s, ok, views.DataSeries{t0: 1})
+	}
+	if _, ok := result.Series("fire_rate"); ok {
+		t.Errorf("Expected fire_rate to be missing from the result data")
+	}
+	if expect := []string{"fire_rate", "has_fire"}; !slices.Equal(result.Aliases(), expect) {
+		t.Errorf("Unexpected aliases:\n got: %v\nwant: %v", result.Aliases(), expect)
+	}
+	result.Evaluation.SeriesIn = []string{"has_fire"}
+	if expect := []string{"has_fire"}; !slices.Equal(result.Aliases(), expect) {
+		t.Errorf("Unexpected aliases with SeriesIn:\n got: %v\nwant: %v", result.Aliases(), expect)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected MustSeries to panic for a missing alias")
+		}
+	}()
+	result.MustSeries("fire_rate")
+}
+
+func TestEvaluateActionsTimeRange(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 30, 0, 0, time.UTC)
+
+	test := func(routine automation.EvaluateActions, expect fields.DataQuery) func(t *testing.T) {
+		return func(t *testing.T) {
+			var got fields.DataQuery
+			h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+				got = req.Params.(map[string]any)["data"].(fields.DataQuery)
+				return decodeResult(`{"data":{}}`, result)
+			})
+			cfg := automation.NewConfig(clarify.NewClient("integration", h)).
+				WithLogger(nil).
+				WithClock(func() time.Time { return now })
+
+			if err := routine.Do(context.Background(), cfg); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !got.Equal(expect) {
+				t.Errorf("Unexpected data query:\n got: %s\nwant: %s", jsonString(got), jsonString(expect))
+			}
+		}
+	}
+
+	lastHour := fields.Data().Where(fields.TimeRange(now.Add(-time.Hour), now))
+	t.Run("default", test(automation.EvaluateActions{}, lastHour))
+	t.Run("TimeFunc", test(
+		automation.EvaluateActions{
+			TimeFunc: func(now time.Time) (time.Time, time.Time) {
+				lt := now.Truncate(time.Hour)
+				return lt.Add(-24 * time.Hour), lt
+			},
+		},
+		fields.Data().Where(fields.TimeRange(
+			time.Date(2024, 1, 9, 12, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC),
+		)),
+	))
+	t.Run("RollupBucket fixed", test(
+		automation.EvaluateActions{RollupBucket: fields.FixedCalendarDuration(15 * time.Minute)},
+		lastHour.RollupDuration(15*time.Minute, time.Monday),
+	))
+	t.Run("RollupBucket months", test(
+		automation.EvaluateActions{RollupBucket: fields.MonthDuration(1)},
+		lastHour.RollupMonths(1),
+	))
+}
+
+func TestEvaluateActionsDataQueryOptions(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	origin := time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC)
+
+	var got fields.DataQuery
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		got = req.Params.(map[string]any)["data"].(fields.DataQuery)
+		return decodeResult(`{"data":{}}`, result)
+	})
+	cfg := automation.NewConfig(clarify.NewClient("integration", h)).
+		WithLogger(nil).
+		WithClock(func() time.Time { return now })
+
+	routine := automation.EvaluateActions{
+		Evaluation: automation.Evaluation{
+			DataQueryOptions: automation.DataQueryOptions{
+				TimeZone:       "Europe/Oslo",
+				FirstDayOfWeek: 7,
+			},
+		},
+		RollupBucket: fields.FixedCalendarDuration(24 * time.Hour),
+	}
+	if err := routine.Do(context.Background(), cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect := fields.Data().
+		Where(fields.TimeRange(now.Add(-time.Hour), now)).
+		RollupDuration(24*time.Hour, time.Sunday).
+		TimeZone("Europe/Oslo")
+	if !got.Equal(expect) {
+		t.Errorf("Unexpected data query:\n got: %s\nwant: %s", jsonString(got), jsonString(expect))
+	}
+
+	routine.Evaluation.Origin = origin
+	routine.Evaluation.FirstDayOfWeek = 0
+	if err := routine.Do(context.Background(), cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect = expect.RollupDuration(24*time.Hour, time.Monday).Origin(origin)
+	if !got.Equal(expect) {
+		t.Errorf("Unexpected data query:\n got: %s\nwant: %s", jsonString(got), jsonString(expect))
+	}
+}
+
+func jsonString(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+var defaultCompareShift = fields.FixedCalendarDuration(7 * 24 * time.Hour)
+
+// EvaluateCompare allows running an evaluation for both a current and a
+// previous time window, such as the same window last week, and pass the
+// series-by-series difference onto a chained list of actions. This is useful
+// for detecting regressions in KPIs.
+type EvaluateCompare struct {
+	// Evaluation contains the evaluations to perform.
+	Evaluation Evaluation
+
+	// TimeFunc is provided the current time, and should return the current
+	// time range to evaluate. If not specified, a default window containing the
+	// last hour will be evaluated.
+	TimeFunc func(time.Time) (gte, lt time.Time)
+
+	// RollupBucket describe the rollup bucket to use for the evaluation. If not
+	// specified, a window rollup is used.
+	RollupBucket fields.CalendarDuration
+
+	// Shift describe how far back in time to move the current time range in
+	// order to get the previous time range, such as a week or a month. The
+	// shift is applied as a calendar shift in the evaluation's time zone, where
+	// whole days and months are moved by date, so that rollup buckets in the
+	// previous time range align with the current ones also across daylight
+	// saving time changes. If not specified, the time range is shifted 7 days
+	// back.
+	Shift fields.CalendarDuration
+
+	// Compare describe how to compare current values with previous values. If
+	// not specified, views.Delta is used.
+	Compare views.CompareFunc
+
+	// Actions describe a chain of functions that are run in order for the
+	// comparison result. The result data contain the compared series at the
+	// timestamps of the current time range.
+	//
+	// Actions are responsible for checking opts.DryRun, and for logging their
+	// own errors.
+	Actions []ActionFunc
+}
+
+func (e EvaluateCompare) Do(ctx context.Context, cfg *Config) error {
+	logger := cfg.Logger()
+	client := cfg.Client()
+
+	// Use the configured clock to allow controlling the current time in tests.
+	now := cfg.Now()
+	var gte, lt time.Time
+	if e.TimeFunc != nil {
+		gte, lt = e.TimeFunc(now)
+	} else {
+		gte, lt = now.Add(-time.Hour), now
+	}
+	shift := e.Shift
+	if shift.IsZero() {
+		shift = defaultCompareShift
+	}
+	loc := time.UTC
+	if e.Evaluation.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(e.Evaluation.TimeZone); err != nil {
+			return fmt.Errorf("time zone: %w", err)
+		}
+	}
+	compare := e.Compare
+	if compare == nil {
+		compare = views.Delta
+	}
+
+	current, err := e.evaluate(ctx, client, gte, lt)
+	if err != nil {
+		return err
+	}
+	previous, err := e.evaluate(ctx, client, calendarShift(gte, shift, loc, -1), calendarShift(lt, shift, loc, -1))
+	if err != nil {
+		return err
+	}
+
+	// Realign the previous buckets with the current ones.
+	aligned := make(views.DataFrame, len(previous))
+	for k, s := range previous {
+		as := make(views.DataSeries, len(s))
+		for t, v := range s {
+			as[fields.AsTimestamp(calendarShift(t.Time(), shift, loc, 1))] = v
+		}
+		aligned[k] = as
+	}
+
+	result := EvaluateResult{
+		Data:       views.Compare(current, aligned, compare),
+		Evaluation: e.Evaluation,
+	}
+	logger.LogAttrs(
+		ctx, slog.LevelDebug, "Evaluation compare result",
+		slog.String("shift", shift.String()),
+		slog.Any("annotations", result.Annotations),
+		slog.Any("data_frame", result.Data),
+	)
+	for _, action := range e.Actions {
+		if !action(ctx, cfg, &result) {
+			break
+		}
+	}
+	return nil
+}
+
+func (e EvaluateCompare) evaluate(ctx context.Context, client *clarify.Client, gte, lt time.Time) (views.DataFrame, error) {
+	dataQuery := e.Evaluation.dataQuery(gte, lt, e.RollupBucket)
+	selection, err := client.Clarify().Evaluate(dataQuery).
+		Items(e.Evaluation.Items...).
+		Calculations(e.Evaluation.Calculations...).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return selection.Data, nil
+}
+
+// calendarShift returns t moved by d in the direction of sign, which must be 1
+// or -1. Months and whole days of d are moved by date in loc, while any
+// remainder is moved by a fixed duration.
+func calendarShift(t time.Time, d fields.CalendarDuration, loc *time.Location, sign int) time.Time {
+	const day = 24 * time.Hour
+	days, rem := d.Duration()/day, d.Duration()%day
+	t = t.In(loc).AddDate(0, sign*d.Months(), sign*int(days))
+	return t.Add(time.Duration(sign) * rem)
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestEvaluateCompare(t *testing.T) {
+	gte := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	lt := gte.Add(2 * time.Hour)
+	weekAgo := gte.Add(-7 * 24 * time.Hour)
+
+	var queries []string
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		if req.Method != "clarify.evaluate" {
+			return fmt.Errorf("unexpected method %q", req.Method)
+		}
+		data, err := json.Marshal(req.Params.(map[string]any)["data"])
+		if err != nil {
+			return err
+		}
+		queries = append(queries, string(data))
+		if len(queries) == 1 {
+			return decodeResult(`{"meta":{"total":-1},"data":{
+				"times":["2024-01-08T00:00:00Z","2024-01-08T01:00:00Z"],
+				"series":{"a":[10,20],"b":[1,2]}
+			},"included":{}}`, result)
+		}
+		return decodeResult(`{"meta":{"total":-1},"data":{
+			"times":["2024-01-01T00:00:00Z","2024-01-01T01:00:00Z"],
+			"series":{"a":[5,0],"c":[1,1]}
+		},"included":{}}`, result)
+	})
+	cfg := automation.NewConfig(clarify.NewClient("integration", h)).WithLogger(nil)
+
+	test := func(compare views.CompareFunc, expect views.DataFrame) func(t *testing.T) {
+		return func(t *testing.T) {
+			queries = nil
+			var result views.DataFrame
+			routine := automation.EvaluateCompare{
+				TimeFunc: func(time.Time) (time.Time, time.Time) {
+					return gte, lt
+				},
+				RollupBucket: fields.FixedCalendarDuration(time.Hour),
+				Compare:      compare,
+				Actions: []automation.ActionFunc{
+					func(ctx context.Context, cfg *automation.Config, r *automation.EvaluateResult) bool {
+						result = r.Data
+						return true
+					},
+				},
+			}
+			if err := routine.Do(context.Background(), cfg); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(queries) != 2 {
+				t.Fatalf("Unexpected number of requests:\n got: %d\nwant: %d", len(queries), 2)
+			}
+			if s := weekAgo.Format(time.RFC3339); !strings.Contains(queries[1], s) {
+				t.Errorf("Expected previous query to contain %s, got: %s", s, queries[1])
+			}
+			if !reflect.DeepEqual(result, expect) {
+				t.Errorf("Unexpected result:\n got: %v\nwant: %v", result, expect)
+			}
+		}
+	}
+
+	t0 := fields.AsTimestamp(gte)
+	t1 := fields.AsTimestamp(gte.Add(time.Hour))
+	t.Run("delta", test(nil, views.DataFrame{
+		"a": {t0: 5, t1: 20},
+	}))
+	t.Run("ratio", test(views.Ratio, views.DataFrame{
+		"a": {t0: 2},
+	}))
+}
+
+func TestEvaluateCompareDST(t *testing.T) {
+	// Daylight saving time ends in Europe/Oslo on 2024-10-27, so the week
+	// before the current window is 169 hours back.
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Skipf("Time zone not available: %v", err)
+	}
+	gte := time.Date(2024, 10, 28, 0, 0, 0, 0, oslo)
+	lt := gte.AddDate(0, 0, 2)
+
+	var queries []string
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		data, err := json.Marshal(req.Params.(map[string]any)["data"])
+		if err != nil {
+			return err
+		}
+		queries = append(queries, string(data))
+		if len(queries) == 1 {
+			return decodeResult(`{"meta":{"total":-1},"data":{
+				"times":["2024-10-27T23:00:00Z","2024-10-28T23:00:00Z"],
+				"series":{"a":[10,20]}
+			},"included":{}}`, result)
+		}
+		return decodeResult(`{"meta":{"total":-1},"data":{
+			"times":["2024-10-20T22:00:00Z","2024-10-21T22:00:00Z"],
+			"series":{"a":[5,5]}
+		},"included":{}}`, result)
+	})
+	cfg := automation.NewConfig(clarify.NewClient("integration", h)).WithLogger(nil)
+
+	var result views.DataFrame
+	routine := automation.EvaluateCompare{
+		Evaluation: automation.Evaluation{
+			DataQueryOptions: automation.DataQueryOptions{TimeZone: "Europe/Oslo"},
+		},
+		TimeFunc: func(time.Time) (time.Time, time.Time) {
+			return gte, lt
+		},
+		RollupBucket: fields.FixedCalendarDuration(24 * time.Hour),
+		Actions: []automation.ActionFunc{
+			func(ctx context.Context, cfg *automation.Config, r *automation.EvaluateResult) bool {
+				result = r.Data
+				return true
+			},
+		},
+	}
+	if err := routine.Do(context.Background(), cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(queries) != 2 {
+		t.Fatalf("Unexpected number of requests:\n got: %d\nwant: %d", len(queries), 2)
+	}
+	if s := "2024-10-21T00:00:00+02:00"; !strings.Contains(queries[1], s) {
+		t.Errorf("Expected previous query to contain %s, got: %s", s, queries[1])
+	}
+	expect := views.DataFrame{
+		"a": {
+			fields.AsTimestamp(gte):                  5,
+			fields.AsTimestamp(gte.AddDate(0, 0, 1)): 15,
+		},
+	}
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("Unexpected result:\n got: %v\nwant: %v", result, expect)
+	}
+}
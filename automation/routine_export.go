@@ -0,0 +1,361 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+// ExportFormat describe the output format for ExportItems and ExportSignals.
+type ExportFormat string
+
+// Supported export formats.
+const (
+	// ExportJSONL writes one JSON encoded resource per line.
+	ExportJSONL ExportFormat = "jsonl"
+
+	// ExportCSV writes a header row followed by one row per resource, with
+	// selected meta fields and the configured label keys as columns.
+	ExportCSV ExportFormat = "csv"
+
+	// ExportTable writes a human readable table with the ID, name, labels and
+	// last updated time of each resource, for interactive use in a terminal.
+	// When label keys are configured, only those labels are included.
+	ExportTable ExportFormat = "table"
+)
+
+// OutputValueKey is the configuration value key that export routines use as a
+// fallback when no format is specified. The automationcli package sets this
+// value from the -output flag. See Config.Value for lookup rules.
+const OutputValueKey = "output"
+
+// UnmarshalText sets f from text, returning an error wrapping ErrBadConfig if
+// the format is unknown.
+func (f *ExportFormat) UnmarshalText(text []byte) error {
+	switch v := ExportFormat(text); v {
+	case ExportJSONL, ExportCSV, ExportTable:
+		*f = v
+		return nil
+	}
+	return fmt.Errorf("%w: unknown export format %q", ErrBadConfig, text)
+}
+
+// exportFormat returns format if it's set, or the format from the configured
+// output value.
+func exportFormat(cfg *Config, format ExportFormat) (ExportFormat, error) {
+	if format != "" {
+		return format, nil
+	}
+	return ValueFromConfig(cfg, OutputValueKey, ExportJSONL)
+}
+
+// ExportItems writes items matching a filter to a writer, e.g. for inventory
+// reports or offline reconciliation. The routine does not perform any writes
+// against Clarify, and thus behaves the same in dry-run mode. Register it with
+// a name such as "export-items" to make it available from automationcli.
+type ExportItems struct {
+	// ItemsFilter selects the items to export. If nil, all items are exported.
+	ItemsFilter fields.ResourceFilterType
+
+	// Format sets the output format. The default is the OutputValueKey
+	// configuration value if set, or ExportJSONL.
+	Format ExportFormat
+
+	// LabelKeys lists label keys to include as "labels.<key>" columns in the
+	// CSV format, or in the labels column in the table format. Multiple label
+	// values are joined by "|". Labels are always included in the JSONL format.
+	LabelKeys []string
+
+	// Writer is the writer to export to. The default is os.Stdout.
+	Writer io.Writer
+}
+
+var _ Routine = ExportItems{}
+
+func (e ExportItems) Do(ctx context.Context, cfg *Config) error {
+	client := cfg.Client()
+	format, err := exportFormat(cfg, e.Format)
+	if err != nil {
+		return err
+	}
+	w, err := newExportWriter(e.Writer, format, e.LabelKeys, "visible")
+	if err != nil {
+		return err
+	}
+
+	query := fields.Query().Sort("id").Limit(selectItemsPageSize)
+	if e.ItemsFilter != nil {
+		query = query.Where(e.ItemsFilter)
+	}
+	for {
+		if err := cfg.Checkpoint(ctx); err != nil {
+			return err
+		}
+		results, err := client.Clarify().SelectItems(query).Do(ctx)
+		if err != nil {
+			return fmt.Errorf("select items: %w", err)
+		}
+		for _, item := range results.Data {
+			a := item.Attributes
+			err := w.write(exportRecord{
+				resource:    item,
+				id:          item.ID,
+				meta:        item.Meta,
+				name:        a.Name,
+				description: a.Description,
+				valueType:   a.ValueType,
+				sourceType:  a.SourceType,
+				engUnit:     a.EngUnit,
+				labels:      a.Labels,
+				extra:       []string{strconv.FormatBool(a.Visible)},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(results.Data) < query.GetLimit() {
+			break
+		}
+		query = query.NextPage()
+	}
+	return w.close(ctx, cfg, "Export items completed")
+}
+
+// ExportSignals writes signals matching a filter to a writer, e.g. for
+// inventory reports or offline reconciliation. The routine does not perform
+// any writes against Clarify, and thus behaves the same in dry-run mode.
+// Register it with a name such as "export-signals" to make it available from
+// automationcli.
+type ExportSignals struct {
+	// Integrations lists the IDs of the integrations to export signals from.
+	Integrations []string
+
+	// SignalsFilter selects the signals to export. If nil, all signals are
+	// exported.
+	SignalsFilter fields.ResourceFilterType
+
+	// Format sets the output format. The default is the OutputValueKey
+	// configuration value if set, or ExportJSONL.
+	Format ExportFormat
+
+	// LabelKeys lists label keys to include as "labels.<key>" columns in the
+	// CSV format, or in the labels column in the table format. Multiple label
+	// values are joined by "|". Labels are always included in the JSONL format.
+	LabelKeys []string
+
+	// Writer is the writer to export to. The default is os.Stdout.
+	Writer io.Writer
+}
+
+var _ Routine = ExportSignals{}
+
+func (e ExportSignals) Do(ctx context.Context, cfg *Config) error {
+	client := cfg.Client()
+	format, err := exportFormat(cfg, e.Format)
+	if err != nil {
+		return err
+	}
+	w, err := newExportWriter(e.Writer, format, e.LabelKeys, "integration", "input", "item")
+	if err != nil {
+		return err
+	}
+
+	for _, integrationID := range e.Integrations {
+		query := fields.Query().Sort("id").Limit(selectSignalsPageSize)
+		if e.SignalsFilter != nil {
+			query = query.Where(e.SignalsFilter)
+		}
+		for {
+			if err := cfg.Checkpoint(ctx); err != nil {
+				return err
+			}
+			results, err := client.Admin().SelectSignals(integrationID, query).Do(ctx)
+			if err != nil {
+				return fmt.Errorf("select signals: %w", err)
+			}
+			for _, signal := range results.Data {
+				a := signal.Attributes
+				itemID, _ := signal.Relationships.ItemID()
+				err := w.write(exportRecord{
+					resource:    signal,
+					id:          signal.ID,
+					meta:        signal.Meta,
+					name:        a.Name,
+					description: a.Description,
+					valueType:   a.ValueType,
+					sourceType:  a.SourceType,
+					engUnit:     a.EngUnit,
+					labels:      a.Labels,
+					extra:       []string{integrationID, a.Input, itemID},
+				})
+				if err != nil {
+					return err
+				}
+			}
+			if len(results.Data) < query.GetLimit() {
+				break
+			}
+			query = query.NextPage()
+		}
+	}
+	return w.close(ctx, cfg, "Export signals completed")
+}
+
+// exportWriter writes resources in either the JSONL, CSV or table format.
+type exportWriter struct {
+	w         io.Writer
+	csv       *csv.Writer
+	table     *tabwriter.Writer
+	labelKeys []string
+	count     int
+}
+
+var exportColumns = []string{"id", "name", "description", "valueType", "sourceType", "engUnit", "createdAt", "updatedAt"}
+
+func newExportWriter(w io.Writer, format ExportFormat, labelKeys []string, extraColumns ...string) (*exportWriter, error) {
+	if w == nil {
+		w = os.Stdout
+	}
+	ew := &exportWriter{w: w, labelKeys: labelKeys}
+	switch format {
+	case "", ExportJSONL:
+		return ew, nil
+	case ExportTable:
+		ew.table = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		if _, err := io.WriteString(ew.table, "ID\tNAME\tLABELS\tUPDATED\n"); err != nil {
+			return nil, err
+		}
+		return ew, nil
+	case ExportCSV:
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+
+	ew.csv = csv.NewWriter(w)
+	header := append(append([]string{}, exportColumns...), extraColumns...)
+	for _, k := range labelKeys {
+		header = append(header, "labels."+k)
+	}
+	if err := ew.csv.Write(header); err != nil {
+		return nil, err
+	}
+	return ew, nil
+}
+
+// exportRecord describes a single resource to export.
+type exportRecord struct {
+	// resource is encoded as is in the JSONL format.
+	resource any
+
+	// The remaining fields compose a row in the CSV and table formats.
+	id          string
+	meta        views.Meta
+	name        string
+	description string
+	valueType   views.ValueType
+	sourceType  views.SourceType
+	engUnit     string
+	labels      fields.Labels
+	extra       []string
+}
+
+// write writes a single resource. The export count is only incremented when
+// the write succeeds.
+func (ew *exportWriter) write(r exportRecord) error {
+	if err := ew.writeRecord(r); err != nil {
+		return err
+	}
+	ew.count++
+	return nil
+}
+
+func (ew *exportWriter) writeRecord(r exportRecord) error {
+	if ew.table != nil {
+		_, err := fmt.Fprintf(ew.table, "%s\t%s\t%s\t%s\n", r.id, r.name, ew.tableLabels(r.labels), r.meta.UpdatedAt.Format(time.RFC3339))
+		return err
+	}
+	if ew.csv == nil {
+		b, err := json.Marshal(r.resource)
+		if err != nil {
+			return err
+		}
+		_, err = ew.w.Write(append(b, '\n'))
+		return err
+	}
+
+	row := []string{
+		r.id,
+		r.name,
+		r.description,
+		string(r.valueType),
+		string(r.sourceType),
+		r.engUnit,
+		r.meta.CreatedAt.Format(time.RFC3339),
+		r.meta.UpdatedAt.Format(time.RFC3339),
+	}
+	row = append(row, r.extra...)
+	for _, k := range ew.labelKeys {
+		row = append(row, strings.Join(r.labels[k], "|"))
+	}
+	return ew.csv.Write(row)
+}
+
+// tableLabels formats labels as "<key>=<value>|<value>" pairs separated by
+// space, sorted by key.
+func (ew *exportWriter) tableLabels(labels fields.Labels) string {
+	keys := ew.labelKeys
+	if len(keys) == 0 {
+		keys = slices.Sorted(maps.Keys(labels))
+	}
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if values := labels[k]; len(values) > 0 {
+			pairs = append(pairs, k+"="+strings.Join(values, "|"))
+		}
+	}
+	return strings.Join(pairs, " ")
+}
+
+func (ew *exportWriter) close(ctx context.Context, cfg *Config, msg string) error {
+	if ew.table != nil {
+		if err := ew.table.Flush(); err != nil {
+			return err
+		}
+	}
+	if ew.csv != nil {
+		ew.csv.Flush()
+		if err := ew.csv.Error(); err != nil {
+			return err
+		}
+	}
+	cfg.AddCount("export_count", ew.count)
+	cfg.Logger().LogAttrs(ctx, slog.LevelInfo, msg, slog.Int("export_count", ew.count))
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+)
+
+func TestExportItems(t *testing.T) {
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		if req.Method != "clarify.selectItems" {
+			return fmt.Errorf("unexpected method %q", req.Method)
+		}
+		return decodeResult(`{"meta":{"total":-1},"data":[
+			{"type":"items","id":"i1","attributes":{"name":"a","valueType":"numeric","visible":true,"labels":{"site":["oslo","bergen"]}},"meta":{"createdAt":"2024-01-01T00:00:00Z","updatedAt":"2024-01-02T00:00:00Z"}},
+			{"type":"items","id":"i2","attributes":{"name":"b, c","valueType":"enum","labels":{}},"meta":{"createdAt":"2024-01-01T00:00:00Z","updatedAt":"2024-01-01T00:00:00Z"}}
+		],"included":{}}`, result)
+	})
+	cfg := automation.NewConfig(clarify.NewClient("integration", h)).WithLogger(nil)
+
+	var csv strings.Builder
+	routine := automation.ExportItems{
+		Format:    automation.ExportCSV,
+		LabelKeys: []string{"site"},
+		Writer:    &csv,
+	}
+	if err := routine.Do(context.Background(), cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect := `id,name,description,valueType,sourceType,engUnit,createdAt,updatedAt,visible,labels.site
+i1,a,,numeric,,,2024-01-01T00:00:00Z,2024-01-02T00:00:00Z,true,oslo|bergen
+i2,"b, c",,enum,,,2024-01-01T00:00:00Z,2024-01-01T00:00:00Z,false,
+`
+	if result := csv.String(); result != expect {
+		t.Errorf("Unexpected CSV output:\n%s", diffLines(strings.Split(expect, "\n"), strings.Split(result, "\n")))
+	}
+
+	var jsonl strings.Builder
+	routine = automation.ExportItems{Writer: &jsonl}
+	if err := routine.Do(context.Background(), cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(jsonl.String(), "\n"), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], `{"type":"items","id":"i1"`) {
+		t.Errorf("Unexpected JSONL output:\n%s", jsonl.String())
+	}
+
+	var table strings.Builder
+	routine = automation.ExportItems{Writer: &table}
+	tableCfg := cfg.WithValues(map[string]any{automation.OutputValueKey: "table"})
+	if err := routine.Do(context.Background(), tableCfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect = `ID  NAME  LABELS            UPDATED
+i1  a     site=oslo|bergen  2024-01-02T00:00:00Z
+i2  b, c                    2024-01-01T00:00:00Z
+`
+	if result := table.String(); result != expect {
+		t.Errorf("Unexpected table output:\n%s", diffLines(strings.Split(expect, "\n"), strings.Split(result, "\n")))
+	}
+
+	routine = automation.ExportItems{Writer: &table}
+	badCfg := cfg.WithValues(map[string]any{automation.OutputValueKey: "xml"})
+	if err := routine.Do(context.Background(), badCfg); !errors.Is(err, automation.ErrBadConfig) {
+		t.Errorf("Unexpected error for unknown output value:\n got: %v\nwant: %v", err, automation.ErrBadConfig)
+	}
+
+	routine = automation.ExportItems{Format: "xml", Writer: &jsonl}
+	if err := routine.Do(context.Background(), cfg); err == nil {
+		t.Errorf("Expected error for unknown format")
+	}
+}
+
+func TestExportSignals(t *testing.T) {
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		if req.Method != "admin.selectSignals" {
+			return fmt.Errorf("unexpected method %q", req.Method)
+		}
+		return decodeResult(`{"meta":{"total":-1},"data":[
+			{"type":"signals","id":"s1","attributes":{"name":"a","input":"temp"},"meta":{"createdAt":"2024-01-01T00:00:00Z","updatedAt":"2024-01-01T00:00:00Z"},"relationships":{"item":{"data":{"type":"items","id":"i1"}}}}
+		],"included":{}}`, result)
+	})
+	cfg := automation.NewConfig(clarify.NewClient("integration", h)).WithLogger(nil)
+
+	var csv strings.Builder
+	routine := automation.ExportSignals{
+		Integrations: []string{"integration"},
+		Format:       automation.ExportCSV,
+		Writer:       &csv,
+	}
+	if err := routine.Do(context.Background(), cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect := `id,name,description,valueType,sourceType,engUnit,createdAt,updatedAt,integration,input,item
+s1,a,,,,,2024-01-01T00:00:00Z,2024-01-01T00:00:00Z,integration,temp,i1
+`
+	if result := csv.String(); result != expect {
+		t.Errorf("Unexpected CSV output:\n%s", diffLines(strings.Split(expect, "\n"), strings.Split(result, "\n")))
+	}
+}
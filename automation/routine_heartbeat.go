@@ -0,0 +1,114 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+// HeartbeatInput describe the heartbeat for a single signal input.
+type HeartbeatInput struct {
+	// Interval sets the minimum duration between heartbeats. If zero, a
+	// heartbeat is inserted each time the routine runs.
+	Interval time.Duration
+
+	// Value is the keep-alive value to insert.
+	Value float64
+}
+
+// Heartbeat inserts keep-alive values to signals of the integration that is
+// used by the configured client. This can prevent gap detection downstream
+// from triggering when a source is intentionally idle. Run the routine
+// repeatedly, e.g. via the automationcli -interval flag; on each run, a value is
+// inserted for all inputs where the interval has passed since the last
+// heartbeat.
+//
+// The time of the last heartbeat per input is recorded in the configured state
+// store. The routine respects the DryRun configuration.
+type Heartbeat struct {
+	// Inputs maps signal input keys to their heartbeat configuration.
+	Inputs map[string]HeartbeatInput
+
+	// StateKey sets the key used to record heartbeat times in the state store.
+	// The default is "heartbeat/" followed by the routine path.
+	StateKey string
+}
+
+var _ Routine = Heartbeat{}
+
+func (h Heartbeat) Do(ctx context.Context, cfg *Config) error {
+	logger := cfg.Logger()
+	client := cfg.Client()
+	state := cfg.StateStore()
+
+	stateKey := h.StateKey
+	if stateKey == "" {
+		stateKey = "heartbeat/" + cfg.RoutinePath()
+	}
+
+	last := make(map[string]time.Time)
+	switch v, found, err := state.Load(ctx, stateKey); {
+	case err != nil:
+		return fmt.Errorf("load state: %w", err)
+	case found:
+		if err := json.Unmarshal([]byte(v), &last); err != nil {
+			return fmt.Errorf("load state: %w", err)
+		}
+	}
+
+	if err := cfg.Checkpoint(ctx); err != nil {
+		return err
+	}
+	now := cfg.Now()
+	ts := fields.AsTimestamp(now)
+	df := make(views.DataFrame)
+	for _, input := range slices.Sorted(maps.Keys(h.Inputs)) {
+		hi := h.Inputs[input]
+		if t, ok := last[input]; ok && now.Before(t.Add(hi.Interval)) {
+			continue
+		}
+		df[input] = views.DataSeries{ts: hi.Value}
+		last[input] = now
+	}
+	logger.LogAttrs(ctx, slog.LevelDebug, "Heartbeat data", slog.Any("data", df))
+	if len(df) == 0 {
+		return nil
+	}
+
+	if !cfg.DryRun() {
+		if _, err := client.Insert(df).Do(ctx); err != nil {
+			return fmt.Errorf("insert: %w", err)
+		}
+		b, err := json.Marshal(last)
+		if err != nil {
+			return fmt.Errorf("store state: %w", err)
+		}
+		if err := state.Store(ctx, stateKey, string(b)); err != nil {
+			return fmt.Errorf("store state: %w", err)
+		}
+	}
+	cfg.AddCount("series_count", len(df))
+	logger.LogAttrs(ctx, slog.LevelInfo, "Heartbeat completed", slog.Int("series_count", len(df)))
+	return nil
+}
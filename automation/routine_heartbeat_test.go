@@ -0,0 +1,79 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestHeartbeat(t *testing.T) {
+	var inserted views.DataFrame
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		if req.Method != "integration.insert" {
+			return fmt.Errorf("unexpected method %q", req.Method)
+		}
+		inserted = req.Params.(map[string]any)["data"].(views.DataFrame)
+		return decodeResult(`{"signalsByInput":{}}`, result)
+	})
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	client := clarify.NewClient("integration", h, clarify.WithClock(func() time.Time { return now }))
+	cfg := automation.NewConfig(client).WithLogger(nil).WithStateStore(automation.NewMemoryStateStore())
+
+	routine := automation.Heartbeat{
+		Inputs: map[string]automation.HeartbeatInput{
+			"fast": {Value: 1},
+			"slow": {Interval: time.Hour, Value: -1},
+		},
+	}
+
+	test := func(offset time.Duration, dryRun bool, expect views.DataFrame) func(t *testing.T) {
+		return func(t *testing.T) {
+			now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).Add(offset)
+			inserted = nil
+			if err := routine.Do(context.Background(), cfg.WithDryRun(dryRun)); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if fmt.Sprint(inserted) != fmt.Sprint(expect) {
+				t.Errorf("Unexpected inserted data:\n got: %v\nwant: %v", inserted, expect)
+			}
+		}
+	}
+	ts := func(offset time.Duration) fields.Timestamp {
+		return fields.AsTimestamp(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).Add(offset))
+	}
+
+	t.Run("dry-run", test(0, true, nil))
+	t.Run("first", test(0, false, views.DataFrame{
+		"fast": {ts(0): 1},
+		"slow": {ts(0): -1},
+	}))
+	t.Run("before interval", test(30*time.Minute, false, views.DataFrame{
+		"fast": {ts(30 * time.Minute): 1},
+	}))
+	t.Run("after interval", test(time.Hour, false, views.DataFrame{
+		"fast": {ts(time.Hour): 1},
+		"slow": {ts(time.Hour): -1},
+	}))
+}
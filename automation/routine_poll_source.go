@@ -0,0 +1,130 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+// Source describe the interface for reading current values from an external
+// system, such as an OPC UA server or a PLC.
+type Source interface {
+	// Poll returns data keyed by signal input key. The now parameter holds the
+	// current time, and should be used as the sample time unless the source
+	// provides its own timestamps.
+	Poll(ctx context.Context, now time.Time) (views.DataFrame, error)
+}
+
+// The SourceFunc type is an adapter to allow the use of ordinary functions as
+// sources. If f is a function with the appropriate signature, SourceFunc(f) is
+// a Source that calls f.
+type SourceFunc func(ctx context.Context, now time.Time) (views.DataFrame, error)
+
+func (f SourceFunc) Poll(ctx context.Context, now time.Time) (views.DataFrame, error) {
+	return f(ctx, now)
+}
+
+var _ Source = NodeSource{}
+
+// NodeSource is a reference Source implementation for systems that address
+// values by node ID, such as OPC UA servers. The source reads all configured
+// nodes in a single call to Read, and maps the values to signal inputs.
+type NodeSource struct {
+	// Nodes maps signal input keys to node IDs.
+	Nodes map[string]string
+
+	// Read must return the current values for the passed in node IDs, in the
+	// same order. NaN values are treated as missing, and are skipped.
+	Read func(ctx context.Context, nodeIDs []string) ([]float64, error)
+
+	// Truncate, if set, truncates sample times to a multiple of the duration.
+	// This can help aligning samples from several sources.
+	Truncate time.Duration
+}
+
+func (s NodeSource) Poll(ctx context.Context, now time.Time) (views.DataFrame, error) {
+	inputs := slices.Sorted(maps.Keys(s.Nodes))
+	nodeIDs := make([]string, 0, len(inputs))
+	for _, input := range inputs {
+		nodeIDs = append(nodeIDs, s.Nodes[input])
+	}
+
+	values, err := s.Read(ctx, nodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != len(nodeIDs) {
+		return nil, fmt.Errorf("read returned %d values for %d nodes", len(values), len(nodeIDs))
+	}
+
+	ts := fields.AsTimestamp(now)
+	if s.Truncate > 0 {
+		ts = ts.Truncate(s.Truncate)
+	}
+	df := make(views.DataFrame, len(inputs))
+	for i, input := range inputs {
+		if math.IsNaN(values[i]) {
+			continue
+		}
+		df[input] = views.DataSeries{ts: values[i]}
+	}
+	return df, nil
+}
+
+// PollSource polls a source once, and inserts the result to the client's
+// integration. Run the routine repeatedly, e.g. via the automationcli -interval
+// flag, to use it as a gateway. The routine respects the DryRun configuration,
+// in which case the polled data is logged, but not inserted.
+type PollSource struct {
+	// Source is the source to poll.
+	Source Source
+}
+
+var _ Routine = PollSource{}
+
+func (p PollSource) Do(ctx context.Context, cfg *Config) error {
+	logger := cfg.Logger()
+	client := cfg.Client()
+
+	if err := cfg.Checkpoint(ctx); err != nil {
+		return err
+	}
+	df, err := p.Source.Poll(ctx, cfg.Now())
+	if err != nil {
+		return fmt.Errorf("poll source: %w", err)
+	}
+	logger.LogAttrs(ctx, slog.LevelDebug, "Polled source", slog.Any("data", df))
+	if len(df) == 0 {
+		return nil
+	}
+
+	if !cfg.DryRun() {
+		if _, err := client.Insert(df).Do(ctx); err != nil {
+			return fmt.Errorf("insert: %w", err)
+		}
+	}
+	cfg.AddCount("series_count", len(df))
+	logger.LogAttrs(ctx, slog.LevelInfo, "Poll source completed", slog.Int("series_count", len(df)))
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestPollSource(t *testing.T) {
+	var inserted views.DataFrame
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		if req.Method != "integration.insert" {
+			return fmt.Errorf("unexpected method %q", req.Method)
+		}
+		inserted = req.Params.(map[string]any)["data"].(views.DataFrame)
+		return decodeResult(`{"signalsByInput":{}}`, result)
+	})
+	now := time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC)
+	client := clarify.NewClient("integration", h, clarify.WithClock(func() time.Time { return now }))
+	cfg := automation.NewConfig(client).WithLogger(nil)
+
+	var readIDs []string
+	routine := automation.PollSource{
+		Source: automation.NodeSource{
+			Nodes: map[string]string{
+				"temp":     "ns=2;s=Temperature",
+				"pressure": "ns=2;s=Pressure",
+			},
+			Read: func(ctx context.Context, nodeIDs []string) ([]float64, error) {
+				readIDs = nodeIDs
+				return []float64{math.NaN(), 21.5}, nil
+			},
+			Truncate: time.Minute,
+		},
+	}
+
+	if err := routine.Do(context.Background(), cfg.WithDryRun(true)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if inserted != nil {
+		t.Fatalf("Expected no insert in dry-run, got: %v", inserted)
+	}
+
+	if err := routine.Do(context.Background(), cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expect := []string{"ns=2;s=Pressure", "ns=2;s=Temperature"}; !slices.Equal(readIDs, expect) {
+		t.Errorf("Unexpected node IDs:\n got: %v\nwant: %v", readIDs, expect)
+	}
+	expect := views.DataFrame{
+		"temp": {fields.AsTimestamp(now.Truncate(time.Minute)): 21.5},
+	}
+	if fmt.Sprint(inserted) != fmt.Sprint(expect) {
+		t.Errorf("Unexpected inserted data:\n got: %v\nwant: %v", inserted, expect)
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+// PruneAnnotations allows removing annotations in bulk from items and signals,
+// e.g. to clean up leftovers from retired automation tools. An annotation is
+// removed if its key match one of the Prefixes, or if Allow is set and the key
+// does not match any of the Allow prefixes. The routine respects the DryRun
+// and EarlyOut configurations. The removed keys are logged for each resource,
+// also in dry-run mode.
+//
+// Note that annotations written by this SDK, such as by PublishSignals, use
+// the fields.AnnotationKeyPrefix prefix. When using Allow, include this prefix
+// to keep them.
+type PruneAnnotations struct {
+	// Prefixes lists annotation key prefixes to remove.
+	Prefixes []string
+
+	// Allow, if set, lists annotation key prefixes to keep. Annotations that
+	// does not match any of the prefixes are removed.
+	Allow []string
+
+	// Integrations must list the IDs of the integrations that the matched
+	// items are published from; see UpdateItems. If empty, no items are
+	// pruned.
+	Integrations []string
+
+	// ItemsFilter selects the items to prune. If nil, all items are matched.
+	ItemsFilter fields.ResourceFilterType
+
+	// Signals, if true, prunes annotations from signals that belong to the
+	// integration of the client.
+	Signals bool
+
+	// SignalsFilter selects the signals to prune. If nil, all signals are
+	// matched.
+	SignalsFilter fields.ResourceFilterType
+}
+
+var _ Routine = PruneAnnotations{}
+
+func (p PruneAnnotations) Do(ctx context.Context, cfg *Config) error {
+	if len(p.Integrations) > 0 {
+		if err := p.pruneItems(ctx, cfg); err != nil {
+			return err
+		}
+	}
+	if p.Signals {
+		if err := p.pruneSignals(ctx, cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prune removes matching keys from a, and returns the sorted list of removed
+// keys.
+func (p PruneAnnotations) prune(a fields.Annotations) []string {
+	hasPrefix := func(prefixes []string, key string) bool {
+		return slices.ContainsFunc(prefixes, func(prefix string) bool {
+			return strings.HasPrefix(key, prefix)
+		})
+	}
+	var removed []string
+	for _, k := range slices.Sorted(maps.Keys(a)) {
+		if hasPrefix(p.Prefixes, k) || (len(p.Allow) > 0 && !hasPrefix(p.Allow, k)) {
+			delete(a, k)
+			removed = append(removed, k)
+		}
+	}
+	return removed
+}
+
+func (p PruneAnnotations) pruneItems(ctx context.Context, cfg *Config) error {
+	logger := cfg.Logger()
+	client := cfg.Client()
+	earlyOut := cfg.EarlyOut()
+	dryRun := cfg.DryRun()
+
+	var matchCount, updateCount, errorCount int
+	defer func() {
+		cfg.AddCount("match_count", matchCount)
+		cfg.AddCount("update_count", updateCount)
+		cfg.AddCount("error_count", errorCount)
+		logger.LogAttrs(ctx, slog.LevelInfo, "Prune item annotations completed",
+			slog.Int("match_count", matchCount),
+			slog.Int("update_count", updateCount),
+			slog.Int("error_count", errorCount),
+		)
+	}()
+
+	query := fields.Query().Sort("id").Limit(selectItemsPageSize)
+	if p.ItemsFilter != nil {
+		query = query.Where(p.ItemsFilter)
+	}
+	update := UpdateItems{Integrations: p.Integrations}
+	for {
+		if err := cfg.Checkpoint(ctx); err != nil {
+			return err
+		}
+		results, err := client.Clarify().SelectItems(query).Do(ctx)
+		if err != nil {
+			return fmt.Errorf("select items: %w", err)
+		}
+		matchCount += len(results.Data)
+
+		changed := make(map[string]views.ItemSave)
+		for _, item := range results.Data {
+			save := views.SavedItem(item)
+			if removed := p.prune(save.Annotations); len(removed) > 0 {
+				logger.LogAttrs(ctx, slog.LevelInfo, "Item annotations pruned",
+					slog.String("item_id", item.ID),
+					slog.Any("keys", removed),
+				)
+				changed[item.ID] = save
+			}
+		}
+		if !dryRun && len(changed) > 0 {
+			n, err := update.publish(ctx, cfg, changed)
+			updateCount += n
+			switch {
+			case err != nil && earlyOut:
+				return err
+			case err != nil:
+				logger.LogAttrs(ctx, slog.LevelError, "Prune item annotations failed", AttrError(err))
+				errorCount += len(changed) - n
+			}
+		} else {
+			updateCount += len(changed)
+		}
+
+		if len(results.Data) < query.GetLimit() {
+			return nil
+		}
+		query = query.NextPage()
+	}
+}
+
+func (p PruneAnnotations) pruneSignals(ctx context.Context, cfg *Config) error {
+	logger := cfg.Logger()
+	client := cfg.Client()
+	earlyOut := cfg.EarlyOut()
+	dryRun := cfg.DryRun()
+
+	var matchCount, updateCount, errorCount int
+	defer func() {
+		cfg.AddCount("match_count", matchCount)
+		cfg.AddCount("update_count", updateCount)
+		cfg.AddCount("error_count", errorCount)
+		logger.LogAttrs(ctx, slog.LevelInfo, "Prune signal annotations completed",
+			slog.Int("match_count", matchCount),
+			slog.Int("update_count", updateCount),
+			slog.Int("error_count", errorCount),
+		)
+	}()
+
+	query := fields.Query().Sort("id").Limit(selectSignalsPageSize)
+	if p.SignalsFilter != nil {
+		query = query.Where(p.SignalsFilter)
+	}
+	for {
+		if err := cfg.Checkpoint(ctx); err != nil {
+			return err
+		}
+		results, err := client.Admin().SelectSignals(client.IntegrationID(), query).Do(ctx)
+		if err != nil {
+			return fmt.Errorf("select signals: %w", err)
+		}
+		matchCount += len(results.Data)
+
+		changed := make(map[string]views.SignalSave)
+		for _, signal := range results.Data {
+			save := views.SavedSignal(signal)
+			if removed := p.prune(save.Annotations); len(removed) > 0 {
+				logger.LogAttrs(ctx, slog.LevelInfo, "Signal annotations pruned",
+					slog.String("signal_id", signal.ID),
+					slog.String("input", signal.Attributes.Input),
+					slog.Any("keys", removed),
+				)
+				changed[signal.Attributes.Input] = save
+			}
+		}
+		if !dryRun && len(changed) > 0 {
+			_, err := client.SaveSignals(changed).Do(ctx)
+			switch {
+			case err != nil && earlyOut:
+				return fmt.Errorf("save signals: %w", err)
+			case err != nil:
+				logger.LogAttrs(ctx, slog.LevelError, "Prune signal annotations failed", AttrError(err))
+				errorCount += len(changed)
+			default:
+				updateCount += len(changed)
+			}
+		} else {
+			updateCount += len(changed)
+		}
+
+		if len(results.Data) < query.GetLimit() {
+			return nil
+		}
+		query = query.NextPage()
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestPruneAnnotations(t *testing.T) {
+	var published map[string]views.ItemSave
+	var saved map[string]views.SignalSave
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		params := req.Params.(map[string]any)
+		switch req.Method {
+		case "clarify.selectItems":
+			return decodeResult(`{"meta":{"total":-1},"data":[
+				{"type":"items","id":"i1","meta":{"annotations":{"old-tool/id":"1","keep":"x"}},"attributes":{"name":"a"}},
+				{"type":"items","id":"i2","meta":{"annotations":{"keep":"y"}},"attributes":{"name":"b"}}
+			],"included":{}}`, result)
+		case "admin.selectSignals":
+			if params["integration"] == "source" {
+				return decodeResult(`{"meta":{"total":-1},"data":[
+					{"type":"signals","id":"s1","relationships":{"item":{"data":{"type":"items","id":"i1"}}}}
+				],"included":{}}`, result)
+			}
+			return decodeResult(`{"meta":{"total":-1},"data":[
+				{"type":"signals","id":"s2","meta":{"annotations":{"old-tool/id":"2","other":"z"}},"attributes":{"input":"in2","name":"c"}},
+				{"type":"signals","id":"s3","meta":{"annotations":{"keep":"z"}},"attributes":{"input":"in3","name":"d"}}
+			],"included":{}}`, result)
+		case "admin.publishSignals":
+			published = params["itemsBySignal"].(map[string]views.ItemSave)
+			return decodeResult(`{"itemsBySignal":{}}`, result)
+		case "integration.saveSignals":
+			saved = params["signalsByInput"].(map[string]views.SignalSave)
+			return decodeResult(`{"signalsByInput":{}}`, result)
+		}
+		return fmt.Errorf("unexpected method %q", req.Method)
+	})
+
+	cfg := automation.NewConfig(clarify.NewClient("integration", h)).WithLogger(nil)
+	routine := automation.PruneAnnotations{
+		Prefixes:     []string{"old-tool/"},
+		Allow:        []string{"keep", "old-tool/"},
+		Integrations: []string{"source"},
+		Signals:      true,
+	}
+
+	if err := routine.Do(context.Background(), cfg.WithDryRun(true)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if published != nil || saved != nil {
+		t.Fatalf("Expected no updates in dry-run, got: %v, %v", published, saved)
+	}
+
+	if err := routine.Do(context.Background(), cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if keys := slices.Sorted(maps.Keys(published)); !slices.Equal(keys, []string{"s1"}) {
+		t.Fatalf("Unexpected published signals:\n got: %v\nwant: [s1]", keys)
+	}
+	if expect := (fields.Annotations{"keep": "x"}); !reflect.DeepEqual(published["s1"].Annotations, expect) {
+		t.Errorf("Unexpected item annotations:\n got: %v\nwant: %v", published["s1"].Annotations, expect)
+	}
+	if keys := slices.Sorted(maps.Keys(saved)); !slices.Equal(keys, []string{"in2"}) {
+		t.Fatalf("Unexpected saved signals:\n got: %v\nwant: [in2]", keys)
+	}
+	if s := saved["in2"]; len(s.Annotations) != 0 || s.Name != "c" {
+		t.Errorf("Unexpected saved signal:\n got: %+v\nwant: {name: c, annotations: {}}", s)
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -18,14 +18,19 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"time"
 
+	"github.com/clarify/clarify-go"
 	"github.com/clarify/clarify-go/fields"
 	"github.com/clarify/clarify-go/views"
 )
 
 const (
-	selectSignalsPageSize  = 1000
-	publishSignalsPageSize = 500
+	selectSignalsPageSize      = 1000
+	selectIntegrationsPageSize = 1000
+	publishSignalsPageSize     = 500
 )
 
 // PublishSignals allows you to automate signal publishing from one or more
@@ -33,9 +38,16 @@ const (
 // configurations.
 type PublishSignals struct {
 	// Integrations must list the IDs of the integrations to publish signals
-	// from. If this list is empty, the rule set is a no-op.
+	// from. If this list and IntegrationsFilter are both empty, the rule set is
+	// a no-op.
 	Integrations []string
 
+	// IntegrationsFilter can optionally be specified to publish signals from
+	// all integrations matching the filter, e.g. by label, in addition to the
+	// integrations listed in Integrations. This keeps configurations stable as
+	// new integrations are added.
+	IntegrationsFilter fields.ResourceFilterType
+
 	// SignalsFilter can optionally be specified to limit which signals to
 	// publish.
 	SignalsFilter fields.ResourceFilterType
@@ -47,6 +59,72 @@ type PublishSignals struct {
 	// Transforms is a list of transforms to apply when publishing the signals.
 	// The transforms are applied in order.
 	Transforms []func(item *views.ItemSave)
+
+	// SignalTransforms is a list of transforms that are given access to the
+	// source signal, such as transform.NameTemplate. They are applied in order
+	// after Transforms.
+	SignalTransforms []func(signal views.Signal, item *views.ItemSave)
+
+	// TargetFlushDuration, if set, makes the number of items published per
+	// request adaptive. When a publish request takes longer than the target
+	// duration, the batch size is halved; when it completes in less than half
+	// the target duration, the batch size is doubled up to the default maximum.
+	// This helps routines to reach a checkpoint within a bounded time, e.g.
+	// when shutting down gracefully.
+	TargetFlushDuration time.Duration
+
+	// Summary, if set, is filled with the outcome per signal when the routine
+	// returns, also when it returns an error. This can be used for
+	// reconciliation reports. Signals that are not processed because the
+	// routine returns early, are not included.
+	Summary *PublishSignalsSummary
+
+	// OnBeforeFlush, if set, is called before each publish request with the
+	// integration ID and the items to publish, keyed by signal ID. The items
+	// must not be modified. The hook is not called in dry-run mode.
+	OnBeforeFlush func(ctx context.Context, integrationID string, items map[string]views.ItemSave)
+
+	// OnAfterFlush, if set, is called after each publish request with the
+	// request result or error. This can be used for custom bookkeeping, such
+	// as persisting signal to item mappings to an external system or emitting
+	// metrics per batch. The result is nil if err is set.
+	OnAfterFlush func(ctx context.Context, integrationID string, result *clarify.PublishSignalsResult, err error)
+}
+
+// PublishOutcome describe the outcome of publishing a single signal.
+type PublishOutcome string
+
+// Publish outcomes reported by PublishSignals.
+const (
+	// PublishedNew indicates that the signal was published as a new item.
+	PublishedNew PublishOutcome = "published-new"
+
+	// Republished indicates that an existing item was updated because either
+	// the signal or the transform version has changed.
+	Republished PublishOutcome = "republished"
+
+	// SkippedUpToDate indicates that the existing item was up-to-date.
+	SkippedUpToDate PublishOutcome = "skipped-up-to-date"
+
+	// PublishFailed indicates that the publish request for the signal failed.
+	PublishFailed PublishOutcome = "failed"
+)
+
+// PublishSignalsSummary describe the outcome of a PublishSignals run.
+type PublishSignalsSummary struct {
+	// Outcomes holds the publish outcome keyed by signal ID.
+	Outcomes map[string]PublishOutcome `json:"outcomes"`
+}
+
+// Count returns the number of signals with the passed in outcome.
+func (s PublishSignalsSummary) Count(outcome PublishOutcome) int {
+	var n int
+	for _, o := range s.Outcomes {
+		if o == outcome {
+			n++
+		}
+	}
+	return n
 }
 
 var _ Routine = PublishSignals{}
@@ -57,65 +135,127 @@ func (p PublishSignals) Do(ctx context.Context, cfg *Config) error {
 	earlyOut := cfg.EarlyOut()
 	dryRun := cfg.DryRun()
 
+	integrations := p.Integrations
+	summary := PublishSignalsSummary{Outcomes: make(map[string]PublishOutcome)}
 	var publishCount, errorCount int
+	items := make(map[string]views.ItemSave)
 	defer func() {
+		// Items that are still pending when returning early were never
+		// published, and have no outcome.
+		for id := range items {
+			delete(summary.Outcomes, id)
+		}
+		cfg.AddCount("integration_count", len(integrations))
+		cfg.AddCount("publish_count", publishCount)
+		cfg.AddCount("new_count", summary.Count(PublishedNew))
+		cfg.AddCount("republish_count", summary.Count(Republished))
+		cfg.AddCount("skip_count", summary.Count(SkippedUpToDate))
+		cfg.AddCount("error_count", errorCount)
 		logger.LogAttrs(ctx, slog.LevelInfo, "Publish signals completed",
-			slog.Int("integration_count", len(p.Integrations)),
+			slog.Int("integration_count", len(integrations)),
 			slog.Int("publish_count", publishCount),
+			slog.Int("new_count", summary.Count(PublishedNew)),
+			slog.Int("republish_count", summary.Count(Republished)),
+			slog.Int("skip_count", summary.Count(SkippedUpToDate)),
 			slog.Int("error_count", errorCount),
 		)
+		if p.Summary != nil {
+			*p.Summary = summary
+		}
 	}()
 
-	if err := ctx.Err(); err != nil {
+	if err := cfg.Checkpoint(ctx); err != nil {
 		return err
 	}
 
-	// We iterate signals without requesting the total count. This is an
-	// optimization bet that total % limit == 0 is uncommon.
-	query := fields.Query().Sort("id").Limit(selectSignalsPageSize)
-	if p.SignalsFilter != nil {
-		query = query.Where(p.SignalsFilter)
+	if p.IntegrationsFilter != nil {
+		ids, err := selectIntegrationIDs(ctx, cfg, p.IntegrationsFilter)
+		if err != nil {
+			return fmt.Errorf("select integrations: %w", err)
+		}
+		for _, id := range ids {
+			if !slices.Contains(integrations, id) {
+				integrations = append(integrations, id)
+			}
+		}
 	}
 
-	items := make(map[string]views.ItemSave)
+	batchSize := publishSignalsPageSize
+	publish := func(integrationID string, batch map[string]views.ItemSave) error {
+		if p.OnBeforeFlush != nil {
+			p.OnBeforeFlush(ctx, integrationID, batch)
+		}
+		start := time.Now()
+		result, err := client.Admin().PublishSignals(integrationID, batch).Do(ctx)
+		p.adaptBatchSize(&batchSize, time.Since(start))
+		if p.OnAfterFlush != nil {
+			p.OnAfterFlush(ctx, integrationID, result, err)
+		}
+		if err != nil {
+			for id := range batch {
+				summary.Outcomes[id] = PublishFailed
+			}
+			if earlyOut {
+				return fmt.Errorf("publish signals: %w", err)
+			}
+			logger.LogAttrs(ctx, slog.LevelError, "Published items failed (flush)", AttrError(err), slog.Int("publish_count", len(batch)))
+			errorCount += len(batch)
+			return nil
+		}
+		logger.LogAttrs(ctx, slog.LevelInfo, "Published items (flush)", slog.Int("publish_count", len(batch)))
+		publishCount += len(batch)
+		logger.LogAttrs(ctx, slog.LevelDebug, "Publish results", slog.Any("result", result))
+		return nil
+	}
 	flush := func(integrationID string) error {
 		logger.LogAttrs(ctx, slog.LevelInfo, "Publish signals", slog.Int("publish_count", publishCount))
 		logger.LogAttrs(ctx, slog.LevelDebug, "Publish parameters", slog.Group("params", slog.Any("itemBySignal", items)))
 
-		if !dryRun {
-			result, err := client.Admin().PublishSignals(integrationID, items).Do(ctx)
-			if err != nil {
-				if earlyOut {
-					return fmt.Errorf("publish signals: %w", err)
-				}
-				logger.LogAttrs(ctx, slog.LevelError, "Published items failed (flush)", AttrError(err), slog.Int("publish_count", len(items)))
-				errorCount += len(items)
-			} else {
-				logger.LogAttrs(ctx, slog.LevelInfo, "Published items (flush)", slog.Int("publish_count", len(items)))
-				publishCount += len(items)
-				logger.LogAttrs(ctx, slog.LevelDebug, "Publish results", slog.Any("result", result))
-			}
-		} else {
+		if dryRun {
 			publishCount += len(items)
+			items = make(map[string]views.ItemSave)
+			return nil
 		}
 
-		items = make(map[string]views.ItemSave)
+		// Publish in batches, checking for cancellation between each request.
+		keys := slices.Sorted(maps.Keys(items))
+		for len(keys) > 0 {
+			if err := cfg.Checkpoint(ctx); err != nil {
+				return err
+			}
+			n := min(batchSize, len(keys))
+			batch := make(map[string]views.ItemSave, n)
+			for _, k := range keys[:n] {
+				batch[k] = items[k]
+				delete(items, k)
+			}
+			keys = keys[n:]
+			if err := publish(integrationID, batch); err != nil {
+				return err
+			}
+		}
 		return nil
 	}
 
-	for _, id := range p.Integrations {
+	for _, id := range integrations {
+		// We iterate signals without requesting the total count. This is an
+		// optimization bet that total % limit == 0 is uncommon.
+		query := fields.Query().Sort("id").Limit(selectSignalsPageSize)
+		if p.SignalsFilter != nil {
+			query = query.Where(p.SignalsFilter)
+		}
 		more := true
 		for more {
-			if err := ctx.Err(); err != nil {
+			if err := cfg.Checkpoint(ctx); err != nil {
 				return err
 			}
 
 			var err error
-			more, err = p.addItems(ctx, cfg, items, id, query)
+			more, err = p.addItems(ctx, cfg, items, summary.Outcomes, id, query)
 			if err != nil {
 				return err
 			}
-			if len(items) >= publishSignalsPageSize {
+			if len(items) >= batchSize {
 				if err := flush(id); err != nil {
 					return err
 				}
@@ -131,9 +271,42 @@ func (p PublishSignals) Do(ctx context.Context, cfg *Config) error {
 	return nil
 }
 
+// selectIntegrationIDs returns the IDs of all integrations matching filter.
+func selectIntegrationIDs(ctx context.Context, cfg *Config, filter fields.ResourceFilterType) ([]string, error) {
+	query := fields.Query().Where(filter).Sort("id").Limit(selectIntegrationsPageSize)
+
+	var ids []string
+	for {
+		results, err := doTryAgain(ctx, cfg, 0, cfg.Client().Admin().SelectIntegrations(query).Do)
+		if err != nil {
+			return nil, err
+		}
+		for _, integration := range results.Data {
+			ids = append(ids, integration.ID)
+		}
+		if len(results.Data) < query.GetLimit() {
+			return ids, nil
+		}
+		query = query.NextPage()
+	}
+}
+
+// adaptBatchSize adjusts size based on the duration of the previous publish
+// request when p.TargetFlushDuration is set.
+func (p PublishSignals) adaptBatchSize(size *int, d time.Duration) {
+	switch {
+	case p.TargetFlushDuration <= 0:
+	case d > p.TargetFlushDuration:
+		*size = max(*size/2, 1)
+	case d < p.TargetFlushDuration/2:
+		*size = min(*size*2, publishSignalsPageSize)
+	}
+}
+
 // addItems adds items that require update to dest from all signals matching
-// the integration ID and query.
-func (p PublishSignals) addItems(ctx context.Context, cfg *Config, dest map[string]views.ItemSave, integrationID string, query fields.ResourceQuery) (bool, error) {
+// the integration ID and query. The expected outcome for each signal is
+// recorded in outcomes.
+func (p PublishSignals) addItems(ctx context.Context, cfg *Config, dest map[string]views.ItemSave, outcomes map[string]PublishOutcome, integrationID string, query fields.ResourceQuery) (bool, error) {
 	logger := cfg.Logger()
 	client := cfg.Client()
 
@@ -161,6 +334,7 @@ func (p PublishSignals) addItems(ctx context.Context, cfg *Config, dest map[stri
 				slog.String("signal_id", signal.ID),
 				slog.String("item_id", signal.Relationships.Item.Data.ID),
 			)
+			outcomes[signal.ID] = SkippedUpToDate
 			continue
 		}
 
@@ -176,6 +350,9 @@ func (p PublishSignals) addItems(ctx context.Context, cfg *Config, dest map[stri
 		for _, f := range p.Transforms {
 			f(&item)
 		}
+		for _, f := range p.SignalTransforms {
+			f(signal, &item)
+		}
 
 		// After running configured transformations, set automation package
 		// annotations.
@@ -185,6 +362,11 @@ func (p PublishSignals) addItems(ctx context.Context, cfg *Config, dest map[stri
 		item.Annotations.Set(AnnotationPublisherSignalID, signal.ID)
 
 		dest[signal.ID] = item
+		if prevItem.ID != "" {
+			outcomes[signal.ID] = Republished
+		} else {
+			outcomes[signal.ID] = PublishedNew
+		}
 	}
 
 	var more bool
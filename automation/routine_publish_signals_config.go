@@ -0,0 +1,131 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/clarify/clarify-go/fields"
+)
+
+// FilterConfig holds a resource filter that can be decoded from either a JSON
+// filter object, or a JSON string with a filter expression as accepted by
+// fields.ParseFilter.
+type FilterConfig struct {
+	fields.ResourceFilter
+}
+
+func (f *FilterConfig) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		rf, err := fields.ParseFilter(s)
+		if err != nil {
+			return err
+		}
+		f.ResourceFilter = rf
+		return nil
+	}
+	return f.ResourceFilter.UnmarshalJSON(data)
+}
+
+// PublishSignalsConfig describe a serializable configuration for a
+// PublishSignals routine. This allows operators to adjust the publishing scope
+// without recompiling. Transforms are referenced by name, and resolved from a
+// TransformRegistry. If TransformVersion is empty, the version calculated by
+// the registry is used.
+type PublishSignalsConfig struct {
+	Integrations        []string             `json:"integrations"`
+	IntegrationsFilter  *FilterConfig        `json:"integrationsFilter,omitempty"`
+	SignalsFilter       *FilterConfig        `json:"signalsFilter,omitempty"`
+	TransformVersion    string               `json:"transformVersion"`
+	Transforms          []string             `json:"transforms"`
+	TargetFlushDuration fields.FixedDuration `json:"targetFlushDuration"`
+}
+
+// Routine returns a PublishSignals routine for c, where transforms are looked
+// up by name in registry. An error wrapping ErrBadConfig is returned if a
+// transform is not found.
+func (c PublishSignalsConfig) Routine(registry *TransformRegistry) (PublishSignals, error) {
+	transforms, version, err := registry.Transforms(c.Transforms...)
+	if err != nil {
+		return PublishSignals{}, err
+	}
+	if c.TransformVersion != "" {
+		version = c.TransformVersion
+	}
+	r := PublishSignals{
+		Integrations:        c.Integrations,
+		TransformVersion:    version,
+		Transforms:          transforms,
+		TargetFlushDuration: c.TargetFlushDuration.Duration,
+	}
+	if c.IntegrationsFilter != nil {
+		r.IntegrationsFilter = c.IntegrationsFilter.ResourceFilter
+	}
+	if c.SignalsFilter != nil {
+		r.SignalsFilter = c.SignalsFilter.ResourceFilter
+	}
+	return r, nil
+}
+
+// LoadPublishSignals reads PublishSignals configurations from a JSON file,
+// keyed by routine name, and returns them as routines. Transforms are looked
+// up by name in registry. An example file:
+//
+//	{
+//	  "publish-pier": {
+//	    "integrations": ["<integration-id>"],
+//	    "signalsFilter": "labels.location=pier",
+//	    "transformVersion": "v1",
+//	    "transforms": ["title-case"]
+//	  }
+//	}
+//
+// Errors wrap ErrBadConfig.
+func LoadPublishSignals(name string, registry *TransformRegistry) (Routines, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadConfig, err)
+	}
+	return DecodePublishSignals(bytes.NewReader(b), registry)
+}
+
+// DecodePublishSignals is like LoadPublishSignals, but reads the configuration
+// from r. Unknown fields are rejected.
+func DecodePublishSignals(r io.Reader, registry *TransformRegistry) (Routines, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	var configs map[string]PublishSignalsConfig
+	if err := dec.Decode(&configs); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadConfig, err)
+	}
+
+	routines := make(Routines, len(configs))
+	for name, c := range configs {
+		r, err := c.Routine(registry)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		routines[name] = r
+	}
+	return routines, nil
+}
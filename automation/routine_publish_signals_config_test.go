@@ -0,0 +1,85 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestDecodePublishSignals(t *testing.T) {
+	registry := automation.NewTransformRegistry().Register("upper", func(item *views.ItemSave) {
+		item.Name = strings.ToUpper(item.Name)
+	})
+
+	routines, err := automation.DecodePublishSignals(strings.NewReader(`{
+		"a": {
+			"integrations": ["i1"],
+			"signalsFilter": "labels.location=pier",
+			"transformVersion": "v1",
+			"transforms": ["upper"],
+			"targetFlushDuration": "PT30S"
+		},
+		"b": {
+			"integrationsFilter": {"labels.site": {"$in": ["x"]}}
+		}
+	}`), registry)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	a := routines["a"].(automation.PublishSignals)
+	if a.Integrations[0] != "i1" || a.TransformVersion != "v1" || a.TargetFlushDuration != 30*time.Second {
+		t.Errorf("Unexpected routine a: %+v", a)
+	}
+	if f, expect := fmt.Sprint(a.SignalsFilter), `{"labels.location":{"$in":["pier"]}}`; f != expect {
+		t.Errorf("Unexpected signals filter:\n got: %s\nwant: %s", f, expect)
+	}
+	item := views.ItemSave{}
+	item.Name = "temp"
+	for _, f := range a.Transforms {
+		f(&item)
+	}
+	if item.Name != "TEMP" {
+		t.Errorf("Unexpected transformed name:\n got: %s\nwant: %s", item.Name, "TEMP")
+	}
+
+	b := routines["b"].(automation.PublishSignals)
+	if _, version, _ := registry.Transforms(); b.TransformVersion != version {
+		t.Errorf("Unexpected transform version:\n got: %s\nwant: %s", b.TransformVersion, version)
+	}
+	if f, expect := fmt.Sprint(b.IntegrationsFilter), `{"labels.site":{"$in":["x"]}}`; f != expect {
+		t.Errorf("Unexpected integrations filter:\n got: %s\nwant: %s", f, expect)
+	}
+	if b.SignalsFilter != nil {
+		t.Errorf("Unexpected signals filter: %v", b.SignalsFilter)
+	}
+
+	for _, config := range []string{
+		`{"a": {"transforms": ["lower"]}}`,
+		`{"a": {"unknown": true}}`,
+		`{"a": {"signalsFilter": "a="}}`,
+	} {
+		if _, err := automation.DecodePublishSignals(strings.NewReader(config), registry); !errors.Is(err, automation.ErrBadConfig) {
+			t.Errorf("Unexpected error for %s:\n got: %v\nwant: %v", config, err, automation.ErrBadConfig)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestPublishSignalsIntegrationsFilter(t *testing.T) {
+	var selected []string
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		switch req.Method {
+		case "admin.selectIntegrations":
+			return decodeResult(`{"meta":{"total":-1},"data":[
+				{"type":"integrations","id":"a","attributes":{"name":"A","labels":{"site":["x"]}}},
+				{"type":"integrations","id":"b","attributes":{"name":"B","labels":{"site":["x"]}}}
+			],"included":{}}`, result)
+		case "admin.selectSignals":
+			selected = append(selected, req.Params.(map[string]any)["integration"].(string))
+			return decodeResult(`{"meta":{"total":-1},"data":[],"included":{}}`, result)
+		}
+		return fmt.Errorf("unexpected method %q", req.Method)
+	})
+	cfg := automation.NewConfig(clarify.NewClient("integration", h)).WithLogger(nil)
+
+	routine := automation.PublishSignals{
+		Integrations:       []string{"b", "c"},
+		IntegrationsFilter: fields.Comparisons{"labels.site": fields.Equal("x")},
+	}
+	if err := routine.Do(context.Background(), cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expect := []string{"b", "c", "a"}; !slices.Equal(selected, expect) {
+		t.Errorf("Unexpected integrations:\n got: %v\nwant: %v", selected, expect)
+	}
+}
+
+func TestPublishSignalsSummary(t *testing.T) {
+	const hash = "220596a7b7b4ea2ac5abb6a13e6198f161443226"
+	item := func(id, signalID, attributesHash string) string {
+		return fmt.Sprintf(`{"type":"items","id":%q,"meta":{"annotations":{
+			"clarify/clarify-go/publisher/signal-id":%q,
+			"clarify/clarify-go/publisher/signal-attributes":%q,
+			"clarify/clarify-go/publisher/transform-version":""
+		}},"attributes":{"visible":true}}`, id, signalID, attributesHash)
+	}
+	signal := func(id string) string {
+		return fmt.Sprintf(`{"type":"signals","id":%q,"meta":{"attributesHash":%q},"attributes":{"name":%q}}`, id, hash, id)
+	}
+
+	test := func(publishErr error, expect automation.PublishSignalsSummary) func(t *testing.T) {
+		return func(t *testing.T) {
+			h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+				switch req.Method {
+				case "admin.selectSignals":
+					return decodeResult(`{"meta":{"total":3},"data":[`+
+						signal("new")+`,`+signal("same")+`,`+signal("changed")+
+						`],"included":{"items":[`+
+						item("i1", "same", hash)+`,`+item("i2", "changed", "0000")+
+						`]}}`, result)
+				case "admin.publishSignals":
+					if publishErr != nil {
+						return publishErr
+					}
+					return decodeResult(`{"itemsBySignal":{}}`, result)
+				}
+				return fmt.Errorf("unexpected method %q", req.Method)
+			})
+			cfg := automation.NewConfig(clarify.NewClient("integration", h)).WithLogger(nil)
+
+			var summary automation.PublishSignalsSummary
+			var flushed []string
+			var flushErr error
+			routine := automation.PublishSignals{
+				Integrations: []string{"a"},
+				Summary:      &summary,
+				OnBeforeFlush: func(_ context.Context, _ string, items map[string]views.ItemSave) {
+					flushed = append(flushed, slices.Sorted(maps.Keys(items))...)
+				},
+				OnAfterFlush: func(_ context.Context, _ string, _ *clarify.PublishSignalsResult, err error) {
+					flushErr = err
+				},
+			}
+			if err := routine.Do(context.Background(), cfg); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !maps.Equal(summary.Outcomes, expect.Outcomes) {
+				t.Errorf("Unexpected outcomes:\n got: %v\nwant: %v", summary.Outcomes, expect.Outcomes)
+			}
+			if expect := []string{"changed", "new"}; !slices.Equal(flushed, expect) {
+				t.Errorf("Unexpected flushed items:\n got: %v\nwant: %v", flushed, expect)
+			}
+			if !errors.Is(flushErr, publishErr) {
+				t.Errorf("Unexpected flush error:\n got: %v\nwant: %v", flushErr, publishErr)
+			}
+		}
+	}
+
+	t.Run("success", test(nil, automation.PublishSignalsSummary{
+		Outcomes: map[string]automation.PublishOutcome{
+			"new":     automation.PublishedNew,
+			"same":    automation.SkippedUpToDate,
+			"changed": automation.Republished,
+		},
+	}))
+	t.Run("failure", test(errors.New("publish failed"), automation.PublishSignalsSummary{
+		Outcomes: map[string]automation.PublishOutcome{
+			"new":     automation.PublishFailed,
+			"same":    automation.SkippedUpToDate,
+			"changed": automation.PublishFailed,
+		},
+	}))
+}
+
+func TestPublishSignalsPagingPerIntegration(t *testing.T) {
+	const pageSize = 1000
+	signals := func(integrationID string, n int) string {
+		var sb strings.Builder
+		for i := range n {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			fmt.Fprintf(&sb, `{"type":"signals","id":"%s%04d","meta":{"attributesHash":"0000"},"attributes":{"name":"x"}}`, integrationID, i)
+		}
+		return sb.String()
+	}
+
+	var skips []string
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		switch req.Method {
+		case "admin.selectSignals":
+			params := req.Params.(map[string]any)
+			integration := params["integration"].(string)
+			skip := params["query"].(fields.ResourceQuery).GetSkip()
+			skips = append(skips, fmt.Sprintf("%s:%d", integration, skip))
+			var data string
+			switch {
+			case integration == "a" && skip == 0:
+				data = signals("a", pageSize)
+			case integration == "b" && skip == 0:
+				data = signals("b", 1)
+			}
+			return decodeResult(`{"meta":{"total":-1},"data":[`+data+`],"included":{}}`, result)
+		case "admin.publishSignals":
+			return decodeResult(`{"itemsBySignal":{}}`, result)
+		}
+		return fmt.Errorf("unexpected method %q", req.Method)
+	})
+	cfg := automation.NewConfig(clarify.NewClient("integration", h)).WithLogger(nil)
+
+	var summary automation.PublishSignalsSummary
+	routine := automation.PublishSignals{
+		Integrations: []string{"a", "b"},
+		Summary:      &summary,
+	}
+	if err := routine.Do(context.Background(), cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expect := []string{"a:0", "a:1000", "b:0"}; !slices.Equal(skips, expect) {
+		t.Errorf("Unexpected selectSignals pages:\n got: %v\nwant: %v", skips, expect)
+	}
+	if n, expect := summary.Count(automation.PublishedNew), pageSize+1; n != expect {
+		t.Errorf("Unexpected number of published signals:\n got: %d\nwant: %d", n, expect)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+// ReportDataQuality reports gaps and coverage for the data of items matching a
+// filter, using views.GapReportRange. Items with a coverage below MinCoverage
+// are logged as warnings, and a summary is logged when completed. The routine
+// respects the DryRun configuration.
+type ReportDataQuality struct {
+	// ItemsFilter selects the items to report on. If nil, all items are
+	// matched.
+	ItemsFilter fields.ResourceFilterType
+
+	// TimeRange returns the time range to report on, relative to the current
+	// time. The default is fields.LastCompleteHours(24).
+	TimeRange fields.TimeRangeFunc
+
+	// ExpectedInterval sets the expected maximum duration between values.
+	// Longer durations are reported as gaps. Must be set.
+	ExpectedInterval time.Duration
+
+	// MinCoverage sets the coverage percentage below which an item is logged
+	// as a warning. The default is 100.
+	MinCoverage float64
+
+	// InputPrefix, if set, enables inserting the coverage per item to the
+	// client's integration. The input key is composed of InputPrefix followed
+	// by the item ID, and the start of the time range is used as sample time.
+	InputPrefix string
+
+	// Writer, if set, receives the report with one JSON object per item and
+	// line, holding the item ID, coverage and gaps.
+	Writer io.Writer
+}
+
+var _ Routine = ReportDataQuality{}
+
+func (r ReportDataQuality) Do(ctx context.Context, cfg *Config) error {
+	logger := cfg.Logger()
+	client := cfg.Client()
+
+	if r.ExpectedInterval <= 0 {
+		return errors.New("expected interval must be positive")
+	}
+	timeRange := r.TimeRange
+	if timeRange == nil {
+		timeRange = fields.LastCompleteHours(24)
+	}
+	minCoverage := r.MinCoverage
+	if minCoverage == 0 {
+		minCoverage = 100
+	}
+
+	gte, lt := timeRange(cfg.Now())
+	itemIDs, err := selectItemIDs(ctx, cfg, r.ItemsFilter, 0)
+	if err != nil {
+		return fmt.Errorf("select items: %w", err)
+	}
+	if err := cfg.Checkpoint(ctx); err != nil {
+		return err
+	}
+	df, err := dataFrameByFilter(ctx, cfg, r.ItemsFilter, gte, lt, nil, 0)
+	if err != nil {
+		return fmt.Errorf("data frame: %w", err)
+	}
+	for _, id := range itemIDs {
+		if _, ok := df[id]; !ok {
+			df[id] = views.DataSeries{}
+		}
+	}
+
+	report := views.GapReportRange(df, fields.AsTimestamp(gte), fields.AsTimestamp(lt), r.ExpectedInterval)
+	var gapCount, lowCoverageCount int
+	var coverageSum float64
+	insert := make(views.DataFrame)
+	for _, id := range slices.Sorted(maps.Keys(report)) {
+		q := report[id]
+		gapCount += len(q.Gaps)
+		coverageSum += q.Coverage
+		if q.Coverage < minCoverage {
+			lowCoverageCount++
+			logger.LogAttrs(ctx, slog.LevelWarn, "Low data coverage",
+				slog.String("item_id", id),
+				slog.Float64("coverage", q.Coverage),
+				slog.Int("gaps", len(q.Gaps)),
+			)
+		}
+		if r.InputPrefix != "" {
+			insert[r.InputPrefix+id] = views.DataSeries{fields.AsTimestamp(gte): q.Coverage}
+		}
+		if r.Writer != nil {
+			b, err := json.Marshal(itemQuality{ItemID: id, SeriesQuality: q})
+			if err != nil {
+				return err
+			}
+			if _, err := r.Writer.Write(append(b, '\n')); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(insert) > 0 && !cfg.DryRun() {
+		if _, err := client.Insert(insert).Do(ctx); err != nil {
+			return fmt.Errorf("insert: %w", err)
+		}
+	}
+
+	cfg.AddCount("item_count", len(report))
+	cfg.AddCount("gap_count", gapCount)
+	cfg.AddCount("low_coverage_count", lowCoverageCount)
+	attrs := []slog.Attr{
+		slog.Time("gte", gte),
+		slog.Time("lt", lt),
+		slog.Int("item_count", len(report)),
+		slog.Int("gap_count", gapCount),
+		slog.Int("low_coverage_count", lowCoverageCount),
+	}
+	if len(report) > 0 {
+		attrs = append(attrs, slog.Float64("mean_coverage", coverageSum/float64(len(report))))
+	}
+	logger.LogAttrs(ctx, slog.LevelInfo, "Report data quality completed", attrs...)
+	return nil
+}
+
+// itemQuality is the JSON format used by ReportDataQuality.Writer.
+type itemQuality struct {
+	ItemID string `json:"itemId"`
+	views.SeriesQuality
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestReportDataQuality(t *testing.T) {
+	gte := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	lt := gte.Add(4 * time.Hour)
+
+	var inserted views.DataFrame
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		switch req.Method {
+		case "clarify.selectItems":
+			return decodeResult(`{"meta":{"total":-1},"data":[
+				{"type":"items","id":"a"},
+				{"type":"items","id":"b"}
+			],"included":{}}`, result)
+		case "clarify.dataFrame":
+			return decodeResult(`{"meta":{"total":-1},"data":{
+				"times":["2024-01-01T00:00:00Z","2024-01-01T01:00:00Z","2024-01-01T03:00:00Z","2024-01-01T04:00:00Z"],
+				"series":{"a":[1,1,1,1]}
+			},"included":{}}`, result)
+		case "integration.insert":
+			inserted = req.Params.(map[string]any)["data"].(views.DataFrame)
+			return decodeResult(`{"signalsByInput":{}}`, result)
+		}
+		return fmt.Errorf("unexpected method %q", req.Method)
+	})
+	cfg := automation.NewConfig(clarify.NewClient("integration", h)).WithLogger(nil)
+
+	var buf strings.Builder
+	routine := automation.ReportDataQuality{
+		TimeRange: func(time.Time) (time.Time, time.Time) {
+			return gte, lt
+		},
+		ExpectedInterval: time.Hour,
+		InputPrefix:      "coverage.",
+		Writer:           &buf,
+	}
+	if err := routine.Do(context.Background(), cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expect := `{"itemId":"a","gaps":[{"start":"2024-01-01T01:00:00Z","end":"2024-01-01T03:00:00Z","duration":"PT2H"}],"coverage":75}
+{"itemId":"b","gaps":[{"start":"2024-01-01T00:00:00Z","end":"2024-01-01T04:00:00Z","duration":"PT4H"}],"coverage":0}
+`
+	if result := buf.String(); result != expect {
+		t.Errorf("Unexpected report:\n%s", diffLines(strings.Split(expect, "\n"), strings.Split(result, "\n")))
+	}
+
+	ts := fields.AsTimestamp(gte)
+	expectInserted := views.DataFrame{
+		"coverage.a": {ts: 75},
+		"coverage.b": {ts: 0},
+	}
+	if fmt.Sprint(inserted) != fmt.Sprint(expectInserted) {
+		t.Errorf("Unexpected inserted data:\n got: %v\nwant: %v", inserted, expectInserted)
+	}
+}
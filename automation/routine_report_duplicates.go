@@ -0,0 +1,192 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+// DuplicateGrouping describe how ReportDuplicateItems groups items.
+type DuplicateGrouping string
+
+// Supported duplicate groupings.
+const (
+	// DuplicateBySignalAttributes groups items by the attributes hash of the
+	// signals they are published from.
+	DuplicateBySignalAttributes DuplicateGrouping = "signal-attributes"
+
+	// DuplicateByNameLabels groups items by their name and labels.
+	DuplicateByNameLabels DuplicateGrouping = "name-labels"
+)
+
+// DuplicateGroup describe a group of items that are likely duplicates.
+type DuplicateGroup struct {
+	Key   string          `json:"key"`
+	Items []DuplicateItem `json:"items"`
+}
+
+// DuplicateItem describe a published item within a DuplicateGroup.
+type DuplicateItem struct {
+	ItemID      string `json:"itemId"`
+	Name        string `json:"name"`
+	Integration string `json:"integration"`
+	SignalID    string `json:"signalId"`
+	Input       string `json:"input"`
+}
+
+// ReportDuplicateItems scans signals across integrations, and reports items
+// that are likely duplicates, e.g. because the same underlying sensor is
+// published twice. Each group of duplicates is logged as a warning, and
+// optionally written to Writer. The routine does not perform any writes
+// against Clarify, and thus behaves the same in dry-run mode.
+type ReportDuplicateItems struct {
+	// Integrations lists the IDs of the integrations to scan.
+	Integrations []string
+
+	// SignalsFilter selects the signals to scan. If nil, all signals are
+	// scanned. Signals that are not published are ignored.
+	SignalsFilter fields.ResourceFilterType
+
+	// GroupBy sets how items are grouped. The default is
+	// DuplicateBySignalAttributes.
+	GroupBy DuplicateGrouping
+
+	// Writer, if set, receives the report with one JSON encoded DuplicateGroup
+	// per line.
+	Writer io.Writer
+}
+
+var _ Routine = ReportDuplicateItems{}
+
+func (r ReportDuplicateItems) Do(ctx context.Context, cfg *Config) error {
+	logger := cfg.Logger()
+	client := cfg.Client()
+
+	var keyFunc func(views.Signal, views.Item) (string, error)
+	switch r.GroupBy {
+	case "", DuplicateBySignalAttributes:
+		keyFunc = func(s views.Signal, _ views.Item) (string, error) {
+			return s.Meta.AttributesHash.String(), nil
+		}
+	case DuplicateByNameLabels:
+		keyFunc = nameLabelsKey
+	default:
+		return fmt.Errorf("unknown duplicate grouping %q", r.GroupBy)
+	}
+
+	// Map keys to item IDs to items; an item is only listed once per key.
+	groups := make(map[string]map[string]DuplicateItem)
+	for _, integrationID := range r.Integrations {
+		query := fields.Query().Sort("id").Limit(selectSignalsPageSize)
+		if r.SignalsFilter != nil {
+			query = query.Where(r.SignalsFilter)
+		}
+		for {
+			if err := cfg.Checkpoint(ctx); err != nil {
+				return err
+			}
+			results, err := client.Admin().SelectSignals(integrationID, query).Include("item").Do(ctx)
+			if err != nil {
+				return fmt.Errorf("select signals: %w", err)
+			}
+			for _, signal := range results.Data {
+				itemID, ok := signal.Relationships.ItemID()
+				if !ok {
+					continue
+				}
+				item, _ := results.Included.ItemByID(itemID)
+				key, err := keyFunc(signal, item)
+				if err != nil {
+					return err
+				}
+				if groups[key] == nil {
+					groups[key] = make(map[string]DuplicateItem)
+				}
+				groups[key][itemID] = DuplicateItem{
+					ItemID:      itemID,
+					Name:        item.Attributes.Name,
+					Integration: integrationID,
+					SignalID:    signal.ID,
+					Input:       signal.Attributes.Input,
+				}
+			}
+			if len(results.Data) < query.GetLimit() {
+				break
+			}
+			query = query.NextPage()
+		}
+	}
+
+	var duplicateCount, itemCount int
+	for _, key := range slices.Sorted(maps.Keys(groups)) {
+		if len(groups[key]) < 2 {
+			continue
+		}
+		group := DuplicateGroup{
+			Key: key,
+			Items: slices.SortedFunc(maps.Values(groups[key]), func(a, b DuplicateItem) int {
+				return cmp.Compare(a.ItemID, b.ItemID)
+			}),
+		}
+		duplicateCount++
+		itemCount += len(group.Items)
+
+		logger.LogAttrs(ctx, slog.LevelWarn, "Duplicate items",
+			slog.String("key", key),
+			slog.Any("items", group.Items),
+		)
+		if r.Writer != nil {
+			b, err := json.Marshal(group)
+			if err != nil {
+				return err
+			}
+			if _, err := r.Writer.Write(append(b, '\n')); err != nil {
+				return err
+			}
+		}
+	}
+
+	cfg.AddCount("duplicate_count", duplicateCount)
+	cfg.AddCount("item_count", itemCount)
+	logger.LogAttrs(ctx, slog.LevelInfo, "Report duplicate items completed",
+		slog.Int("duplicate_count", duplicateCount),
+		slog.Int("item_count", itemCount),
+	)
+	return nil
+}
+
+// nameLabelsKey returns a key composed of the item name and labels, where
+// label values are sorted.
+func nameLabelsKey(_ views.Signal, item views.Item) (string, error) {
+	labels := item.Attributes.Labels.Clone()
+	for k := range labels {
+		slices.Sort(labels[k])
+	}
+	b, err := json.Marshal(labels)
+	if err != nil {
+		return "", err
+	}
+	return item.Attributes.Name + " " + string(b), nil
+}
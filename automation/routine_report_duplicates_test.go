@@ -0,0 +1,79 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+)
+
+func TestReportDuplicateItems(t *testing.T) {
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		if req.Method != "admin.selectSignals" {
+			return fmt.Errorf("unexpected method %q", req.Method)
+		}
+		switch req.Params.(map[string]any)["integration"] {
+		case "i1":
+			return decodeResult(`{"meta":{"total":-1},"data":[
+				{"type":"signals","id":"s1","attributes":{"input":"temp"},"meta":{"attributesHash":"aa"},"relationships":{"item":{"data":{"type":"items","id":"item1"}}}},
+				{"type":"signals","id":"s2","attributes":{"input":"hum"},"meta":{"attributesHash":"bb"},"relationships":{"item":{"data":{"type":"items","id":"item2"}}}},
+				{"type":"signals","id":"s3","attributes":{"input":"raw"},"meta":{"attributesHash":"aa"},"relationships":{"item":{"data":null}}}
+			],"included":{"items":[
+				{"type":"items","id":"item1","attributes":{"name":"Temperature","labels":{"site":["oslo","bergen"]}}},
+				{"type":"items","id":"item2","attributes":{"name":"Humidity","labels":{}}}
+			]}}`, result)
+		case "i2":
+			return decodeResult(`{"meta":{"total":-1},"data":[
+				{"type":"signals","id":"s4","attributes":{"input":"temp"},"meta":{"attributesHash":"aa"},"relationships":{"item":{"data":{"type":"items","id":"item3"}}}}
+			],"included":{"items":[
+				{"type":"items","id":"item3","attributes":{"name":"Temperature","labels":{"site":["bergen","oslo"]}}}
+			]}}`, result)
+		}
+		return fmt.Errorf("unexpected integration")
+	})
+	cfg := automation.NewConfig(clarify.NewClient("i1", h)).WithLogger(nil)
+
+	test := func(groupBy automation.DuplicateGrouping, expect string) func(t *testing.T) {
+		return func(t *testing.T) {
+			var buf bytes.Buffer
+			routine := automation.ReportDuplicateItems{
+				Integrations: []string{"i1", "i2"},
+				GroupBy:      groupBy,
+				Writer:       &buf,
+			}
+			if err := routine.Do(context.Background(), cfg); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result := buf.String(); result != expect {
+				t.Errorf("Unexpected report:\n%s", diffLines(strings.Split(expect, "\n"), strings.Split(result, "\n")))
+			}
+		}
+	}
+
+	t.Run("signal-attributes", test(automation.DuplicateBySignalAttributes,
+		`{"key":"aa","items":[{"itemId":"item1","name":"Temperature","integration":"i1","signalId":"s1","input":"temp"},{"itemId":"item3","name":"Temperature","integration":"i2","signalId":"s4","input":"temp"}]}
+`))
+	t.Run("name-labels", test(automation.DuplicateByNameLabels,
+		`{"key":"Temperature {\"site\":[\"bergen\",\"oslo\"]}","items":[{"itemId":"item1","name":"Temperature","integration":"i1","signalId":"s1","input":"temp"},{"itemId":"item3","name":"Temperature","integration":"i2","signalId":"s4","input":"temp"}]}
+`))
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+// SelfMetrics runs a routine, and inserts metrics about the run as signals to
+// a designated integration. This allows using Clarify to visualize and alert
+// on the health of your automation. The following inputs are inserted, keyed
+// by InputPrefix:
+//
+//   - duration: The run duration in seconds.
+//   - failed: 1 if the routine returned an error, otherwise 0.
+//   - errors: The number of entries logged at the error level.
+//   - <key>: The sum of counts reported by the routine via Config.AddCount,
+//     such as "publish_count".
+//
+// Failures to insert metrics are logged, but does not cause the routine to
+// fail. The routine respects the DryRun configuration.
+type SelfMetrics struct {
+	// Routine is the routine to run.
+	Routine Routine
+
+	// Client is used to insert metrics, and should be configured for the
+	// designated integration. If nil, the configured client is used.
+	Client *clarify.Client
+
+	// InputPrefix is prepended to all input keys. The default is "automation."
+	// followed by the routine path and a ".".
+	InputPrefix string
+}
+
+var _ Routine = SelfMetrics{}
+
+func (m SelfMetrics) Do(ctx context.Context, cfg *Config) error {
+	client := m.Client
+	if client == nil {
+		client = cfg.Client()
+	}
+	prefix := m.InputPrefix
+	switch {
+	case prefix != "":
+	case cfg.RoutinePath() != "":
+		prefix = "automation." + cfg.RoutinePath() + "."
+	default:
+		prefix = "automation."
+	}
+
+	var base slog.Handler = slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError + 1})
+	if cfg.logger != nil {
+		base = cfg.logger.Handler()
+	}
+	state := &countState{counts: make(map[string]int64)}
+	parent := cfg.counter
+	counter := func(key string, n int) {
+		state.lock.Lock()
+		state.counts[key] += int64(n)
+		state.lock.Unlock()
+		if parent != nil {
+			parent(key, n)
+		}
+	}
+	runCfg := cfg.WithLogger(slog.New(&errorCountHandler{Handler: base, state: state})).WithCounter(counter)
+
+	start := cfg.Now()
+	err := m.Routine.Do(ctx, runCfg)
+	end := cfg.Now()
+
+	ts := fields.AsTimestamp(end)
+	failed := 0.0
+	if err != nil {
+		failed = 1
+	}
+	df := views.DataFrame{
+		prefix + "duration": {ts: end.Sub(start).Seconds()},
+		prefix + "failed":   {ts: failed},
+	}
+	state.lock.Lock()
+	df[prefix+"errors"] = views.DataSeries{ts: float64(state.errors)}
+	for k, v := range state.counts {
+		df[prefix+k] = views.DataSeries{ts: float64(v)}
+	}
+	state.lock.Unlock()
+
+	logger := cfg.Logger()
+	logger.LogAttrs(ctx, slog.LevelDebug, "Self metrics", slog.Any("data", df))
+	if !cfg.DryRun() {
+		if _, insertErr := client.Insert(df).Do(ctx); insertErr != nil {
+			logger.LogAttrs(ctx, slog.LevelWarn, "Failed to insert self metrics", AttrError(fmt.Errorf("insert: %w", insertErr)))
+		}
+	}
+	return err
+}
+
+// errorCountHandler wraps a slog handler to count log entries at the error
+// level or above.
+type errorCountHandler struct {
+	slog.Handler
+	state *countState
+}
+
+type countState struct {
+	lock   sync.Mutex
+	errors int64
+	counts map[string]int64
+}
+
+func (h *errorCountHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= slog.LevelError || h.Handler.Enabled(ctx, level)
+}
+
+func (h *errorCountHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level >= slog.LevelError {
+		h.state.lock.Lock()
+		h.state.errors++
+		h.state.lock.Unlock()
+	}
+	if !h.Handler.Enabled(ctx, r.Level) {
+		return nil
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *errorCountHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &errorCountHandler{Handler: h.Handler.WithAttrs(attrs), state: h.state}
+}
+
+func (h *errorCountHandler) WithGroup(name string) slog.Handler {
+	return &errorCountHandler{Handler: h.Handler.WithGroup(name), state: h.state}
+}
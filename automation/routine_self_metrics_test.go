@@ -0,0 +1,141 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestSelfMetrics(t *testing.T) {
+	var inserted views.DataFrame
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		if req.Method != "integration.insert" {
+			return fmt.Errorf("unexpected method %q", req.Method)
+		}
+		inserted = req.Params.(map[string]any)["data"].(views.DataFrame)
+		return decodeResult(`{"signalsByInput":{}}`, result)
+	})
+	client := clarify.NewClient("metrics", h)
+	cfg := automation.NewConfig(client).WithLogger(nil)
+
+	routine := automation.Routines{
+		"publish": automation.RoutineFunc(func(ctx context.Context, cfg *automation.Config) error {
+			cfg.Logger().LogAttrs(ctx, slog.LevelInfo, "Ignored", slog.Int("publish_count", 10))
+			cfg.AddCount("publish_count", 3)
+			cfg.AddCount("error_count", 1)
+			return nil
+		}),
+		"fail": automation.RoutineFunc(func(ctx context.Context, cfg *automation.Config) error {
+			return errors.New("failed")
+		}),
+	}
+	metrics := automation.SelfMetrics{Routine: routine}
+
+	err := metrics.Do(context.Background(), cfg.WithSubRoutineName("job"))
+	if err == nil {
+		t.Fatalf("Expected error from routine")
+	}
+
+	expectKeys := []string{
+		"automation.job.duration",
+		"automation.job.error_count",
+		"automation.job.errors",
+		"automation.job.failed",
+		"automation.job.publish_count",
+	}
+	if keys := slices.Sorted(maps.Keys(inserted)); !slices.Equal(keys, expectKeys) {
+		t.Fatalf("Unexpected inputs:\n got: %v\nwant: %v", keys, expectKeys)
+	}
+	value := func(key string) float64 {
+		for _, v := range inserted[key] {
+			return v
+		}
+		return -1
+	}
+	for key, expect := range map[string]float64{
+		"automation.job.failed":        1,
+		"automation.job.errors":        1,
+		"automation.job.publish_count": 3,
+		"automation.job.error_count":   1,
+	} {
+		if v := value(key); v != expect {
+			t.Errorf("Unexpected value for %s:\n got: %v\nwant: %v", key, v, expect)
+		}
+	}
+}
+
+func TestSelfMetricsPublishSignals(t *testing.T) {
+	var inserted views.DataFrame
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		switch req.Method {
+		case "admin.selectSignals":
+			return decodeResult(`{"meta":{"total":-1},"data":[
+				{"type":"signals","id":"s1","meta":{"attributesHash":"0000"},"attributes":{"name":"A"}},
+				{"type":"signals","id":"s2","meta":{"attributesHash":"0000"},"attributes":{"name":"B"}}
+			],"included":{}}`, result)
+		case "admin.publishSignals":
+			return decodeResult(`{"itemsBySignal":{}}`, result)
+		case "integration.insert":
+			inserted = req.Params.(map[string]any)["data"].(views.DataFrame)
+			return decodeResult(`{"signalsByInput":{}}`, result)
+		}
+		return fmt.Errorf("unexpected method %q", req.Method)
+	})
+	client := clarify.NewClient("integration", h)
+	cfg := automation.NewConfig(client).WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	metrics := automation.SelfMetrics{
+		Routine:     automation.PublishSignals{Integrations: []string{"a"}},
+		InputPrefix: "m.",
+	}
+	if err := metrics.Do(context.Background(), cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for key, expect := range map[string]float64{
+		"m.publish_count":     2,
+		"m.new_count":         2,
+		"m.republish_count":   0,
+		"m.skip_count":        0,
+		"m.error_count":       0,
+		"m.integration_count": 1,
+		"m.errors":            0,
+		"m.failed":            0,
+	} {
+		series, ok := inserted[key]
+		if !ok {
+			t.Errorf("Missing input %s", key)
+			continue
+		}
+		for _, v := range series {
+			if v != expect {
+				t.Errorf("Unexpected value for %s:\n got: %v\nwant: %v", key, v, expect)
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+// SetVisibility allows setting the visibility of published items in bulk, e.g.
+// to temporarily hide noisy items. Items that already have the desired
+// visibility are skipped. The routine is a specialization of UpdateItems, and
+// respects the DryRun and EarlyOut configurations.
+type SetVisibility struct {
+	// Integrations must list the IDs of the integrations that the matched
+	// items are published from.
+	Integrations []string
+
+	// ItemsFilter selects the items to update. If nil, all items are matched.
+	ItemsFilter fields.ResourceFilterType
+
+	// Visible is the desired item visibility.
+	Visible bool
+}
+
+var _ Routine = SetVisibility{}
+
+func (s SetVisibility) Do(ctx context.Context, cfg *Config) error {
+	return UpdateItems{
+		Integrations: s.Integrations,
+		ItemsFilter:  s.ItemsFilter,
+		Transforms: []func(item *views.ItemSave){
+			func(item *views.ItemSave) { item.Visible = s.Visible },
+		},
+	}.Do(ctx, cfg)
+}
@@ -0,0 +1,237 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+// UpdateItems allows applying transforms to already published items in bulk,
+// e.g. to clean up labels or annotations, or to change item visibility. Items
+// are updated by re-publishing the signals they were published from, and must
+// therefore originate from one of the listed integrations. The routine
+// respects the DryRun and EarlyOut configurations. In dry-run mode, the fields
+// that would change are logged for each item.
+//
+// Note that if items are also maintained by a PublishSignals routine, changes
+// done by UpdateItems are overwritten when the source signal changes. For
+// permanent changes, update the PublishSignals transforms as well.
+type UpdateItems struct {
+	// Integrations must list the IDs of the integrations that the matched
+	// items are published from. Items that are not published from any of the
+	// listed integrations are skipped.
+	Integrations []string
+
+	// ItemsFilter selects the items to update. If nil, all items are matched.
+	ItemsFilter fields.ResourceFilterType
+
+	// Transforms is a list of transforms to apply to the matched items. The
+	// transforms are applied in order. Items where the transforms result in no
+	// changes are skipped.
+	Transforms []func(item *views.ItemSave)
+}
+
+var _ Routine = UpdateItems{}
+
+func (u UpdateItems) Do(ctx context.Context, cfg *Config) error {
+	logger := cfg.Logger()
+	client := cfg.Client()
+	earlyOut := cfg.EarlyOut()
+	dryRun := cfg.DryRun()
+
+	var matchCount, updateCount, notFoundCount, errorCount int
+	defer func() {
+		cfg.AddCount("match_count", matchCount)
+		cfg.AddCount("update_count", updateCount)
+		cfg.AddCount("not_found_count", notFoundCount)
+		cfg.AddCount("error_count", errorCount)
+		logger.LogAttrs(ctx, slog.LevelInfo, "Update items completed",
+			slog.Int("match_count", matchCount),
+			slog.Int("update_count", updateCount),
+			slog.Int("not_found_count", notFoundCount),
+			slog.Int("error_count", errorCount),
+		)
+	}()
+
+	query := fields.Query().Sort("id").Limit(selectItemsPageSize)
+	if u.ItemsFilter != nil {
+		query = query.Where(u.ItemsFilter)
+	}
+
+	for {
+		if err := cfg.Checkpoint(ctx); err != nil {
+			return err
+		}
+		results, err := client.Clarify().SelectItems(query).Do(ctx)
+		if err != nil {
+			return fmt.Errorf("select items: %w", err)
+		}
+		matchCount += len(results.Data)
+
+		changed := u.changedItems(ctx, cfg, results.Data)
+		if !dryRun && len(changed) > 0 {
+			n, err := u.publish(ctx, cfg, changed)
+			updateCount += n
+			switch {
+			case err != nil && earlyOut:
+				return err
+			case err != nil:
+				logger.LogAttrs(ctx, slog.LevelError, "Update items failed", AttrError(err))
+				errorCount += len(changed) - n
+			default:
+				notFoundCount += len(changed) - n
+			}
+		} else {
+			updateCount += len(changed)
+		}
+
+		if len(results.Data) < query.GetLimit() {
+			return nil
+		}
+		query = query.NextPage()
+	}
+}
+
+// changedItems returns the transformed items from items that changed, keyed by
+// item ID.
+func (u UpdateItems) changedItems(ctx context.Context, cfg *Config, items []views.Item) map[string]views.ItemSave {
+	logger := cfg.Logger()
+	changed := make(map[string]views.ItemSave)
+	for _, item := range items {
+		before := views.SavedItem(item)
+		after := views.SavedItem(item)
+		for _, f := range u.Transforms {
+			f(&after)
+		}
+		diff := itemSaveDiff(before, after)
+		if len(diff) == 0 {
+			logger.LogAttrs(ctx, slog.LevelDebug, "Item is up-to-date", slog.String("item_id", item.ID))
+			continue
+		}
+		logger.LogAttrs(ctx, slog.LevelInfo, "Item changed",
+			slog.String("item_id", item.ID),
+			slog.Any("fields", diff),
+		)
+		logger.LogAttrs(ctx, slog.LevelDebug, "Item diff",
+			slog.String("item_id", item.ID),
+			slog.Any("before", before),
+			slog.Any("after", after),
+		)
+		changed[item.ID] = after
+	}
+	return changed
+}
+
+// publish re-publishes changed items by looking up the signals they were
+// published from. It returns the number of items that where updated.
+func (u UpdateItems) publish(ctx context.Context, cfg *Config, changed map[string]views.ItemSave) (int, error) {
+	client := cfg.Client()
+	ids := slices.Sorted(maps.Keys(changed))
+
+	var updateCount int
+	for _, integrationID := range u.Integrations {
+		if len(ids) == 0 {
+			break
+		}
+		if err := cfg.Checkpoint(ctx); err != nil {
+			return updateCount, err
+		}
+		query := fields.Query().
+			Where(fields.CompareField("item", fields.In(ids...))).
+			Limit(len(ids))
+		results, err := client.Admin().SelectSignals(integrationID, query).Do(ctx)
+		if err != nil {
+			return updateCount, fmt.Errorf("select signals: %w", err)
+		}
+
+		itemsBySignal := make(map[string]views.ItemSave, len(results.Data))
+		for _, signal := range results.Data {
+			itemID, ok := signal.Relationships.ItemID()
+			if !ok {
+				continue
+			}
+			if item, ok := changed[itemID]; ok {
+				itemsBySignal[signal.ID] = item
+			}
+		}
+		for _, batch := range batchItems(itemsBySignal, publishSignalsPageSize) {
+			if err := cfg.Checkpoint(ctx); err != nil {
+				return updateCount, err
+			}
+			if _, err := client.Admin().PublishSignals(integrationID, batch).Do(ctx); err != nil {
+				return updateCount, fmt.Errorf("publish signals: %w", err)
+			}
+			updateCount += len(batch)
+		}
+
+		// Remaining IDs must be found in the next integration.
+		for _, signal := range results.Data {
+			itemID, _ := signal.Relationships.ItemID()
+			ids = slices.DeleteFunc(ids, func(id string) bool { return id == itemID })
+		}
+	}
+	return updateCount, nil
+}
+
+// batchItems splits items into batches of at most size entries, ordered by key.
+func batchItems(items map[string]views.ItemSave, size int) []map[string]views.ItemSave {
+	var batches []map[string]views.ItemSave
+	keys := slices.Sorted(maps.Keys(items))
+	for len(keys) > 0 {
+		n := min(size, len(keys))
+		batch := make(map[string]views.ItemSave, n)
+		for _, k := range keys[:n] {
+			batch[k] = items[k]
+		}
+		batches = append(batches, batch)
+		keys = keys[n:]
+	}
+	return batches
+}
+
+// itemSaveDiff returns the sorted JSON names of fields that differ between a
+// and b.
+func itemSaveDiff(a, b views.ItemSave) []string {
+	ma, mb := jsonFields(a), jsonFields(b)
+	var diff []string
+	for k, va := range ma {
+		if !bytes.Equal(va, mb[k]) {
+			diff = append(diff, k)
+		}
+	}
+	for k := range mb {
+		if _, ok := ma[k]; !ok {
+			diff = append(diff, k)
+		}
+	}
+	slices.Sort(diff)
+	return diff
+}
+
+func jsonFields(v any) map[string]json.RawMessage {
+	var m map[string]json.RawMessage
+	b, _ := json.Marshal(v)
+	_ = json.Unmarshal(b, &m)
+	return m
+}
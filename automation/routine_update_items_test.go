@@ -0,0 +1,117 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestUpdateItems(t *testing.T) {
+	var published map[string]views.ItemSave
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		params := req.Params.(map[string]any)
+		switch req.Method {
+		case "clarify.selectItems":
+			return decodeResult(`{"meta":{"total":-1},"data":[
+				{"type":"items","id":"i1","attributes":{"name":"a","labels":{"site":["oslo"]}}},
+				{"type":"items","id":"i2","attributes":{"name":"b","labels":{"site":["bergen"]}}}
+			],"included":{}}`, result)
+		case "admin.selectSignals":
+			return decodeResult(`{"meta":{"total":-1},"data":[
+				{"type":"signals","id":"s1","relationships":{"item":{"data":{"type":"items","id":"i1"}}}}
+			],"included":{}}`, result)
+		case "admin.publishSignals":
+			published = params["itemsBySignal"].(map[string]views.ItemSave)
+			return decodeResult(`{"itemsBySignal":{}}`, result)
+		}
+		return fmt.Errorf("unexpected method %q", req.Method)
+	})
+
+	cfg := automation.NewConfig(clarify.NewClient("integration", h)).WithLogger(nil)
+	routine := automation.UpdateItems{
+		Integrations: []string{"integration"},
+		Transforms: []func(item *views.ItemSave){
+			func(item *views.ItemSave) {
+				if slices.Contains(item.Labels.Get("site"), "oslo") {
+					item.Labels.Set("site", []string{"Oslo"})
+				}
+			},
+		},
+	}
+
+	if err := routine.Do(context.Background(), cfg.WithDryRun(true)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if published != nil {
+		t.Fatalf("Expected no publish in dry-run, got: %v", published)
+	}
+
+	if err := routine.Do(context.Background(), cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if keys := slices.Sorted(maps.Keys(published)); !slices.Equal(keys, []string{"s1"}) {
+		t.Fatalf("Unexpected published signals:\n got: %v\nwant: [s1]", keys)
+	}
+	if site := published["s1"].Labels.Get("site"); !slices.Equal(site, []string{"Oslo"}) {
+		t.Errorf("Unexpected site label:\n got: %v\nwant: [Oslo]", site)
+	}
+	if name := published["s1"].Name; name != "a" {
+		t.Errorf("Unexpected name:\n got: %q\nwant: %q", name, "a")
+	}
+}
+
+func TestSetVisibility(t *testing.T) {
+	var published map[string]views.ItemSave
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		params := req.Params.(map[string]any)
+		switch req.Method {
+		case "clarify.selectItems":
+			return decodeResult(`{"meta":{"total":-1},"data":[
+				{"type":"items","id":"i1","attributes":{"name":"a","visible":true}},
+				{"type":"items","id":"i2","attributes":{"name":"b","visible":false}}
+			],"included":{}}`, result)
+		case "admin.selectSignals":
+			return decodeResult(`{"meta":{"total":-1},"data":[
+				{"type":"signals","id":"s1","relationships":{"item":{"data":{"type":"items","id":"i1"}}}}
+			],"included":{}}`, result)
+		case "admin.publishSignals":
+			published = params["itemsBySignal"].(map[string]views.ItemSave)
+			return decodeResult(`{"itemsBySignal":{}}`, result)
+		}
+		return fmt.Errorf("unexpected method %q", req.Method)
+	})
+
+	cfg := automation.NewConfig(clarify.NewClient("integration", h)).WithLogger(nil)
+	routine := automation.SetVisibility{
+		Integrations: []string{"integration"},
+		Visible:      false,
+	}
+	if err := routine.Do(context.Background(), cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if item, ok := published["s1"]; !ok || item.Visible || len(published) != 1 {
+		t.Errorf("Unexpected published items:\n got: %v\nwant: map[s1:{visible: false}]", published)
+	}
+}
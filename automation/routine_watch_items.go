@@ -0,0 +1,334 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+// Item change types used in ItemChange.
+const (
+	ItemChangeCreated = "created"
+	ItemChangeUpdated = "updated"
+	ItemChangeDeleted = "deleted"
+)
+
+// DefaultWatchItemFields lists the item fields watched by WatchItems when no
+// fields are specified.
+var DefaultWatchItemFields = []string{"name", "labels", "enumValues"}
+
+// FieldChange describe the JSON encoded value of a field before and after a
+// change. Before is null for created items, and After is null for deleted
+// items.
+type FieldChange struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// ItemChange describe a change to the watched fields of a single item.
+type ItemChange struct {
+	ItemID string                 `json:"itemId"`
+	Type   string                 `json:"type"`
+	Fields map[string]FieldChange `json:"fields"`
+}
+
+// ItemChangeNotification is the payload passed to an ItemChangeNotifier.
+type ItemChangeNotification struct {
+	AppName string       `json:"app,omitempty"`
+	Routine string       `json:"routine"`
+	Time    time.Time    `json:"time"`
+	Changes []ItemChange `json:"changes"`
+}
+
+// ItemChangeNotifier describe the interface for sending item change
+// notifications, e.g. to a webhook or a queue.
+type ItemChangeNotifier interface {
+	NotifyItemChanges(ctx context.Context, n ItemChangeNotification) error
+}
+
+// The ItemChangeNotifierFunc type is an adapter to allow the use of ordinary
+// functions as item change notifiers.
+type ItemChangeNotifierFunc func(context.Context, ItemChangeNotification) error
+
+func (f ItemChangeNotifierFunc) NotifyItemChanges(ctx context.Context, n ItemChangeNotification) error {
+	return f(ctx, n)
+}
+
+var _ ItemChangeNotifier = WebhookNotifier{}
+
+// WebhookNotifier is an ItemChangeNotifier that sends notifications as JSON in
+// a HTTP POST request to a webhook URL. Responses with a status code outside of
+// the 2xx range are reported as errors.
+type WebhookNotifier struct {
+	// URL is the webhook URL.
+	URL string
+
+	// Header contain additional HTTP headers to send, e.g. for
+	// authentication.
+	Header http.Header
+
+	// Client is the HTTP client to use. If nil, a client with a 30 second
+	// timeout is used.
+	Client *http.Client
+}
+
+func (wh WebhookNotifier) NotifyItemChanges(ctx context.Context, n ItemChangeNotification) error {
+	b, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.URL, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	for k, v := range wh.Header {
+		req.Header[k] = slices.Clone(v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := wh.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// WatchItems detects changes to item meta-data between runs, and sends
+// notifications with the difference to a notifier. This allows keeping
+// external catalogs, such as a CMDB, in sync with Clarify. Run the routine
+// repeatedly, e.g. via the automationcli -interval flag.
+//
+// The watched fields of all matched items are recorded in the configured state
+// store. On the first run, the state is recorded without sending any
+// notifications. Items that are no longer matched are reported as deleted. The
+// state is only updated when all notifications are sent successfully, so that
+// failed notifications are retried on the next run. The routine respects the
+// DryRun configuration, in which case changes are logged only.
+type WatchItems struct {
+	// ItemsFilter selects the items to watch. If nil, all items are matched.
+	ItemsFilter fields.ResourceFilterType
+
+	// Fields lists the JSON names of the item save view fields to watch, such
+	// as "name", "labels" or "annotations". If empty, DefaultWatchItemFields
+	// is used.
+	Fields []string
+
+	// Notifier receives the detected changes.
+	Notifier ItemChangeNotifier
+
+	// BatchSize sets the maximum number of changes per notification. If zero,
+	// a default of 100 is used.
+	BatchSize int
+
+	// StateKey sets the key used to record item state in the state store. The
+	// default is "watch-items/" followed by the routine path.
+	StateKey string
+}
+
+const defaultWatchItemsBatchSize = 100
+
+var _ Routine = WatchItems{}
+
+func (w WatchItems) Do(ctx context.Context, cfg *Config) error {
+	logger := cfg.Logger()
+	state := cfg.StateStore()
+	if w.Notifier == nil {
+		return fmt.Errorf("%w: WatchItems: missing notifier", ErrBadConfig)
+	}
+
+	stateKey := w.StateKey
+	if stateKey == "" {
+		stateKey = "watch-items/" + cfg.RoutinePath()
+	}
+	watchFields := w.Fields
+	if len(watchFields) == 0 {
+		watchFields = DefaultWatchItemFields
+	}
+	batchSize := w.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultWatchItemsBatchSize
+	}
+
+	var previous map[string]map[string]json.RawMessage
+	switch v, found, err := state.Load(ctx, stateKey); {
+	case err != nil:
+		return fmt.Errorf("load state: %w", err)
+	case found:
+		if err := json.Unmarshal([]byte(v), &previous); err != nil {
+			return fmt.Errorf("load state: %w", err)
+		}
+	}
+
+	current, err := w.selectItems(ctx, cfg, watchFields)
+	if err != nil {
+		return err
+	}
+
+	var changes []ItemChange
+	if previous != nil {
+		changes = itemChanges(previous, current)
+	}
+	for _, c := range changes {
+		logger.LogAttrs(ctx, slog.LevelInfo, "Item changed",
+			slog.String("item_id", c.ItemID),
+			slog.String("type", c.Type),
+			slog.Any("fields", slices.Sorted(maps.Keys(c.Fields))),
+		)
+	}
+	if cfg.DryRun() {
+		return nil
+	}
+
+	for batch := range slices.Chunk(changes, batchSize) {
+		if err := cfg.Checkpoint(ctx); err != nil {
+			return err
+		}
+		n := ItemChangeNotification{
+			AppName: cfg.AppName(),
+			Routine: cfg.RoutinePath(),
+			Time:    cfg.Now(),
+			Changes: batch,
+		}
+		if err := w.Notifier.NotifyItemChanges(ctx, n); err != nil {
+			return fmt.Errorf("notify: %w", err)
+		}
+	}
+
+	b, err := json.Marshal(current)
+	if err != nil {
+		return fmt.Errorf("store state: %w", err)
+	}
+	if err := state.Store(ctx, stateKey, string(b)); err != nil {
+		return fmt.Errorf("store state: %w", err)
+	}
+	cfg.AddCount("match_count", len(current))
+	cfg.AddCount("change_count", len(changes))
+	logger.LogAttrs(ctx, slog.LevelInfo, "Watch items completed",
+		slog.Int("match_count", len(current)),
+		slog.Int("change_count", len(changes)),
+	)
+	return nil
+}
+
+// selectItems returns the JSON encoded value of watchFields for all matched
+// items, keyed by item ID.
+func (w WatchItems) selectItems(ctx context.Context, cfg *Config, watchFields []string) (map[string]map[string]json.RawMessage, error) {
+	client := cfg.Client()
+	query := fields.Query().Sort("id").Limit(selectItemsPageSize)
+	if w.ItemsFilter != nil {
+		query = query.Where(w.ItemsFilter)
+	}
+
+	items := make(map[string]map[string]json.RawMessage)
+	for {
+		if err := cfg.Checkpoint(ctx); err != nil {
+			return nil, err
+		}
+		results, err := client.Clarify().SelectItems(query).Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("select items: %w", err)
+		}
+		for _, item := range results.Data {
+			all := jsonFields(views.SavedItem(item))
+			watched := make(map[string]json.RawMessage, len(watchFields))
+			for _, name := range watchFields {
+				if v, ok := all[name]; ok {
+					watched[name] = v
+				}
+			}
+			items[item.ID] = watched
+		}
+		if len(results.Data) < query.GetLimit() {
+			return items, nil
+		}
+		query = query.NextPage()
+	}
+}
+
+// itemChanges returns the changes between previous and current, ordered by
+// item ID.
+func itemChanges(previous, current map[string]map[string]json.RawMessage) []ItemChange {
+	ids := slices.Collect(maps.Keys(current))
+	for id := range previous {
+		if _, ok := current[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+
+	var changes []ItemChange
+	for _, id := range ids {
+		before, existed := previous[id]
+		after, exists := current[id]
+		c := ItemChange{ItemID: id, Fields: make(map[string]FieldChange)}
+		switch {
+		case !existed:
+			c.Type = ItemChangeCreated
+		case !exists:
+			c.Type = ItemChangeDeleted
+		default:
+			c.Type = ItemChangeUpdated
+		}
+		for _, name := range slices.Sorted(maps.Keys(mergeKeys(before, after))) {
+			if bytes.Equal(before[name], after[name]) {
+				continue
+			}
+			c.Fields[name] = FieldChange{Before: rawOrNull(before[name]), After: rawOrNull(after[name])}
+		}
+		if len(c.Fields) > 0 || c.Type != ItemChangeUpdated {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+func mergeKeys(a, b map[string]json.RawMessage) map[string]struct{} {
+	keys := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+	return keys
+}
+
+func rawOrNull(v json.RawMessage) json.RawMessage {
+	if v == nil {
+		return json.RawMessage("null")
+	}
+	return v
+}
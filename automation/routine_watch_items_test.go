@@ -0,0 +1,121 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+)
+
+func TestWatchItems(t *testing.T) {
+	var items string
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		if req.Method != "clarify.selectItems" {
+			return fmt.Errorf("unexpected method %q", req.Method)
+		}
+		return decodeResult(`{"meta":{"total":-1},"data":[`+items+`],"included":{}}`, result)
+	})
+
+	var notifications []automation.ItemChangeNotification
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		var n automation.ItemChangeNotification
+		if err := json.Unmarshal(b, &n); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		notifications = append(notifications, n)
+	}))
+	defer srv.Close()
+
+	cfg := automation.NewConfig(clarify.NewClient("integration", h)).WithLogger(nil)
+	routine := automation.WatchItems{
+		Notifier: automation.WebhookNotifier{
+			URL:    srv.URL,
+			Header: http.Header{"Authorization": {"Bearer token"}},
+		},
+	}
+	run := func(data string) {
+		t.Helper()
+		items = data
+		if err := routine.Do(context.Background(), cfg); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	run(`{"type":"items","id":"i1","attributes":{"name":"a","labels":{"site":["oslo"]}}},
+		{"type":"items","id":"i2","attributes":{"name":"b"}}`)
+	if len(notifications) != 0 {
+		t.Fatalf("Expected no notifications on first run, got: %v", notifications)
+	}
+
+	run(`{"type":"items","id":"i1","attributes":{"name":"a","labels":{"site":["bergen"]},"description":"new"}},
+		{"type":"items","id":"i3","attributes":{"name":"c"}}`)
+	if len(notifications) != 1 {
+		t.Fatalf("Unexpected number of notifications:\n got: %d\nwant: 1", len(notifications))
+	}
+	changes := notifications[0].Changes
+	if len(changes) != 3 {
+		t.Fatalf("Unexpected changes:\n got: %+v\nwant: 3 changes", changes)
+	}
+	expectTypes := map[string]string{
+		"i1": automation.ItemChangeUpdated,
+		"i2": automation.ItemChangeDeleted,
+		"i3": automation.ItemChangeCreated,
+	}
+	for _, c := range changes {
+		if c.Type != expectTypes[c.ItemID] {
+			t.Errorf("Unexpected change type for %s:\n got: %s\nwant: %s", c.ItemID, c.Type, expectTypes[c.ItemID])
+		}
+	}
+	if fc, ok := changes[0].Fields["labels"]; !ok || string(fc.Before) != `{"site":["oslo"]}` || string(fc.After) != `{"site":["bergen"]}` {
+		t.Errorf("Unexpected labels change for i1: %+v", changes[0].Fields)
+	}
+	if len(changes[0].Fields) != 1 {
+		t.Errorf("Unexpected changed fields for i1:\n got: %v\nwant: [labels]", changes[0].Fields)
+	}
+
+	// No changes; no notification.
+	run(`{"type":"items","id":"i1","attributes":{"name":"a","labels":{"site":["bergen"]}}},
+		{"type":"items","id":"i3","attributes":{"name":"c"}}`)
+	if len(notifications) != 1 {
+		t.Errorf("Unexpected number of notifications:\n got: %d\nwant: 1", len(notifications))
+	}
+
+	// Failed notifications are retried on the next run.
+	routine.Notifier = automation.WebhookNotifier{URL: srv.URL}
+	items = `{"type":"items","id":"i3","attributes":{"name":"d"}}`
+	if err := routine.Do(context.Background(), cfg); err == nil {
+		t.Fatalf("Expected an error for unauthorized webhook")
+	}
+	routine.Notifier = automation.WebhookNotifier{URL: srv.URL, Header: http.Header{"Authorization": {"Bearer token"}}}
+	run(items)
+	if len(notifications) != 2 || len(notifications[1].Changes) != 2 {
+		t.Errorf("Unexpected notifications after retry: %+v", notifications)
+	}
+}
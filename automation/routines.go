@@ -1,4 +1,4 @@
-// Copyright 2023-2024 Searis AS
+// Copyright 2023-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -20,8 +20,12 @@ import (
 	"io"
 	"log/slog"
 	"maps"
+	"math/rand/v2"
 	"slices"
 	"strings"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
 )
 
 // Routines describe a set of named (sub-)routines. Routines can be nested by
@@ -143,9 +147,28 @@ LOOKUP:
 	return filtered
 }
 
+// Configuration value keys used by Routines.Do to spread the start of member
+// routines over time. See Config.Value for lookup rules.
+const (
+	// StaggerValueKey sets a duration to wait before starting each member
+	// routine, except the first one.
+	StaggerValueKey = "routines:stagger"
+
+	// JitterValueKey sets a maximum random duration to add to each stagger
+	// wait.
+	JitterValueKey = "routines:jitter"
+)
+
 // Do runs the member routines in an alphanumerical order and assigns correct
 // sub-routine names. If cfg.EarlyOut() returns true, return at the first error.
-// Otherwise log the error and continue.
+// Otherwise log the error and continue. No further routines are started once
+// cfg.Checkpoint returns an error.
+//
+// To avoid many routines hammering the API at once, set the StaggerValueKey
+// and JitterValueKey configuration values. Before starting each member routine
+// except the first, Do then waits the stagger duration plus a random duration
+// in the range [0,jitter). Values are looked up per member routine, which
+// allows different settings per sub-tree. The wait uses cfg.After.
 func (routines Routines) Do(ctx context.Context, cfg *Config) error {
 	earlyOut := cfg.EarlyOut()
 
@@ -156,7 +179,10 @@ func (routines Routines) Do(ctx context.Context, cfg *Config) error {
 	slices.Sort(keys)
 
 	var errCnt int
-	for _, k := range keys {
+	for i, k := range keys {
+		if err := cfg.Checkpoint(ctx); err != nil {
+			return err
+		}
 		r := routines[k]
 		cfg := cfg.WithSubRoutineName(k)
 		logger := cfg.Logger()
@@ -164,8 +190,13 @@ func (routines Routines) Do(ctx context.Context, cfg *Config) error {
 			cfg.Logger().LogAttrs(ctx, slog.LevelWarn, "Routine is nil")
 			continue
 		}
+		if i > 0 {
+			if err := stagger(ctx, cfg); err != nil {
+				return err
+			}
+		}
 		logger.LogAttrs(ctx, slog.LevelDebug, "Routine started")
-		if err := r.Do(ctx, cfg); err != nil {
+		if err := doRecorded(ctx, cfg, r); err != nil {
 			if earlyOut {
 				return fmt.Errorf("%s: %w", k, err)
 			}
@@ -179,3 +210,67 @@ func (routines Routines) Do(ctx context.Context, cfg *Config) error {
 
 	return nil
 }
+
+// stagger waits according to the StaggerValueKey and JitterValueKey values
+// for cfg. An error is returned if cfg.Checkpoint fails during the wait.
+func stagger(ctx context.Context, cfg *Config) error {
+	logger := cfg.Logger()
+	wait, err := ValueFromConfig(cfg, StaggerValueKey, time.Duration(0))
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelWarn, "Ignoring stagger", AttrError(err))
+	}
+	jitter, err := ValueFromConfig(cfg, JitterValueKey, time.Duration(0))
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelWarn, "Ignoring jitter", AttrError(err))
+	}
+	if jitter > 0 {
+		wait += rand.N(jitter)
+	}
+	if wait <= 0 {
+		return nil
+	}
+
+	logger.LogAttrs(ctx, slog.LevelDebug, "Staggering routine start", slog.Duration("wait", wait))
+	var stop <-chan struct{}
+	if cfg.stop != nil {
+		stop = cfg.stop.Done()
+	}
+	select {
+	case <-ctx.Done():
+	case <-stop:
+	case <-cfg.After(wait):
+	}
+	return cfg.Checkpoint(ctx)
+}
+
+// doRecorded runs r, and records the run using the configured run recorder
+// unless r is a Routines instance. Failures to record the run are logged, but
+// does not cause the routine to fail.
+func doRecorded(ctx context.Context, cfg *Config, r Routine) error {
+	recorder := cfg.RunRecorder()
+	if _, ok := r.(Routines); ok || recorder == nil {
+		return r.Do(ctx, cfg)
+	}
+
+	start := cfg.Now()
+	err := r.Do(ctx, cfg)
+	end := cfg.Now()
+
+	record := RunRecord{
+		AppName:  cfg.AppName(),
+		Routine:  cfg.RoutinePath(),
+		Start:    start,
+		End:      end,
+		Duration: fields.AsFixedDuration(end.Sub(start)),
+		Status:   RunStatusSucceeded,
+		DryRun:   cfg.DryRun(),
+	}
+	if err != nil {
+		record.Status = RunStatusFailed
+		record.Error = err.Error()
+	}
+	if recErr := recorder.RecordRun(ctx, record); recErr != nil {
+		cfg.Logger().LogAttrs(ctx, slog.LevelWarn, "Failed to record run", AttrError(recErr))
+	}
+	return err
+}
@@ -1,4 +1,4 @@
-// Copyright 2023 Searis AS
+// Copyright 2023-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -17,12 +17,21 @@ package automation_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/clarify/clarify-go"
 	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
 )
 
 func TestRoutinesSubRoutines(t *testing.T) {
@@ -68,8 +77,10 @@ func TestRoutinesSubRoutines(t *testing.T) {
 			}))
 
 			ctx := context.Background()
+			// The default app name depends on the build, so it's cleared.
 			cfg := automation.
 				NewConfig(nil).
+				WithAppName("").
 				WithLogger(logger)
 
 			routines := all.SubRoutines(tc.patterns...)
@@ -169,3 +180,147 @@ func diffLines(expect, result []string) string {
 	}
 	return buf.String()
 }
+
+func TestRoutinesStopContext(t *testing.T) {
+	var called bool
+	routines := automation.Routines{
+		"routine": automation.RoutineFunc(func(ctx context.Context, cfg *automation.Config) error {
+			called = true
+			return nil
+		}),
+	}
+
+	stop, cancel := context.WithCancel(context.Background())
+	cancel()
+	cfg := automation.NewConfig(nil).WithLogger(nil).WithStopContext(stop)
+	if err := routines.Do(context.Background(), cfg); !errors.Is(err, context.Canceled) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, context.Canceled)
+	}
+	if called {
+		t.Errorf("Expected routine not to be called after stop")
+	}
+}
+
+func TestRoutinesStagger(t *testing.T) {
+	var runs []string
+	var waits []time.Duration
+	record := automation.RoutineFunc(func(ctx context.Context, cfg *automation.Config) error {
+		runs = append(runs, cfg.RoutinePath())
+		return nil
+	})
+	routines := automation.Routines{
+		"a": record,
+		"b": record,
+		"c": automation.Routines{"d": record, "e": record},
+	}
+	after := func(d time.Duration) <-chan time.Time {
+		waits = append(waits, d)
+		c := make(chan time.Time, 1)
+		c <- time.Time{}
+		return c
+	}
+
+	cfg := automation.NewConfig(nil).WithLogger(nil).WithTimer(after).WithValues(map[string]any{
+		automation.StaggerValueKey:          "20ms",
+		"c/e." + automation.StaggerValueKey: time.Duration(0),
+	})
+	if err := routines.Do(context.Background(), cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expect := []string{"a", "b", "c/d", "c/e"}; !slices.Equal(runs, expect) {
+		t.Errorf("Unexpected runs:\n got: %v\nwant: %v", runs, expect)
+	}
+	// Waits before b and c; c/d is the first member of c, and c/e has no
+	// stagger.
+	if expect := []time.Duration{20 * time.Millisecond, 20 * time.Millisecond}; !slices.Equal(waits, expect) {
+		t.Errorf("Unexpected waits:\n got: %v\nwant: %v", waits, expect)
+	}
+
+	// Stopping during a wait aborts the remaining routines.
+	stop, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg = cfg.WithStopContext(stop).WithTimer(func(d time.Duration) <-chan time.Time {
+		cancel()
+		return nil
+	})
+	runs = nil
+	if err := routines.Do(context.Background(), cfg); !errors.Is(err, context.Canceled) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, context.Canceled)
+	}
+	if expect := []string{"a"}; !slices.Equal(runs, expect) {
+		t.Errorf("Unexpected runs:\n got: %v\nwant: %v", runs, expect)
+	}
+}
+
+func TestRoutinesRunRecorder(t *testing.T) {
+	routines := automation.Routines{
+		"a": automation.Routines{
+			"ok": automation.RoutineFunc(func(ctx context.Context, cfg *automation.Config) error {
+				return nil
+			}),
+		},
+		"b": automation.RoutineFunc(func(ctx context.Context, cfg *automation.Config) error {
+			return errors.New("boom")
+		}),
+	}
+
+	name := filepath.Join(t.TempDir(), "runs.jsonl")
+	cfg := automation.NewConfig(nil).
+		WithAppName("").
+		WithLogger(nil).
+		WithRunRecorder(automation.NewFileRunRecorder(name))
+	_ = routines.Do(context.Background(), cfg)
+
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var result []string
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		var r automation.RunRecord
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		result = append(result, r.Routine+" "+r.Status+" "+r.Error)
+	}
+	expect := []string{
+		"a/ok succeeded ",
+		"b failed boom",
+	}
+	if diff := diffLines(expect, result); diff != "" {
+		t.Errorf("Unexpected run records (-want +got):\n%s", diff)
+	}
+}
+
+func TestConfigClock(t *testing.T) {
+	clientTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfgTime := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		return decodeResult(`{"meta":{"total":-1},"data":[],"included":{}}`, result)
+	})
+	client := clarify.NewClient("integration", h, clarify.WithClock(func() time.Time {
+		return clientTime
+	}))
+
+	cfg := automation.NewConfig(client)
+	if now := cfg.Now(); !now.Equal(clientTime) {
+		t.Errorf("Unexpected client time:\n got: %v\nwant: %v", now, clientTime)
+	}
+	cfg = cfg.WithClock(func() time.Time { return cfgTime })
+	if now := cfg.Now(); !now.Equal(cfgTime) {
+		t.Errorf("Unexpected config time:\n got: %v\nwant: %v", now, cfgTime)
+	}
+
+	var gte time.Time
+	routine := automation.ReportDataQuality{
+		TimeRange: func(now time.Time) (time.Time, time.Time) {
+			gte = now
+			return now, now
+		},
+		ExpectedInterval: time.Hour,
+	}
+	_ = routine.Do(context.Background(), cfg.WithLogger(nil).WithDryRun(true))
+	if !gte.Equal(cfgTime) {
+		t.Errorf("Unexpected routine time:\n got: %v\nwant: %v", gte, cfgTime)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+)
+
+// Run statuses used in RunRecord.
+const (
+	RunStatusSucceeded = "succeeded"
+	RunStatusFailed    = "failed"
+)
+
+// RunRecord describe a single completed routine run.
+type RunRecord struct {
+	AppName  string               `json:"app,omitempty"`
+	Routine  string               `json:"routine"`
+	Start    time.Time            `json:"start"`
+	End      time.Time            `json:"end"`
+	Duration fields.FixedDuration `json:"duration"`
+	Status   string               `json:"status"`
+	Error    string               `json:"error,omitempty"`
+	DryRun   bool                 `json:"dryRun,omitempty"`
+}
+
+// RunRecorder describe the interface for recording routine runs for auditing
+// purposes. The recorder is invoked by Routines.Do once for each member
+// routine that is not itself a Routines instance.
+//
+// Implementations must be safe for concurrent use.
+type RunRecorder interface {
+	RecordRun(ctx context.Context, r RunRecord) error
+}
+
+var _ RunRecorder = (*FileRunRecorder)(nil)
+
+// FileRunRecorder is a RunRecorder that appends records to a file in the JSON
+// Lines format; one JSON object per line.
+type FileRunRecorder struct {
+	lock sync.Mutex
+	name string
+}
+
+// NewFileRunRecorder returns a run recorder that appends records to the named
+// file. The file is created on the first call to RecordRun.
+func NewFileRunRecorder(name string) *FileRunRecorder {
+	return &FileRunRecorder{name: name}
+}
+
+func (rec *FileRunRecorder) RecordRun(_ context.Context, r RunRecord) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	rec.lock.Lock()
+	defer rec.lock.Unlock()
+
+	f, err := os.OpenFile(rec.name, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// StateStore describe the interface for persisting routine state between runs.
+// Keys should be prefixed by the routine path to avoid collisions.
+//
+// Implementations must be safe for concurrent use.
+type StateStore interface {
+	// Load returns the value stored for key. If no value is stored, found is
+	// false.
+	Load(ctx context.Context, key string) (value string, found bool, err error)
+
+	// Store stores value for key, replacing any existing value.
+	Store(ctx context.Context, key, value string) error
+}
+
+var (
+	_ StateStore = (*MemoryStateStore)(nil)
+	_ StateStore = (*FileStateStore)(nil)
+)
+
+// MemoryStateStore is a StateStore that holds state in memory. State is lost
+// when the process exits.
+type MemoryStateStore struct {
+	lock   sync.Mutex
+	values map[string]string
+}
+
+// NewMemoryStateStore returns a new empty in-memory state store.
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{values: make(map[string]string)}
+}
+
+func (s *MemoryStateStore) Load(_ context.Context, key string) (string, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	v, ok := s.values[key]
+	return v, ok, nil
+}
+
+func (s *MemoryStateStore) Store(_ context.Context, key, value string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.values == nil {
+		s.values = make(map[string]string)
+	}
+	s.values[key] = value
+	return nil
+}
+
+// FileStateStore is a StateStore that persists state as a JSON object in a
+// single file. The file is read on each Load, and rewritten on each Store.
+type FileStateStore struct {
+	lock sync.Mutex
+	name string
+}
+
+// NewFileStateStore returns a state store that persist state to the named
+// file. The file is created on the first call to Store.
+func NewFileStateStore(name string) *FileStateStore {
+	return &FileStateStore{name: name}
+}
+
+func (s *FileStateStore) Load(_ context.Context, key string) (string, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	m, err := s.read()
+	if err != nil {
+		return "", false, err
+	}
+	v, ok := m[key]
+	return v, ok, nil
+}
+
+func (s *FileStateStore) Store(_ context.Context, key, value string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	m, err := s.read()
+	if err != nil {
+		return err
+	}
+	m[key] = value
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first to avoid corrupting state on failure.
+	tmp := s.name + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Clean(s.name))
+}
+
+func (s *FileStateStore) read() (map[string]string, error) {
+	m := make(map[string]string)
+	b, err := os.ReadFile(s.name)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return m, nil
+	case err != nil:
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/template"
+
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// NameTemplateData describe the data passed to templates executed by
+// NameTemplate.
+type NameTemplateData struct {
+	// Signal holds the source signal.
+	Signal NameTemplateSignal
+
+	// Item holds the item attributes after previous transforms are applied.
+	Item views.ItemSaveAttributes
+}
+
+// NameTemplateSignal exposes the source signal attributes, such as .Name and
+// .Labels, together with the signal ID and annotations.
+type NameTemplateSignal struct {
+	ID string
+	views.SignalAttributes
+	Annotations fields.Annotations
+}
+
+// nameTemplateFuncs holds the functions available to name templates. String
+// functions accept label values as well as strings; multiple label values are
+// joined by ", ".
+var nameTemplateFuncs = template.FuncMap{
+	"title": func(v any) string {
+		return cases.Title(language.Und, cases.NoLower).String(templateString(v))
+	},
+	"upper": func(v any) string { return strings.ToUpper(templateString(v)) },
+	"lower": func(v any) string { return strings.ToLower(templateString(v)) },
+	"trim":  func(v any) string { return strings.TrimSpace(templateString(v)) },
+	"first": func(v []string) string {
+		if len(v) == 0 {
+			return ""
+		}
+		return v[0]
+	},
+	"join": func(sep string, v []string) string { return strings.Join(v, sep) },
+	"default": func(def string, v any) string {
+		if s := templateString(v); s != "" {
+			return s
+		}
+		return def
+	},
+}
+
+func templateString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// NameTemplate returns a signal transform that sets the item name from a
+// text/template, executed with NameTemplateData. Example:
+//
+//	{{ .Signal.Labels.location | title }} – {{ .Signal.Name }}
+//
+// In addition to the built-in template functions, the template can use title,
+// upper, lower, trim, first, join and default. Leading and trailing white-space
+// is removed from the result. If the template fails to execute, or renders an
+// empty string, the item name is left unchanged. Execution errors are logged
+// to the slog package default logger, as transforms have no error return.
+//
+// NameTemplate panics if text is not a valid template.
+func NameTemplate(text string) func(signal views.Signal, item *views.ItemSave) {
+	tmpl := template.Must(template.New("name").Funcs(nameTemplateFuncs).Parse(text))
+	return func(signal views.Signal, item *views.ItemSave) {
+		data := NameTemplateData{
+			Signal: NameTemplateSignal{
+				ID:               signal.ID,
+				SignalAttributes: signal.Attributes,
+				Annotations:      signal.Meta.Annotations,
+			},
+			Item: item.ItemSaveAttributes,
+		}
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, data); err != nil {
+			slog.Default().LogAttrs(context.Background(), slog.LevelWarn, "Name template failed",
+				slog.String("signal_id", signal.ID),
+				automation.AttrError(err),
+			)
+			return
+		}
+		if name := strings.TrimSpace(sb.String()); name != "" {
+			item.Name = name
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/clarify/clarify-go/automation/transform"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestNameTemplate(t *testing.T) {
+	var signal views.Signal
+	signal.ID = "s1"
+	signal.Attributes.Name = "Temperature"
+	signal.Attributes.Labels = fields.Labels{"location": {"north pier"}}
+
+	test := func(text, expect string) func(t *testing.T) {
+		return func(t *testing.T) {
+			item := views.PublishedItem(signal)
+			transform.NameTemplate(text)(signal, &item)
+			if item.Name != expect {
+				t.Errorf("Unexpected name:\n got: %q\nwant: %q", item.Name, expect)
+			}
+		}
+	}
+	t.Run("Labels", test(`{{ .Signal.Labels.location | title }} – {{ .Signal.Name }}`, "North Pier – Temperature"))
+	t.Run("MissingLabel", test(`{{ .Signal.Labels.area | default "Unknown" }} {{ .Signal.ID }}`, "Unknown s1"))
+	t.Run("Item", test(`{{ .Item.Name | upper }}`, "TEMPERATURE"))
+	t.Run("Empty", test(`{{ .Signal.Labels.area | first }}`, "Temperature"))
+}
+
+func TestNameTemplateError(t *testing.T) {
+	var buf bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	var signal views.Signal
+	signal.ID = "s1"
+	signal.Attributes.Name = "Temperature"
+	item := views.PublishedItem(signal)
+	transform.NameTemplate(`{{ .Signal.Missing }}`)(signal, &item)
+	if item.Name != "Temperature" {
+		t.Errorf("Unexpected name:\n got: %q\nwant: %q", item.Name, "Temperature")
+	}
+	if s := buf.String(); !strings.Contains(s, "Name template failed") || !strings.Contains(s, "signal_id=s1") {
+		t.Errorf("Unexpected log output: %s", s)
+	}
+}
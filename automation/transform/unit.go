@@ -0,0 +1,157 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transform provides reusable transforms for items published by the
+// automation.PublishSignals routine.
+//
+// Note that published items always present the data from the signal they are
+// published from. Transforms can therefore change how data is described, but
+// not the data itself. For this reason, the ConvertUnit transform records a
+// preferred unit conversion, but leaves the engineering unit of the item
+// unchanged, so that it keeps describing the data it presents. To present data
+// in the preferred unit, use one of the following:
+//
+//   - Scale the data before it's inserted, e.g. by applying
+//     UnitConversion.DataSeries to the series of an ingest pipeline, and set
+//     the engineering unit of the signal to match.
+//   - Scale the data when it's read, by using UnitConversion.Formula in a
+//     calculation for evaluate requests.
+package transform
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/views"
+)
+
+// UnitConversion describe a linear conversion between two engineering units.
+type UnitConversion struct {
+	From, To string
+	Scale    float64
+	Offset   float64
+}
+
+type unitBase struct {
+	dimension     string
+	scale, offset float64
+}
+
+// units list known engineering units with the linear conversion to the base
+// unit of their dimension.
+var units = map[string]unitBase{
+	"degC": {"temperature", 1, 0},
+	"degF": {"temperature", 5.0 / 9, -32 * 5.0 / 9},
+	"K":    {"temperature", 1, -273.15},
+
+	"Pa":  {"pressure", 1, 0},
+	"kPa": {"pressure", 1e3, 0},
+	"bar": {"pressure", 1e5, 0},
+	"psi": {"pressure", 6894.757293168361, 0},
+
+	"m":  {"length", 1, 0},
+	"mm": {"length", 1e-3, 0},
+	"km": {"length", 1e3, 0},
+	"ft": {"length", 0.3048, 0},
+	"in": {"length", 0.0254, 0},
+
+	"W":  {"power", 1, 0},
+	"kW": {"power", 1e3, 0},
+	"MW": {"power", 1e6, 0},
+	"hp": {"power", 745.6998715822702, 0},
+
+	"Wh":  {"energy", 1, 0},
+	"kWh": {"energy", 1e3, 0},
+	"MWh": {"energy", 1e6, 0},
+	"J":   {"energy", 1 / 3600.0, 0},
+}
+
+// LookupUnitConversion returns the conversion from one known engineering unit
+// to another. False is returned if either unit is unknown, or if the units
+// describe different dimensions.
+func LookupUnitConversion(from, to string) (UnitConversion, bool) {
+	f, ok1 := units[from]
+	t, ok2 := units[to]
+	if !ok1 || !ok2 || f.dimension != t.dimension {
+		return UnitConversion{}, false
+	}
+	// Convert via the base unit: base = v*f.scale + f.offset, and
+	// v' = (base - t.offset) / t.scale.
+	return UnitConversion{
+		From:   from,
+		To:     to,
+		Scale:  f.scale / t.scale,
+		Offset: (f.offset - t.offset) / t.scale,
+	}, true
+}
+
+// Convert returns v converted from c.From to c.To.
+func (c UnitConversion) Convert(v float64) float64 {
+	return v*c.Scale + c.Offset
+}
+
+// DataSeries returns a new series where all values in s are converted.
+func (c UnitConversion) DataSeries(s views.DataSeries) views.DataSeries {
+	out := make(views.DataSeries, len(s))
+	for t, v := range s {
+		out[t] = c.Convert(v)
+	}
+	return out
+}
+
+// Formula returns a calculation formula that converts the series with the
+// passed in alias. Factors are rounded to 12 significant digits.
+func (c UnitConversion) Formula(alias string) string {
+	f := alias
+	if c.Scale != 1 {
+		f += " * " + strconv.FormatFloat(c.Scale, 'g', 12, 64)
+	}
+	if c.Offset != 0 {
+		f += " + " + strconv.FormatFloat(c.Offset, 'g', 12, 64)
+	}
+	return f
+}
+
+// String returns a string on the format "<from>-><to>".
+func (c UnitConversion) String() string {
+	return c.From + "->" + c.To
+}
+
+// ConvertUnit returns a transform that records a conversion from the unit from
+// to the unit to for items with the engineering unit from. The conversion is
+// recorded in the automation.AnnotationPublisherUnitConversion annotation, so
+// that the scaling can be applied where the data is read. The engineering unit
+// is left unchanged, as the item still presents the data in the unit from.
+// Items with a different engineering unit are left unchanged.
+//
+// ConvertUnit panics if there is no known conversion between the units. Use
+// ConvertUnitWith to provide a custom conversion.
+func ConvertUnit(from, to string) func(item *views.ItemSave) {
+	c, ok := LookupUnitConversion(from, to)
+	if !ok {
+		panic(fmt.Sprintf("transform: no known unit conversion from %q to %q", from, to))
+	}
+	return ConvertUnitWith(c)
+}
+
+// ConvertUnitWith is like ConvertUnit, but uses the passed in conversion.
+func ConvertUnitWith(c UnitConversion) func(item *views.ItemSave) {
+	return func(item *views.ItemSave) {
+		if item.EngUnit != c.From {
+			return
+		}
+		item.Annotations.Set(automation.AnnotationPublisherUnitConversion, c.String())
+	}
+}
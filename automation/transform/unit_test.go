@@ -0,0 +1,75 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/automation/transform"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestLookupUnitConversion(t *testing.T) {
+	test := func(from, to string, v, expect float64) func(t *testing.T) {
+		return func(t *testing.T) {
+			c, ok := transform.LookupUnitConversion(from, to)
+			if !ok {
+				t.Fatalf("Unexpected lookup failure")
+			}
+			if result := c.Convert(v); math.Abs(result-expect) > 1e-9 {
+				t.Errorf("Unexpected result:\n got: %v\nwant: %v", result, expect)
+			}
+		}
+	}
+	t.Run("degC->degF", test("degC", "degF", 100, 212))
+	t.Run("degF->degC", test("degF", "degC", 32, 0))
+	t.Run("degF->K", test("degF", "K", 212, 373.15))
+	t.Run("bar->kPa", test("bar", "kPa", 2, 200))
+	t.Run("ft->m", test("ft", "m", 10, 3.048))
+
+	if _, ok := transform.LookupUnitConversion("degC", "bar"); ok {
+		t.Errorf("Expected lookup across dimensions to fail")
+	}
+}
+
+func TestUnitConversionFormula(t *testing.T) {
+	c, _ := transform.LookupUnitConversion("degC", "degF")
+	if result, expect := c.Formula("t"), "t * 1.8 + 32"; result != expect {
+		t.Errorf("Unexpected formula:\n got: %q\nwant: %q", result, expect)
+	}
+}
+
+func TestConvertUnit(t *testing.T) {
+	f := transform.ConvertUnit("degC", "degF")
+
+	var item views.ItemSave
+	item.EngUnit = "degC"
+	f(&item)
+	if item.EngUnit != "degC" {
+		t.Errorf("Unexpected engUnit:\n got: %q\nwant: %q", item.EngUnit, "degC")
+	}
+	if v := item.Annotations.Get(automation.AnnotationPublisherUnitConversion); v != "degC->degF" {
+		t.Errorf("Unexpected annotation:\n got: %q\nwant: %q", v, "degC->degF")
+	}
+
+	var other views.ItemSave
+	other.EngUnit = "bar"
+	f(&other)
+	if other.EngUnit != "bar" || other.Annotations != nil {
+		t.Errorf("Unexpected change to item with other unit: %+v", other)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"crypto/sha1"
+	"fmt"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+// TransformRegistry holds named and versioned item transforms, so that they
+// can be referenced by name, e.g. from configuration files. The zero value is
+// an empty registry ready to use.
+type TransformRegistry struct {
+	transforms map[string]registeredTransform
+}
+
+type registeredTransform struct {
+	version string
+	f       func(item *views.ItemSave)
+}
+
+// NewTransformRegistry returns a new empty registry.
+func NewTransformRegistry() *TransformRegistry {
+	return &TransformRegistry{}
+}
+
+// Register adds the transform f with the passed in name and an empty version.
+// It's equivalent to r.RegisterVersion(name, "", f).
+func (r *TransformRegistry) Register(name string, f func(item *views.ItemSave)) *TransformRegistry {
+	return r.RegisterVersion(name, "", f)
+}
+
+// RegisterVersion adds the transform f with the passed in name and version.
+// The version should be changed when the behavior of f changes, in order to
+// republish items that are affected. Panics if name is already registered.
+func (r *TransformRegistry) RegisterVersion(name, version string, f func(item *views.ItemSave)) *TransformRegistry {
+	if _, ok := r.transforms[name]; ok {
+		panic(fmt.Sprintf("automation: transform %q already registered", name))
+	}
+	if r.transforms == nil {
+		r.transforms = make(map[string]registeredTransform)
+	}
+	r.transforms[name] = registeredTransform{version: version, f: f}
+	return r
+}
+
+// Transforms returns the transforms registered with the passed in names in
+// order, and a transform version that is calculated as a hash of the names and
+// versions. The version changes when the list of names or any of the
+// transform versions change, and is suitable for use as the
+// PublishSignals.TransformVersion value. An error wrapping ErrBadConfig is
+// returned if any of the names are not registered. A nil registry is treated
+// as empty.
+//
+// When no names are passed in, the version is empty, matching the default
+// PublishSignals.TransformVersion value.
+func (r *TransformRegistry) Transforms(names ...string) ([]func(item *views.ItemSave), string, error) {
+	if len(names) == 0 {
+		return []func(item *views.ItemSave){}, "", nil
+	}
+	transforms := make([]func(item *views.ItemSave), 0, len(names))
+	hash := sha1.New()
+	for _, name := range names {
+		var t registeredTransform
+		var ok bool
+		if r != nil {
+			t, ok = r.transforms[name]
+		}
+		if !ok {
+			return nil, "", fmt.Errorf("%w: unknown transform %q", ErrBadConfig, name)
+		}
+		transforms = append(transforms, t.f)
+		fmt.Fprintf(hash, "%s\x00%s\n", name, t.version)
+	}
+	return transforms, fields.Hexadecimal(hash.Sum(nil)).String(), nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestTransformRegistry(t *testing.T) {
+	noop := func(item *views.ItemSave) {}
+	r1 := automation.NewTransformRegistry().Register("a", noop).RegisterVersion("b", "1", noop)
+	r2 := automation.NewTransformRegistry().Register("a", noop).RegisterVersion("b", "2", noop)
+
+	_, v1, err := r1.Transforms("a", "b")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, v, _ := r1.Transforms("a", "b"); v != v1 {
+		t.Errorf("Expected stable version:\n got: %s\nwant: %s", v, v1)
+	}
+	for _, names := range [][]string{{"a"}, {"b", "a"}} {
+		if _, v, _ := r1.Transforms(names...); v == v1 {
+			t.Errorf("Expected version for %v to differ from %s", names, v1)
+		}
+	}
+	if _, v, _ := r2.Transforms("a", "b"); v == v1 {
+		t.Errorf("Expected version to change with transform version")
+	}
+	if _, _, err := r1.Transforms("c"); !errors.Is(err, automation.ErrBadConfig) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, automation.ErrBadConfig)
+	}
+	if transforms, v, err := r1.Transforms(); err != nil || v != "" || len(transforms) != 0 {
+		t.Errorf("Unexpected result for no transforms:\n got: %d, %q, %v\nwant: 0, \"\", <nil>", len(transforms), v, err)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+)
+
+var (
+	_ Source = ChanSource(nil)
+	_ Source = (*ReaderSource)(nil)
+)
+
+// ChanSource is a Source that receives messages from a channel. Receive
+// returns io.EOF once the channel is closed. This allows adapting
+// subscription based clients, by sending messages to the channel from the
+// subscription callback.
+type ChanSource <-chan Message
+
+func (s ChanSource) Receive(ctx context.Context) (Message, error) {
+	select {
+	case m, ok := <-s:
+		if !ok {
+			return Message{}, io.EOF
+		}
+		return m, nil
+	case <-ctx.Done():
+		return Message{}, ctx.Err()
+	}
+}
+
+// ReaderSource is a Source that reads one message per line from a reader,
+// such as messages in the JSON Lines format written to the standard output
+// of a command-line consumer. Empty lines are skipped.
+//
+// Cancellation of the context passed to Receive is only detected between
+// lines.
+type ReaderSource struct {
+	// Subject is set as the subject of all messages.
+	Subject string
+
+	s *bufio.Scanner
+}
+
+// maxLineSize is the maximum line length accepted by ReaderSource.
+const maxLineSize = 1 << 20
+
+// NewReaderSource returns a new source reading messages from r.
+func NewReaderSource(r io.Reader) *ReaderSource {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, maxLineSize)
+	return &ReaderSource{s: s}
+}
+
+func (s *ReaderSource) Receive(ctx context.Context) (Message, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return Message{}, err
+		}
+		if !s.s.Scan() {
+			if err := s.s.Err(); err != nil {
+				return Message{}, err
+			}
+			return Message{}, io.EOF
+		}
+		line := bytes.TrimSpace(s.s.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		return Message{Subject: s.Subject, Data: bytes.Clone(line)}, nil
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trigger allows running automation routines in response to messages
+// from a queue or pub/sub system, such as NATS, Kafka or SQS, enabling
+// event-driven automation around the SDK.
+//
+// A Source describe the interface for receiving messages. To avoid pulling
+// third-party dependencies into the SDK, adapters for specific systems are
+// left to the application; a thin adapter typically forwards messages from a
+// subscription callback into a ChanSource. ReaderSource allows consuming
+// messages in the JSON Lines format from an io.Reader, such as the standard
+// output of a command-line consumer.
+//
+// By default, messages are decoded as JSON encoded Invocation values:
+//
+//	{"routine": "publish", "values": {"integration": "<integration-id>"}}
+package trigger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/clarify/clarify-go/automation"
+)
+
+// Errors returned by the trigger.
+const (
+	ErrBadMessage strError = "bad message"
+	ErrNoRoutine  strError = "no matching routine"
+)
+
+type strError string
+
+func (err strError) Error() string { return string(err) }
+
+// Message describe a single message received from a Source.
+type Message struct {
+	// Subject is an optional subject, topic or queue name for the message.
+	Subject string
+
+	// Data contain the message payload.
+	Data []byte
+
+	// Ack, if set, is called once the message has been handled, with a
+	// non-nil error if decoding the message or running the routines failed.
+	// Adapters can use this to acknowledge or reject the message.
+	Ack func(err error)
+}
+
+// Source describe the interface for receiving messages. Receive should block
+// until a message is available, and return io.EOF when there are no more
+// messages.
+type Source interface {
+	Receive(ctx context.Context) (Message, error)
+}
+
+// Invocation describe a request to run routines.
+type Invocation struct {
+	// Routine is a routine path pattern; see automation.Routines.SubRoutines.
+	Routine string `json:"routine"`
+
+	// Values are passed to the routines as configuration values; see
+	// automation.Config.WithValues.
+	Values map[string]any `json:"values,omitempty"`
+
+	// DryRun, if true, runs the routines in dry-run mode.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// DecodeFunc describe a function for mapping a message to an invocation.
+type DecodeFunc func(Message) (Invocation, error)
+
+// DecodeJSON decodes the message data as a JSON encoded Invocation. Unknown
+// fields are rejected. Errors wrap ErrBadMessage.
+func DecodeJSON(m Message) (Invocation, error) {
+	var inv Invocation
+	if err := decodeStrict(m.Data, &inv); err != nil {
+		return Invocation{}, fmt.Errorf("%w: %v", ErrBadMessage, err)
+	}
+	if inv.Routine == "" {
+		return Invocation{}, fmt.Errorf("%w: missing routine", ErrBadMessage)
+	}
+	return inv, nil
+}
+
+// Trigger runs routines for each message received from a source.
+type Trigger struct {
+	// Routines contain the routines that can be triggered.
+	Routines automation.Routines
+
+	// Source is the source to receive messages from.
+	Source Source
+
+	// Decode maps each message to an invocation. If not set, DecodeJSON is
+	// used.
+	Decode DecodeFunc
+}
+
+// Run receives and handles messages one at the time until the source returns
+// an error, ctx is canceled, or cfg.Checkpoint returns an error. Failures to
+// decode a message or to run routines are logged and reported via the
+// message's Ack function, but does not stop the trigger. If the source
+// returns io.EOF, nil is returned.
+func (t Trigger) Run(ctx context.Context, cfg *automation.Config) error {
+	logger := cfg.Logger()
+	for {
+		if err := cfg.Checkpoint(ctx); err != nil {
+			return err
+		}
+		m, err := t.Source.Receive(ctx)
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return err
+		}
+
+		err = t.handle(ctx, cfg, m)
+		if err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "Triggered run failed",
+				slog.String("subject", m.Subject),
+				automation.AttrError(err),
+			)
+		}
+		if m.Ack != nil {
+			m.Ack(err)
+		}
+	}
+}
+
+func (t Trigger) handle(ctx context.Context, cfg *automation.Config, m Message) error {
+	decode := t.Decode
+	if decode == nil {
+		decode = DecodeJSON
+	}
+	inv, err := decode(m)
+	if err != nil {
+		return err
+	}
+	routines := t.Routines.SubRoutines(inv.Routine)
+	if len(routines) == 0 {
+		return fmt.Errorf("%w: %q", ErrNoRoutine, inv.Routine)
+	}
+
+	if len(inv.Values) > 0 {
+		cfg = cfg.WithValues(inv.Values)
+	}
+	if inv.DryRun {
+		cfg = cfg.WithDryRun(true)
+	}
+	cfg.Logger().LogAttrs(ctx, slog.LevelInfo, "Triggered run",
+		slog.String("subject", m.Subject),
+		slog.String("pattern", inv.Routine),
+		slog.Any("values", inv.Values),
+	)
+	return routines.Do(ctx, cfg)
+}
+
+func decodeStrict(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/clarify/clarify-go/automation"
+	"github.com/clarify/clarify-go/automation/trigger"
+)
+
+func TestTrigger(t *testing.T) {
+	type call struct {
+		path        string
+		integration string
+		dryRun      bool
+	}
+	var calls []call
+	routine := automation.RoutineFunc(func(ctx context.Context, cfg *automation.Config) error {
+		integration, _ := automation.ValueFromConfig(cfg, "integration", "")
+		calls = append(calls, call{cfg.RoutinePath(), integration, cfg.DryRun()})
+		return nil
+	})
+	routines := automation.Routines{
+		"publish": automation.Routines{"a": routine, "b": routine},
+		"other":   routine,
+	}
+
+	input := strings.Join([]string{
+		`{"routine":"publish/a","values":{"integration":"x"}}`,
+		``,
+		`{"routine":"missing"}`,
+		`{"routine":"other","dryRun":true,"unknown":1}`,
+		`{"routine":"other","dryRun":true}`,
+	}, "\n")
+	ch := make(chan trigger.Message, 1)
+	ch <- trigger.Message{Data: []byte(`{"routine":"publish"}`)}
+	close(ch)
+
+	var acks []error
+	ack := func(err error) { acks = append(acks, err) }
+
+	cfg := automation.NewConfig(nil).WithLogger(nil)
+	for _, src := range []trigger.Source{trigger.NewReaderSource(strings.NewReader(input)), trigger.ChanSource(ch)} {
+		err := trigger.Trigger{
+			Routines: routines,
+			Source:   ackSource{src, ack},
+		}.Run(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	expectCalls := []call{
+		{"publish/a", "x", false},
+		{"other", "", true},
+		{"publish/a", "", false},
+		{"publish/b", "", false},
+	}
+	if len(calls) != len(expectCalls) {
+		t.Fatalf("Unexpected calls:\n got: %v\nwant: %v", calls, expectCalls)
+	}
+	for i := range calls {
+		if calls[i] != expectCalls[i] {
+			t.Errorf("Unexpected call %d:\n got: %v\nwant: %v", i, calls[i], expectCalls[i])
+		}
+	}
+
+	expectAcks := []error{nil, trigger.ErrNoRoutine, trigger.ErrBadMessage, nil, nil}
+	if len(acks) != len(expectAcks) {
+		t.Fatalf("Unexpected acks:\n got: %v\nwant: %v", acks, expectAcks)
+	}
+	for i := range acks {
+		if !errors.Is(acks[i], expectAcks[i]) || (expectAcks[i] == nil && acks[i] != nil) {
+			t.Errorf("Unexpected ack %d:\n got: %v\nwant: %v", i, acks[i], expectAcks[i])
+		}
+	}
+}
+
+// ackSource sets ack as the Ack function for all messages from src.
+type ackSource struct {
+	src trigger.Source
+	ack func(error)
+}
+
+func (s ackSource) Receive(ctx context.Context) (trigger.Message, error) {
+	m, err := s.src.Receive(ctx)
+	m.Ack = s.ack
+	return m, err
+}
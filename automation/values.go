@@ -0,0 +1,58 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ValueFromConfig returns the value for key from cfg converted to type T, or
+// fallback if no value is set. See Config.Value for lookup rules.
+//
+// String values, such as values passed in from the command-line, are converted
+// using encoding.TextUnmarshaler when implemented by *T, time.ParseDuration
+// when T is a time.Duration, and JSON decoding otherwise. An error wrapping
+// ErrBadConfig is returned if the value can not be converted.
+func ValueFromConfig[T any](cfg *Config, key string, fallback T) (T, error) {
+	v, ok := cfg.Value(key)
+	if !ok {
+		return fallback, nil
+	}
+	if t, ok := v.(T); ok {
+		return t, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return fallback, fmt.Errorf("%w: value %q: can not use %T as %T", ErrBadConfig, key, v, fallback)
+	}
+
+	var t T
+	var err error
+	switch target := any(&t).(type) {
+	case encoding.TextUnmarshaler:
+		err = target.UnmarshalText([]byte(s))
+	case *time.Duration:
+		*target, err = time.ParseDuration(s)
+	default:
+		err = json.Unmarshal([]byte(s), target)
+	}
+	if err != nil {
+		return fallback, fmt.Errorf("%w: value %q: %v", ErrBadConfig, key, err)
+	}
+	return t, nil
+}
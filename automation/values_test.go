@@ -0,0 +1,62 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/automation"
+)
+
+func TestValueFromConfig(t *testing.T) {
+	cfg := automation.NewConfig(nil).WithValues(map[string]any{
+		"threshold":                      "0.1",
+		"evaluate.threshold":             "0.2",
+		"evaluate/detect-fire.threshold": "0.5",
+		"evaluate/detect-fire.window":    "15m",
+		"evaluate/detect-fire.enabled":   true,
+		"evaluate/detect-fire.bad":       "x",
+	}).WithSubRoutineName("evaluate")
+
+	test := func(cfg *automation.Config, key string, expect float64) func(t *testing.T) {
+		return func(t *testing.T) {
+			t.Helper()
+			v, err := automation.ValueFromConfig(cfg, key, 0.0)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if v != expect {
+				t.Errorf("Unexpected value:\n got: %v\nwant: %v", v, expect)
+			}
+		}
+	}
+	t.Run("parent", test(cfg.WithSubRoutineName("other"), "threshold", 0.2))
+	t.Run("exact", test(cfg.WithSubRoutineName("detect-fire"), "threshold", 0.5))
+	t.Run("global", test(automation.NewConfig(nil).WithValues(map[string]any{"threshold": "0.1"}), "threshold", 0.1))
+	t.Run("fallback", test(cfg, "missing", 0))
+
+	routineCfg := cfg.WithSubRoutineName("detect-fire")
+	if d, err := automation.ValueFromConfig(routineCfg, "window", time.Minute); err != nil || d != 15*time.Minute {
+		t.Errorf("Unexpected duration value: %v, %v", d, err)
+	}
+	if b, err := automation.ValueFromConfig(routineCfg, "enabled", false); err != nil || !b {
+		t.Errorf("Unexpected bool value: %v, %v", b, err)
+	}
+	if _, err := automation.ValueFromConfig(routineCfg, "bad", 0); !errors.Is(err, automation.ErrBadConfig) {
+		t.Errorf("Expected ErrBadConfig, got: %v", err)
+	}
+}
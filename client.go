@@ -1,4 +1,4 @@
-// Copyright 2022-2024 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -16,6 +16,11 @@ package clarify
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/clarify/clarify-go/fields"
 	"github.com/clarify/clarify-go/internal/request"
@@ -39,13 +44,65 @@ const (
 
 // Client allows calling JSON RPC methods against Clarify.
 type Client struct {
-	ns IntegrationNamespace
+	ns   IntegrationNamespace
+	opts clientOptions
 }
 
 // NewClient can be used to initialize an integration client from a
-// jsonrpc.Handler implementation.
-func NewClient(integration string, h jsonrpc.Handler) *Client {
-	return &Client{ns: IntegrationNamespace{integration: integration, h: h}}
+// jsonrpc.Handler implementation. Options can be passed in to configure
+// optional behavior.
+func NewClient(integration string, h jsonrpc.Handler, opts ...ClientOption) *Client {
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Client{
+		ns:   IntegrationNamespace{integration: integration, h: o.handler(h), validate: o.validate},
+		opts: o,
+	}
+}
+
+// IntegrationID returns the ID of the integration that c is initialized for.
+func (c Client) IntegrationID() string {
+	return c.ns.integration
+}
+
+// Now returns the current time according to the client's clock. The default
+// clock is time.Now.
+func (c Client) Now() time.Time {
+	if c.opts.now == nil {
+		return time.Now()
+	}
+	return c.opts.now()
+}
+
+// WithAPIVersion returns a copy of c where all requests are sent with the
+// specified API version instead of the default version for each method. This
+// allows opting into newer server features before they are supported by the
+// SDK. Request-level overrides, set via the request's APIVersion method, take
+// precedence. An empty string resets to the default versions.
+func (c Client) WithAPIVersion(version string) *Client {
+	h := c.ns.h
+	if vh, ok := h.(apiVersionHandler); ok {
+		h = vh.Handler
+	}
+	if version != "" {
+		h = apiVersionHandler{Handler: h, version: version}
+	}
+	c.ns.h = h
+	return &c
+}
+
+var _ request.APIVersionHandler = apiVersionHandler{}
+
+// apiVersionHandler wraps a handler to override the default API version.
+type apiVersionHandler struct {
+	jsonrpc.Handler
+	version string
+}
+
+func (h apiVersionHandler) APIVersion() string {
+	return h.version
 }
 
 // Insert returns a new request for inserting data to clarify. When referencing
@@ -82,7 +139,7 @@ func (c Client) Integration() IntegrationNamespace {
 // Access to the admin namespace must be explicitly granted per integration in
 // the Clarify admin panel. Do not grant excessive permissions.
 func (c Client) Admin() AdminNamespace {
-	return AdminNamespace{h: c.ns.h}
+	return AdminNamespace{h: c.ns.h, opts: c.opts}
 }
 
 // Clarify return a handler for initializing methods that require access to
@@ -91,13 +148,14 @@ func (c Client) Admin() AdminNamespace {
 // Access to the clarify namespace must be explicitly granted per integration in
 // the Clarify admin panel.  Do not grant excessive permissions.
 func (c Client) Clarify() ClarifyNamespace {
-	return ClarifyNamespace{h: c.ns.h}
+	return ClarifyNamespace{h: c.ns.h, opts: c.opts}
 }
 
 type IntegrationNamespace struct {
 	integration string
 
-	h jsonrpc.Handler
+	h        jsonrpc.Handler
+	validate bool
 }
 
 // Insert returns a new request for inserting data to clarify. When referencing
@@ -112,6 +170,11 @@ type InsertRequest = request.Request[InsertResult]
 // InsertResult holds the result of an Insert operation.
 type InsertResult struct {
 	SignalsByInput map[string]views.CreateSummary `json:"signalsByInput"`
+
+	// DroppedByInput holds the number of samples per series that were
+	// dropped client-side before the request was sent; see
+	// WithTimestampValidation. It's set whether or not the request succeeds.
+	DroppedByInput map[string]int `json:"-"`
 }
 
 var methodInsert = request.Method[InsertResult]{
@@ -122,8 +185,19 @@ var methodInsert = request.Method[InsertResult]{
 // SaveSignals returns a new request for updating signal meta-data in Clarify.
 // When referencing input IDs that don't exist for the current integration, new
 // signals are created automatically on demand.
+//
+// When the client is configured with WithSignalValidation, inputs are
+// validated client-side using ValidateSignals. If validation fails, the
+// returned request's Do method returns the validation error without performing
+// a request.
 func (ns IntegrationNamespace) SaveSignals(inputs map[string]views.SignalSave) SaveSignalRequest {
-	return methodSaveSignals.NewRequest(ns.h,
+	h := ns.h
+	if ns.validate {
+		if err := ValidateSignals(inputs); err != nil {
+			h = invalidRPCHandler{err: err}
+		}
+	}
+	return methodSaveSignals.NewRequest(h,
 		paramIntegration.Value(ns.integration),
 		paramSignalsByInput.Value(inputs),
 	)
@@ -145,20 +219,40 @@ var methodSaveSignals = request.Method[SaveSignalsResult]{
 	Method:     "integration.saveSignals",
 }
 
+// ValidateSignals validates inputs client-side, and returns an error wrapping
+// ErrBadRequest and PathErrors if any issues are found. Issues are keyed by
+// `signalsByInput.<input>` for input keys, and by
+// `signalsByInput.<input>.<field>` for signal fields. See SignalSave.Issues
+// for the rules that are checked.
+func ValidateSignals(inputs map[string]views.SignalSave) error {
+	issues := make(PathErrors)
+	for input, signal := range inputs {
+		if err := views.ValidateInputKey(input); err != nil {
+			issues["signalsByInput."+input] = []string{err.Error()}
+		}
+		for path, msgs := range signal.Issues() {
+			issues["signalsByInput."+input+"."+path] = msgs
+		}
+	}
+	if len(issues) > 0 {
+		return joinErrors(ErrBadRequest, issues, ": ")
+	}
+	return nil
+}
+
 type AdminNamespace struct {
-	h jsonrpc.Handler
+	h    jsonrpc.Handler
+	opts clientOptions
 }
 
 // SelectSignals returns a new request for querying signals and related
-// resources.
+// resources. The default selection format can be overridden per request via
+// Format.
 func (ns AdminNamespace) SelectSignals(integration string, q fields.ResourceQuery) SelectSignalsRequest {
 	return methodSelectSignals.NewRequest(ns.h,
 		paramIntegration.Value(integration),
-		paramQuery.Value(q),
-		paramFormat.Value(views.SelectionFormat{
-			DataAsArray:         true,
-			GroupIncludedByType: true,
-		}),
+		paramQuery.Value(ns.opts.query(q)),
+		paramFormat.Value(views.DefaultSelectionFormat()),
 	)
 }
 
@@ -177,6 +271,94 @@ var methodSelectSignals = request.RelationalMethod[SelectSignalsResult]{
 	Method:     "admin.selectSignals",
 }
 
+// SelectIntegrations returns a new request for querying integrations. This can
+// be used to discover integrations by e.g. labels, instead of hard-coding a
+// list of integration IDs. The default selection format can be overridden per
+// request via Format.
+func (ns AdminNamespace) SelectIntegrations(q fields.ResourceQuery) SelectIntegrationsRequest {
+	return methodSelectIntegrations.NewRequest(ns.h,
+		paramQuery.Value(ns.opts.query(q)),
+		paramFormat.Value(views.DefaultSelectionFormat()),
+	)
+}
+
+type (
+	// SelectIntegrationsRequest describe an initialized
+	// admin.selectIntegrations RPC request with access to a request handler.
+	SelectIntegrationsRequest = request.Relational[SelectIntegrationsResult]
+
+	// SelectIntegrationsResult describe the result format for a
+	// SelectIntegrationsRequest.
+	SelectIntegrationsResult = views.Selection[[]views.Integration, views.IntegrationInclude]
+)
+
+var methodSelectIntegrations = request.RelationalMethod[SelectIntegrationsResult]{
+	APIVersion: apiVersion,
+	Method:     "admin.selectIntegrations",
+}
+
+// Usage returns a new request for reporting resource counts for the
+// organization, such as the number of integrations, signals and items. The
+// counts are collected via select requests, and do not include request counts
+// or ingestion quotas, which are not exposed by the API.
+func (ns AdminNamespace) Usage() UsageRequest {
+	return UsageRequest{ns: ns}
+}
+
+// UsageRequest describe a request for resource counts, performed as multiple
+// select RPC requests.
+type UsageRequest struct {
+	ns AdminNamespace
+}
+
+// UsageResult describe the result format for a UsageRequest.
+type UsageResult struct {
+	Integrations         int            `json:"integrations"`
+	Signals              int            `json:"signals"`
+	SignalsByIntegration map[string]int `json:"signalsByIntegration"`
+	Items                int            `json:"items"`
+}
+
+// Do performs one request for selecting integrations per page of 1000
+// integrations, one request per integration for counting signals, and one
+// request for counting items.
+func (req UsageRequest) Do(ctx context.Context) (*UsageResult, error) {
+	count := fields.Query().Limit(0).Total(true)
+	result := UsageResult{
+		SignalsByIntegration: make(map[string]int),
+	}
+
+	q := fields.Query().Sort("id").Limit(usageIntegrationsPageSize)
+	for {
+		res, err := req.ns.SelectIntegrations(q).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, integration := range res.Data {
+			signals, err := req.ns.SelectSignals(integration.ID, count).Do(ctx)
+			if err != nil {
+				return nil, err
+			}
+			result.SignalsByIntegration[integration.ID] = signals.Meta.Total
+			result.Signals += signals.Meta.Total
+		}
+		result.Integrations += len(res.Data)
+		if len(res.Data) < q.GetLimit() {
+			break
+		}
+		q = q.NextPage()
+	}
+
+	clarify := ClarifyNamespace{h: req.ns.h, opts: req.ns.opts}
+	items, err := clarify.SelectItems(count).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.Items = items.Meta.Total
+
+	return &result, nil
+}
+
 // PublishSignals returns a new request for publishing signals as items.
 func (ns AdminNamespace) PublishSignals(integration string, itemsBySignal map[string]views.ItemSave) PublishSignalsRequest {
 	return methodPublishSignals.NewRequest(ns.h,
@@ -203,17 +385,16 @@ var methodPublishSignals = request.Method[PublishSignalsResult]{
 }
 
 type ClarifyNamespace struct {
-	h jsonrpc.Handler
+	h    jsonrpc.Handler
+	opts clientOptions
 }
 
-// SelectItems returns a request for querying items.
+// SelectItems returns a request for querying items. The default selection
+// format can be overridden per request via Format.
 func (ns ClarifyNamespace) SelectItems(q fields.ResourceQuery) SelectItemsRequest {
 	return methodSelectItems.NewRequest(ns.h,
-		paramQuery.Value(q),
-		paramFormat.Value(views.SelectionFormat{
-			DataAsArray:         true,
-			GroupIncludedByType: true,
-		}),
+		paramQuery.Value(ns.opts.query(q)),
+		paramFormat.Value(views.DefaultSelectionFormat()),
 	)
 }
 
@@ -231,6 +412,106 @@ var methodSelectItems = request.RelationalMethod[SelectItemsResult]{
 	Method:     "clarify.selectItems",
 }
 
+// getItemsChunkSize is the maximum number of IDs to look up per request; it
+// matches the maximum query limit for clarify.selectItems.
+const getItemsChunkSize = 1000
+
+// usageIntegrationsPageSize is the number of integrations to select per
+// request for UsageRequest; it matches the maximum query limit for
+// admin.selectIntegrations.
+const usageIntegrationsPageSize = 1000
+
+// defaultDataFrameChunkSize is the default number of item IDs per request for
+// DataFrameRequest.DoChunked.
+const defaultDataFrameChunkSize = 50
+
+// GetItems returns a request for looking up items by ID. Duplicated IDs are
+// only looked up once.
+func (ns ClarifyNamespace) GetItems(ids ...string) GetItemsRequest {
+	return GetItemsRequest{
+		ids: ids,
+		ns:  ns,
+	}
+}
+
+// GetItemsRequest describe a request for looking up items by ID, performed as
+// one or more clarify.selectItems RPC requests.
+type GetItemsRequest struct {
+	ids []string
+	ns  ClarifyNamespace
+}
+
+// Do looks up the items in chunks of at most 1000 IDs per request, and returns
+// the result keyed by item ID. Items that are not found are not included in
+// the result.
+func (req GetItemsRequest) Do(ctx context.Context) (map[string]views.Item, error) {
+	ids := slices.Clone(req.ids)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	items := make(map[string]views.Item, len(ids))
+	for chunk := range slices.Chunk(ids, getItemsChunkSize) {
+		q := fields.Query().
+			Where(fields.CompareField("id", fields.In(chunk...))).
+			Limit(len(chunk))
+		res, err := req.ns.SelectItems(q).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range res.Data {
+			items[item.ID] = item
+		}
+	}
+	return items, nil
+}
+
+// LatestValues returns a request for looking up the latest non-empty value of
+// each item matching the items query, such as for rendering the current value
+// of a set of items in a dashboard.
+func (ns ClarifyNamespace) LatestValues(items fields.ResourceQuery) LatestValuesRequest {
+	return LatestValuesRequest{
+		items: items,
+		ns:    ns,
+	}
+}
+
+// LatestValuesRequest describe a request for the latest value of items,
+// performed as a clarify.dataFrame RPC request for the last data-point of each
+// item.
+type LatestValuesRequest struct {
+	items  fields.ResourceQuery
+	before time.Time
+	ns     ClarifyNamespace
+}
+
+// Before returns a request for the latest values before t, instead of the
+// latest values overall. A zero t resets to the default.
+func (req LatestValuesRequest) Before(t time.Time) LatestValuesRequest {
+	req.before = t
+	return req
+}
+
+// Do performs the request against the server, and returns the latest sample
+// keyed by item ID. Items without data are not included in the result. Only
+// items within the limit of the items query are looked up.
+func (req LatestValuesRequest) Do(ctx context.Context) (map[string]views.Sample, error) {
+	data := fields.Data().Last(1)
+	if !req.before.IsZero() {
+		data = fields.Data().LastBefore(1, req.before)
+	}
+	res, err := req.ns.DataFrame(req.items, data).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	samples := make(map[string]views.Sample, len(res.Data))
+	for id, s := range res.Data {
+		if sample, ok := s.Latest(); ok {
+			samples[id] = sample
+		}
+	}
+	return samples, nil
+}
+
 // DataFrame returns a new request from retrieving raw or aggregated data from
 // Clarify. When a data query rollup is specified, data is aggregated using the
 // default aggregation methods for each item is used. That is statistical
@@ -238,32 +519,451 @@ var methodSelectItems = request.RelationalMethod[SelectItemsResult]{
 // histogram aggregation in seconds (duration spent in each state per bucket)
 // for enum items.
 func (ns ClarifyNamespace) DataFrame(items fields.ResourceQuery, data fields.DataQuery) DataFrameRequest {
-	return methodDataFrame.NewRequest(ns.h,
-		paramQuery.Value(items),
-		paramData.Value(data),
-		paramFormat.Value(views.SelectionFormat{
-			GroupIncludedByType: true,
-		}),
-	)
+	return DataFrameRequest{
+		items: ns.opts.query(items),
+		data:  data,
+		h:     ns.h,
+	}
 }
 
-type (
-	DataFrameRequest = request.Relational[DataFrameResult]
-	DataFrameResult  = views.Selection[views.DataFrame, views.DataFrameInclude]
-)
+// DataFrameRequest describe an initialized clarify.dataFrame RPC request with
+// access to a request handler.
+type DataFrameRequest struct {
+	items         fields.ResourceQuery
+	data          fields.DataQuery
+	aggregates    []fields.TimeAggregation
+	relationships []string
+	apiVersion    string
+	timeout       time.Duration
+	h             jsonrpc.Handler
+}
+
+// DataFrameResult describe the result format for a DataFrameRequest.
+type DataFrameResult = views.Selection[views.DataFrame, views.DataFrameInclude]
 
 var methodDataFrame = request.RelationalMethod[DataFrameResult]{
 	APIVersion: apiVersion,
 	Method:     "clarify.dataFrame",
 }
 
+// Include returns a request that appends the named relationships to the
+// request include list.
+func (req DataFrameRequest) Include(relationships ...string) DataFrameRequest {
+	newRelationships := make([]string, 0, len(req.relationships)+len(relationships))
+	newRelationships = append(append(newRelationships, req.relationships...), relationships...)
+	req.relationships = newRelationships
+
+	return req
+}
+
+// Aggregates returns a request where the result only include the series for
+// the specified rollup aggregates, e.g. only fields.TimeAggregationAvg, for
+// each item. This reduce the payload size for clients that only need some of
+// the aggregates. Supported aggregates are count, min, max, sum and avg;
+// state series for enum items are not included when aggregates are set. When
+// called multiple times, the aggregates are appended. An error wrapping
+// ErrBadRequest is returned from Do if the data query has no rollup.
+//
+// As the series filter requires explicit series keys, item IDs are resolved
+// via an additional clarify.selectItems request before requesting data. For
+// per-item aggregation methods, use Evaluate instead.
+func (req DataFrameRequest) Aggregates(methods ...fields.TimeAggregation) DataFrameRequest {
+	newAggregates := make([]fields.TimeAggregation, 0, len(req.aggregates)+len(methods))
+	newAggregates = append(append(newAggregates, req.aggregates...), methods...)
+	req.aggregates = newAggregates
+
+	return req
+}
+
+// APIVersion returns a request that is sent with the specified API version
+// instead of the default version for the method. An empty string resets to the
+// default.
+func (req DataFrameRequest) APIVersion(version string) DataFrameRequest {
+	req.apiVersion = version
+	return req
+}
+
+// Timeout returns a request where each RPC request performed is given the
+// specified timeout. Methods that perform multiple requests, such as
+// DoChunked, apply the timeout per request. A value <= 0 resets to no timeout.
+func (req DataFrameRequest) Timeout(d time.Duration) DataFrameRequest {
+	req.timeout = d
+	return req
+}
+
+// Do performs the request against the server and returns the result.
+func (req DataFrameRequest) Do(ctx context.Context) (*DataFrameResult, error) {
+	req, ok, err := req.resolveAggregates(ctx)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return &DataFrameResult{Data: views.DataFrame{}}, nil
+	}
+	return req.do(ctx, req.data)
+}
+
+// resolveAggregates returns a request for the IDs of the items matching the
+// items query, with a series filter for the request aggregates applied. If no
+// aggregates are set, req is returned as is. If no items match the query, ok
+// is false.
+func (req DataFrameRequest) resolveAggregates(ctx context.Context) (_ DataFrameRequest, ok bool, err error) {
+	if len(req.aggregates) == 0 {
+		return req, true, nil
+	}
+	if err := req.checkAggregates(); err != nil {
+		return req, false, err
+	}
+	res, err := req.selectItems(req.items).Do(ctx)
+	if err != nil {
+		return req, false, err
+	}
+	if len(res.Data) == 0 {
+		return req, false, nil
+	}
+	ids := make([]string, 0, len(res.Data))
+	for _, item := range res.Data {
+		ids = append(ids, item.ID)
+	}
+	req.items = fields.Query().
+		Where(fields.CompareField("id", fields.In(ids...))).
+		Limit(len(ids))
+	req.data = req.aggregateData(req.data, ids)
+	return req, true, nil
+}
+
+// checkAggregates returns an error if the request contain aggregates that
+// can not be used to filter data frame series, or if aggregates are set for a
+// data query without a rollup.
+func (req DataFrameRequest) checkAggregates() error {
+	if _, ok := req.data.GetRollup(); !ok && len(req.aggregates) > 0 {
+		return fmt.Errorf("%w: Aggregates require a data query with a rollup", ErrBadRequest)
+	}
+	for _, m := range req.aggregates {
+		switch m {
+		case fields.TimeAggregationCount, fields.TimeAggregationMin, fields.TimeAggregationMax, fields.TimeAggregationSum, fields.TimeAggregationAvg:
+		default:
+			return fmt.Errorf("%w: unsupported data frame aggregate %q", ErrBadRequest, m)
+		}
+	}
+	return nil
+}
+
+// aggregateData returns data with a series filter that only include the
+// series for req.aggregates for the passed in item IDs.
+func (req DataFrameRequest) aggregateData(data fields.DataQuery, ids []string) fields.DataQuery {
+	keys := make([]string, 0, len(ids)*len(req.aggregates))
+	for _, id := range ids {
+		for _, m := range req.aggregates {
+			keys = append(keys, id+"_"+m.String())
+		}
+	}
+	return data.Where(fields.SeriesIn(keys...))
+}
+
+func (req DataFrameRequest) do(ctx context.Context, data fields.DataQuery) (*DataFrameResult, error) {
+	r := methodDataFrame.NewRequest(req.h,
+		paramQuery.Value(req.items),
+		paramData.Value(data),
+		paramFormat.Value(views.SelectionFormat{
+			GroupIncludedByType: true,
+		})).
+		Include(req.relationships...).
+		APIVersion(req.apiVersion).
+		Timeout(req.timeout)
+
+	return r.Do(ctx)
+}
+
+// selectItems returns a clarify.selectItems request for q, using the timeout
+// of req.
+func (req DataFrameRequest) selectItems(q fields.ResourceQuery) SelectItemsRequest {
+	return methodSelectItems.NewRequest(req.h,
+		paramQuery.Value(q),
+		paramFormat.Value(views.DefaultSelectionFormat()),
+	).Timeout(req.timeout)
+}
+
+// DoWindowed splits the data query time range into windows of at most the
+// specified size, performs one request per window with up to parallelism
+// requests running concurrently, and merges the results into a single result.
+// Windows are aligned to rollup bucket boundaries for fixed duration rollups;
+// see fields.DataQuery.SplitTimeRange for details.
+//
+// If any request fails, remaining requests are canceled and the first error is
+// returned.
+func (req DataFrameRequest) DoWindowed(ctx context.Context, window time.Duration, parallelism int) (*DataFrameResult, error) {
+	req, ok, err := req.resolveAggregates(ctx)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return &DataFrameResult{Data: views.DataFrame{}}, nil
+	}
+	queries := req.data.SplitTimeRange(window)
+	if len(queries) == 1 {
+		return req.do(ctx, queries[0])
+	}
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	results, err := doParallel(ctx, len(queries), parallelism, func(ctx context.Context, i int) (*DataFrameResult, error) {
+		return req.do(ctx, queries[i])
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mergeDataFrameResults(results), nil
+}
+
+// DoTimeRanges performs one request per time range, with up to parallelism
+// requests running concurrently, and merges the results into a single result.
+// Each range is combined with the time range of the data query; see
+// fields.DataQuery.ForTimeRanges. This allows fetching multiple time windows,
+// e.g. the same hour for several days, without fetching the data in between.
+//
+// If any request fails, remaining requests are canceled and the first error is
+// returned.
+func (req DataFrameRequest) DoTimeRanges(ctx context.Context, parallelism int, ranges ...fields.DataFilter) (*DataFrameResult, error) {
+	req, ok, err := req.resolveAggregates(ctx)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return &DataFrameResult{Data: views.DataFrame{}}, nil
+	}
+	queries := req.data.ForTimeRanges(ranges...)
+	if len(queries) == 0 {
+		return &DataFrameResult{Data: views.DataFrame{}}, nil
+	}
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	results, err := doParallel(ctx, len(queries), parallelism, func(ctx context.Context, i int) (*DataFrameResult, error) {
+		return req.do(ctx, queries[i])
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mergeDataFrameResults(results), nil
+}
+
+// DoLastBefore returns the last n non-empty data-points before t for each
+// item matching the items query, such as to get the latest value as of time t
+// for reconciliation jobs. The search is performed backwards in time windows
+// of the given size, with one request per window, until n data-points are
+// found for all items, or maxWindows requests have been performed. Each
+// request only include items that still lack data-points. If window is <= 0,
+// a single request without a lower time bound is performed; see
+// fields.DataQuery.LastBefore.
+//
+// As item IDs are resolved via an additional clarify.selectItems request,
+// the method only supports raw data queries. An error wrapping ErrBadRequest
+// is returned if the data query has a rollup, or if aggregates are set.
+func (req DataFrameRequest) DoLastBefore(ctx context.Context, n int, t time.Time, window time.Duration, maxWindows int) (*DataFrameResult, error) {
+	if _, ok := req.data.GetRollup(); ok || len(req.aggregates) > 0 {
+		return nil, fmt.Errorf("%w: DoLastBefore require a raw data query", ErrBadRequest)
+	}
+	if n < 1 {
+		n = 1
+	}
+	if window <= 0 {
+		return req.do(ctx, req.data.LastBefore(n, t))
+	}
+	if maxWindows < 1 {
+		maxWindows = 1
+	}
+
+	res, err := req.selectItems(req.items).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	remaining := make([]string, 0, len(res.Data))
+	for _, item := range res.Data {
+		remaining = append(remaining, item.ID)
+	}
+
+	merged := DataFrameResult{Data: make(views.DataFrame)}
+	seen := make(map[string]bool)
+	lt := t
+	for i := 0; i < maxWindows && len(remaining) > 0; i++ {
+		gte := lt.Add(-window)
+		windowReq := req
+		windowReq.items = fields.Query().
+			Where(fields.CompareField("id", fields.In(remaining...))).
+			Limit(len(remaining))
+		data := req.data.Where(fields.TimeRange(gte, lt)).Where(fields.SeriesIn(remaining...)).Last(n)
+		result, err := windowReq.do(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			merged.Meta = result.Meta
+		}
+		merged.Included.Items = appendIncludedItems(merged.Included.Items, result.Included.Items, seen)
+
+		// Windows are searched from newest to oldest, so only the latest
+		// data-points needed to reach n are kept from each window.
+		for k, series := range result.Data {
+			target, ok := merged.Data[k]
+			if !ok {
+				target = make(views.DataSeries, n)
+				merged.Data[k] = target
+			}
+			times := series.Timestamps()
+			for j := len(times) - 1; j >= 0 && len(target) < n; j-- {
+				target[times[j]] = series[times[j]]
+			}
+		}
+		remaining = slices.DeleteFunc(remaining, func(id string) bool {
+			return len(merged.Data[id]) >= n
+		})
+		lt = gte
+	}
+	return &merged, nil
+}
+
+// DoChunked resolves the IDs of all items matching the items query, and
+// performs one request per chunk of at most size item IDs, with up to
+// parallelism requests running concurrently. The results are merged into a
+// single result, including the combined list of included items. This allows
+// retrieving data for more items than allowed in a single request. The limit
+// and skip values of the items query are ignored.
+//
+// If any request fails, remaining requests are canceled and the first error is
+// returned.
+func (req DataFrameRequest) DoChunked(ctx context.Context, size, parallelism int) (*DataFrameResult, error) {
+	if size < 1 {
+		size = defaultDataFrameChunkSize
+	}
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	if err := req.checkAggregates(); err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	q := req.items.Sort("id").Skip(0).Limit(getItemsChunkSize)
+	for {
+		res, err := req.selectItems(q).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range res.Data {
+			ids = append(ids, item.ID)
+		}
+		if len(res.Data) < q.GetLimit() {
+			break
+		}
+		q = q.NextPage()
+	}
+	if len(ids) == 0 {
+		return &DataFrameResult{Data: views.DataFrame{}}, nil
+	}
+
+	chunks := slices.Collect(slices.Chunk(ids, size))
+	results, err := doParallel(ctx, len(chunks), parallelism, func(ctx context.Context, i int) (*DataFrameResult, error) {
+		chunkReq := req
+		chunkReq.items = fields.Query().
+			Where(fields.CompareField("id", fields.In(chunks[i]...))).
+			Limit(len(chunks[i]))
+		data := req.data
+		if len(req.aggregates) > 0 {
+			data = req.aggregateData(data, chunks[i])
+		}
+		return chunkReq.do(ctx, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mergeDataFrameResults(results), nil
+}
+
+// doParallel calls f for each index in [0,n), with up to parallelism calls
+// running concurrently, and returns the results in order. If any call fails,
+// remaining calls are canceled and the first error is returned.
+func doParallel[R any](ctx context.Context, n, parallelism int, f func(ctx context.Context, i int) (*R, error)) ([]*R, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	results := make([]*R, n)
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i := range n {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			res, err := f(ctx, i)
+			if err != nil {
+				cancel(err)
+				return
+			}
+			results[i] = res
+		}()
+	}
+	wg.Wait()
+	if err := context.Cause(ctx); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// mergeDataFrameResults merges results in order, letting values from later
+// results take precedence for duplicated timestamps.
+func mergeDataFrameResults(results []*DataFrameResult) *DataFrameResult {
+	merged := DataFrameResult{
+		Meta: results[0].Meta,
+		Data: make(views.DataFrame),
+	}
+	seen := make(map[string]bool)
+	for _, res := range results {
+		for k, series := range res.Data {
+			target, ok := merged.Data[k]
+			if !ok {
+				target = make(views.DataSeries, len(series))
+				merged.Data[k] = target
+			}
+			maps.Copy(target, series)
+		}
+		merged.Included.Items = appendIncludedItems(merged.Included.Items, res.Included.Items, seen)
+	}
+	return &merged
+}
+
+// appendIncludedItems appends the items that are not already seen to dst, and
+// marks them as seen.
+func appendIncludedItems(dst, items []views.Item, seen map[string]bool) []views.Item {
+	for _, item := range items {
+		if !seen[item.ID] {
+			seen[item.ID] = true
+			dst = append(dst, item)
+		}
+	}
+	return dst
+}
+
 // Evaluate returns a new request for retrieving aggregated data from Clarify
 // and perform calculations.
 func (ns ClarifyNamespace) Evaluate(data fields.DataQuery) EvaluateRequest {
-	return EvaluateRequest{
+	er := EvaluateRequest{
 		data: data,
 		h:    ns.h,
 	}
+	if ns.opts.format != nil {
+		er.format = *ns.opts.format
+	}
+	return er
 }
 
 func (er EvaluateRequest) Items(items ...fields.EvaluateItem) EvaluateRequest {
@@ -304,6 +1004,41 @@ func (er EvaluateRequest) Format(format views.SelectionFormat) EvaluateRequest {
 	return er
 }
 
+// SeriesIn returns a request where the result is reduced to only include the
+// series with the specified keys, e.g. the aliases of calculations. When called
+// multiple times, only series matching all calls are included.
+func (er EvaluateRequest) SeriesIn(keys ...string) EvaluateRequest {
+	er.data = er.data.Where(fields.SeriesIn(keys...))
+
+	return er
+}
+
+// Last returns a request where the result only include the last n non-empty
+// data-points per series. If n is <= 0, no limit is applied.
+func (er EvaluateRequest) Last(n int) EvaluateRequest {
+	er.data = er.data.Last(n)
+
+	return er
+}
+
+// APIVersion returns a request that is sent with the specified API version
+// instead of the default version for the method. An empty string resets to the
+// default.
+func (er EvaluateRequest) APIVersion(version string) EvaluateRequest {
+	er.apiVersion = version
+
+	return er
+}
+
+// Timeout returns a request where the context passed to Do is given the
+// specified timeout for the duration of the call. A value <= 0 resets to no
+// timeout.
+func (er EvaluateRequest) Timeout(d time.Duration) EvaluateRequest {
+	er.timeout = d
+
+	return er
+}
+
 func (er EvaluateRequest) Do(ctx context.Context) (*EvaluateResult, error) {
 	r := methodEvaluate.NewRequest(er.h,
 		paramData.Value(er.data),
@@ -311,7 +1046,9 @@ func (er EvaluateRequest) Do(ctx context.Context) (*EvaluateResult, error) {
 		paramGroups.Value(er.groups),
 		paramCalculations.Value(er.calculations),
 		paramFormat.Value(er.format)).
-		Include(er.relationships...)
+		Include(er.relationships...).
+		APIVersion(er.apiVersion).
+		Timeout(er.timeout)
 
 	return r.Do(ctx)
 }
@@ -325,6 +1062,8 @@ type EvaluateRequest struct {
 	calculations  []fields.Calculation
 	relationships []string
 	format        views.SelectionFormat
+	apiVersion    string
+	timeout       time.Duration
 	h             jsonrpc.Handler
 }
 
@@ -0,0 +1,232 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clarify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+// ErrReadOnly is returned for write methods performed by a client configured
+// with WithReadOnly.
+const ErrReadOnly strError = "read-only client"
+
+// ClientOption describes a function for configuring optional client
+// behavior. See NewClient.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	defaultLimit int
+	userAgent    string
+	middleware   []func(jsonrpc.Handler) jsonrpc.Handler
+	format       *views.SelectionFormat
+	now          func() time.Time
+	dryRun       bool
+	readOnly     bool
+	payloadLimit *payloadLimitHandler
+	timestamps   *TimestampValidation
+	validate     bool
+}
+
+// WithDefaultLimit returns an option that sets the limit to use for resource
+// queries where no limit is explicitly set. The default is to use the limit
+// default from the fields package.
+func WithDefaultLimit(n int) ClientOption {
+	return func(opts *clientOptions) {
+		opts.defaultLimit = n
+	}
+}
+
+// WithUserAgent returns an option that appends suffix to the User-Agent header
+// for all requests, e.g. to identify an application in server logs. The suffix
+// should be on the format "<product>/<version>".
+func WithUserAgent(suffix string) ClientOption {
+	return func(opts *clientOptions) {
+		opts.userAgent = suffix
+	}
+}
+
+// WithMiddleware returns an option that wraps the client's request handler with
+// the passed in middleware functions. The first middleware becomes the
+// outermost handler.
+func WithMiddleware(middleware ...func(jsonrpc.Handler) jsonrpc.Handler) ClientOption {
+	return func(opts *clientOptions) {
+		opts.middleware = append(opts.middleware, middleware...)
+	}
+}
+
+// WithSelectionFormat returns an option that sets the default selection format
+// for requests that allow setting a custom format, such as Evaluate. Requests
+// that decode results into a fixed format are not affected.
+func WithSelectionFormat(format views.SelectionFormat) ClientOption {
+	return func(opts *clientOptions) {
+		opts.format = &format
+	}
+}
+
+// WithClock returns an option that sets the function to use for getting the
+// current time, as returned by Client.Now. This is useful for testing code that
+// calculates time ranges relative to the current time.
+func WithClock(now func() time.Time) ClientOption {
+	return func(opts *clientOptions) {
+		opts.now = now
+	}
+}
+
+// WithDryRun returns an option that, when dryRun is true, short-circuits write
+// methods (Insert, SaveSignals and PublishSignals) without sending them to the
+// server. Instead, a success result is synthesized, where each entry is
+// reported as neither created nor updated, and the request is logged via
+// slog.Default(). Client-side validation is still performed. Read methods are
+// not affected.
+func WithDryRun(dryRun bool) ClientOption {
+	return func(opts *clientOptions) {
+		opts.dryRun = dryRun
+	}
+}
+
+// WithReadOnly returns an option that, when readOnly is true, rejects write
+// methods (Insert, SaveSignals and PublishSignals) locally with an error
+// wrapping ErrReadOnly. This is useful for reporting tools and notebooks, where
+// accidental writes must be impossible. Read methods are not affected. The
+// option takes precedence over WithDryRun.
+func WithReadOnly(readOnly bool) ClientOption {
+	return func(opts *clientOptions) {
+		opts.readOnly = readOnly
+	}
+}
+
+// WithSignalValidation returns an option that, when validate is true, makes
+// SaveSignals validate inputs client-side using ValidateSignals before sending
+// the request. The validation rules are best effort; they are not taken from
+// the API specification, and may reject signals that the server would accept.
+// The default is to leave validation to the server.
+func WithSignalValidation(validate bool) ClientOption {
+	return func(opts *clientOptions) {
+		opts.validate = validate
+	}
+}
+
+// handler returns h wrapped by configured middleware.
+func (opts clientOptions) handler(h jsonrpc.Handler) jsonrpc.Handler {
+	if opts.dryRun {
+		h = dryRunHandler{Handler: h}
+	}
+	if opts.readOnly {
+		h = readOnlyHandler{Handler: h}
+	}
+	if opts.payloadLimit != nil {
+		pl := *opts.payloadLimit
+		pl.Handler = h
+		h = pl
+	}
+	if opts.timestamps != nil {
+		h = timestampHandler{Handler: h, TimestampValidation: *opts.timestamps, now: opts.now}
+	}
+	if opts.userAgent != "" {
+		h = userAgentHandler{Handler: h, userAgent: opts.userAgent}
+	}
+	for i := len(opts.middleware) - 1; i >= 0; i-- {
+		h = opts.middleware[i](h)
+	}
+	return h
+}
+
+// query returns q with the default limit applied.
+func (opts clientOptions) query(q fields.ResourceQuery) fields.ResourceQuery {
+	if opts.defaultLimit != 0 && !q.HasLimit() {
+		q = q.Limit(opts.defaultLimit)
+	}
+	return q
+}
+
+// userAgentHandler wraps a handler to set a custom User-Agent suffix.
+type userAgentHandler struct {
+	jsonrpc.Handler
+	userAgent string
+}
+
+func (h userAgentHandler) Do(ctx context.Context, req jsonrpc.Request, result any) error {
+	req.UserAgent = h.userAgent
+	return h.Handler.Do(ctx, req, result)
+}
+
+// dryRunHandler wraps a handler to synthesize results for write methods.
+type dryRunHandler struct {
+	jsonrpc.Handler
+}
+
+func (h dryRunHandler) Do(ctx context.Context, req jsonrpc.Request, result any) error {
+	params, _ := req.Params.(map[string]any)
+	var keys []string
+	switch req.Method {
+	case methodInsert.Method:
+		data, _ := params[string(paramData)].(views.DataFrame)
+		keys = slices.Sorted(maps.Keys(data))
+		if res, ok := result.(*InsertResult); ok {
+			res.SignalsByInput = make(map[string]views.CreateSummary, len(keys))
+			for _, k := range keys {
+				res.SignalsByInput[k] = views.CreateSummary{}
+			}
+		}
+	case methodSaveSignals.Method:
+		inputs, _ := params[string(paramSignalsByInput)].(map[string]views.SignalSave)
+		keys = slices.Sorted(maps.Keys(inputs))
+		if res, ok := result.(*SaveSignalsResult); ok {
+			res.SignalsByInput = make(map[string]views.SaveSummary, len(keys))
+			for _, k := range keys {
+				res.SignalsByInput[k] = views.SaveSummary{}
+			}
+		}
+	case methodPublishSignals.Method:
+		items, _ := params[string(paramItemsBySignal)].(map[string]views.ItemSave)
+		keys = slices.Sorted(maps.Keys(items))
+		if res, ok := result.(*PublishSignalsResult); ok {
+			res.ItemsBySignals = make(map[string]views.SaveSummary, len(keys))
+			for _, k := range keys {
+				res.ItemsBySignals[k] = views.SaveSummary{}
+			}
+		}
+	default:
+		return h.Handler.Do(ctx, req, result)
+	}
+
+	slog.Default().LogAttrs(ctx, slog.LevelInfo, "Dry-run: skipped request",
+		slog.String("method", req.Method),
+		slog.Any("keys", keys),
+	)
+	return nil
+}
+
+// readOnlyHandler wraps a handler to reject write methods.
+type readOnlyHandler struct {
+	jsonrpc.Handler
+}
+
+func (h readOnlyHandler) Do(ctx context.Context, req jsonrpc.Request, result any) error {
+	switch req.Method {
+	case methodInsert.Method, methodSaveSignals.Method, methodPublishSignals.Method:
+		return fmt.Errorf("%w: method %s not allowed", ErrReadOnly, req.Method)
+	}
+	return h.Handler.Do(ctx, req, result)
+}
@@ -0,0 +1,989 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clarify_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/testdata"
+	"github.com/clarify/clarify-go/views"
+)
+
+// versionRPCHandler records the API version of the last request.
+type versionRPCHandler struct {
+	version *string
+}
+
+func (h versionRPCHandler) Do(ctx context.Context, req jsonrpc.Request, result any) error {
+	*h.version = req.APIVersion
+	return nil
+}
+
+func TestClientAPIVersion(t *testing.T) {
+	var version string
+	ctx := context.Background()
+	c := clarify.NewClient("integration", versionRPCHandler{&version})
+
+	test := func(do func() error, expect string) func(t *testing.T) {
+		return func(t *testing.T) {
+			version = ""
+			if err := do(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if version != expect {
+				t.Errorf("Unexpected API version:\n got: %q\nwant: %q", version, expect)
+			}
+		}
+	}
+	selectItems := func(c *clarify.Client, v string) func() error {
+		return func() error {
+			_, err := c.Clarify().SelectItems(fields.Query()).APIVersion(v).Do(ctx)
+			return err
+		}
+	}
+	evaluate := func(c *clarify.Client, v string) func() error {
+		return func() error {
+			_, err := c.Clarify().Evaluate(fields.Data()).APIVersion(v).Do(ctx)
+			return err
+		}
+	}
+
+	t.Run("default", test(selectItems(c, ""), "1.1"))
+	t.Run("request", test(selectItems(c, "1.2alpha2"), "1.2alpha2"))
+	t.Run("client", test(selectItems(c.WithAPIVersion("1.2"), ""), "1.2"))
+	t.Run("client and request", test(selectItems(c.WithAPIVersion("1.2"), "1.2alpha2"), "1.2alpha2"))
+	t.Run("client reset", test(selectItems(c.WithAPIVersion("1.2").WithAPIVersion(""), ""), "1.1"))
+	t.Run("evaluate request", test(evaluate(c, "1.2alpha2"), "1.2alpha2"))
+}
+
+func TestRequestTimeout(t *testing.T) {
+	var deadline time.Time
+	var hasDeadline bool
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		deadline, hasDeadline = ctx.Deadline()
+		return nil
+	})
+	c := clarify.NewClient("integration", h)
+	ctx := context.Background()
+
+	test := func(do func(d time.Duration) error, d time.Duration) func(t *testing.T) {
+		return func(t *testing.T) {
+			hasDeadline = false
+			start := time.Now()
+			if err := do(d); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if hasDeadline != (d > 0) {
+				t.Fatalf("Unexpected deadline:\n got: %t\nwant: %t", hasDeadline, d > 0)
+			}
+			if hasDeadline && (deadline.Before(start.Add(d)) || deadline.After(time.Now().Add(d))) {
+				t.Errorf("Unexpected deadline:\n got: %v\nwant: ~%v", deadline, start.Add(d))
+			}
+		}
+	}
+	insert := func(d time.Duration) error {
+		_, err := c.Insert(views.DataFrame{}).Timeout(d).Do(ctx)
+		return err
+	}
+	selectItems := func(d time.Duration) error {
+		_, err := c.Clarify().SelectItems(fields.Query()).Timeout(d).Do(ctx)
+		return err
+	}
+	dataFrame := func(d time.Duration) error {
+		_, err := c.Clarify().DataFrame(fields.Query(), fields.Data()).Timeout(d).Do(ctx)
+		return err
+	}
+	evaluate := func(d time.Duration) error {
+		_, err := c.Clarify().Evaluate(fields.Data()).Timeout(d).Do(ctx)
+		return err
+	}
+
+	t.Run("insert", test(insert, 5*time.Second))
+	t.Run("select items", test(selectItems, 5*time.Second))
+	t.Run("select items without timeout", test(selectItems, 0))
+	t.Run("data frame", test(dataFrame, 2*time.Minute))
+	t.Run("evaluate", test(evaluate, time.Minute))
+}
+
+// recordRPCHandler records the last request.
+type recordRPCHandler struct {
+	req *jsonrpc.Request
+}
+
+func (h recordRPCHandler) Do(ctx context.Context, req jsonrpc.Request, result any) error {
+	*h.req = req
+	return nil
+}
+
+func TestNewClientOptions(t *testing.T) {
+	var req jsonrpc.Request
+	var calls []string
+	middleware := func(name string) func(jsonrpc.Handler) jsonrpc.Handler {
+		return func(next jsonrpc.Handler) jsonrpc.Handler {
+			return testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+				calls = append(calls, name)
+				return next.Do(ctx, req, result)
+			})
+		}
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := clarify.NewClient("integration", recordRPCHandler{&req},
+		clarify.WithDefaultLimit(10),
+		clarify.WithUserAgent("my-app/1.0"),
+		clarify.WithMiddleware(middleware("a"), middleware("b")),
+		clarify.WithSelectionFormat(views.SelectionFormat{DataAsArray: true}),
+		clarify.WithClock(func() time.Time { return now }),
+	)
+	ctx := context.Background()
+
+	if _, err := c.Clarify().SelectItems(fields.Query()).Do(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	query := req.Params.(map[string]any)["query"].(fields.ResourceQuery)
+	if limit := query.GetLimit(); limit != 10 {
+		t.Errorf("Unexpected query limit:\n got: %d\nwant: 10", limit)
+	}
+	if req.UserAgent != "my-app/1.0" {
+		t.Errorf("Unexpected user agent:\n got: %q\nwant: %q", req.UserAgent, "my-app/1.0")
+	}
+	if expect := []string{"a", "b"}; !slices.Equal(calls, expect) {
+		t.Errorf("Unexpected middleware calls:\n got: %v\nwant: %v", calls, expect)
+	}
+
+	if _, err := c.Clarify().SelectItems(fields.Query().Limit(5)).Do(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	query = req.Params.(map[string]any)["query"].(fields.ResourceQuery)
+	if limit := query.GetLimit(); limit != 5 {
+		t.Errorf("Unexpected explicit query limit:\n got: %d\nwant: 5", limit)
+	}
+
+	if _, err := c.Clarify().Evaluate(fields.Data()).Do(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	format := req.Params.(map[string]any)["format"].(views.SelectionFormat)
+	if !format.DataAsArray {
+		t.Errorf("Expected default selection format to be applied")
+	}
+
+	if result := c.Now(); !result.Equal(now) {
+		t.Errorf("Unexpected Now:\n got: %v\nwant: %v", result, now)
+	}
+}
+
+func TestGetItems(t *testing.T) {
+	var calls int
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		calls++
+		b, err := json.Marshal(req.Params.(map[string]any)["query"])
+		if err != nil {
+			return err
+		}
+		var query struct {
+			Filter struct {
+				ID struct {
+					In []string `json:"$in"`
+				} `json:"id"`
+			} `json:"filter"`
+			Limit int `json:"limit"`
+		}
+		if err := json.Unmarshal(b, &query); err != nil {
+			return err
+		}
+		if l := len(query.Filter.ID.In); l > 1000 || query.Limit != l {
+			t.Errorf("Unexpected query limit %d for %d IDs", query.Limit, l)
+		}
+		var items []views.Item
+		for _, id := range query.Filter.ID.In {
+			if id != "missing" {
+				items = append(items, testdata.NewItem(testdata.ItemID(id)))
+			}
+		}
+		return json.Unmarshal(testdata.JSON(testdata.NewSelectItems(items)), result)
+	})
+	c := clarify.NewClient("integration", h)
+
+	ids := []string{"missing"}
+	for i := range 2500 {
+		ids = append(ids, fmt.Sprintf("item-%04d", i))
+	}
+	ids = append(ids, "item-0000") // duplicate
+
+	items, err := c.Clarify().GetItems(ids...).Do(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Unexpected number of requests:\n got: %d\nwant: %d", calls, 3)
+	}
+	if len(items) != 2500 {
+		t.Errorf("Unexpected number of items:\n got: %d\nwant: %d", len(items), 2500)
+	}
+	if item, ok := items["item-1234"]; !ok || item.ID != "item-1234" {
+		t.Errorf("Unexpected item for item-1234:\n got: %v\nwant: item-1234", item.ID)
+	}
+	if _, ok := items["missing"]; ok {
+		t.Errorf("Unexpected item for missing ID")
+	}
+}
+
+func TestDataFrameDoChunked(t *testing.T) {
+	gte := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	lt := gte.Add(2 * time.Hour)
+
+	var lock sync.Mutex
+	var chunks [][]string
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		switch req.Method {
+		case "clarify.selectItems":
+			var items []views.Item
+			for i := range 5 {
+				items = append(items, testdata.NewItem(testdata.ItemID(fmt.Sprintf("item-%d", i))))
+			}
+			return json.Unmarshal(testdata.JSON(testdata.NewSelectItems(items)), result)
+		case "clarify.dataFrame":
+			b, err := json.Marshal(req.Params.(map[string]any)["query"])
+			if err != nil {
+				return err
+			}
+			var query struct {
+				Filter struct {
+					ID struct {
+						In []string `json:"$in"`
+					} `json:"id"`
+				} `json:"filter"`
+			}
+			if err := json.Unmarshal(b, &query); err != nil {
+				return err
+			}
+			lock.Lock()
+			chunks = append(chunks, query.Filter.ID.In)
+			lock.Unlock()
+
+			var items []views.Item
+			for _, id := range query.Filter.ID.In {
+				items = append(items, testdata.NewItem(testdata.ItemID(id)))
+			}
+			return json.Unmarshal(testdata.JSON(testdata.NewDataFrameRollup(items, gte, lt, time.Hour)), result)
+		}
+		return fmt.Errorf("unexpected method %q", req.Method)
+	})
+	c := clarify.NewClient("integration", h)
+
+	data := fields.Data().Where(fields.TimeRange(gte, lt)).RollupDuration(time.Hour, time.Monday)
+	res, err := c.Clarify().DataFrame(fields.Query(), data).Include("item").DoChunked(context.Background(), 2, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(chunks) != 3 {
+		t.Errorf("Unexpected number of data frame requests:\n got: %d\nwant: %d", len(chunks), 3)
+	}
+	for _, chunk := range chunks {
+		if len(chunk) > 2 {
+			t.Errorf("Unexpected chunk size:\n got: %d\nwant: <= 2", len(chunk))
+		}
+	}
+	if l := len(res.Included.Items); l != 5 {
+		t.Errorf("Unexpected number of included items:\n got: %d\nwant: %d", l, 5)
+	}
+	if _, ok := res.Data["item-4_avg"]; !ok {
+		t.Errorf("Expected series item-4_avg in result")
+	}
+}
+
+func TestDataFrameAggregates(t *testing.T) {
+	var req jsonrpc.Request
+	h := testutil.HandlerFunc(func(ctx context.Context, r jsonrpc.Request, result any) error {
+		switch r.Method {
+		case "clarify.selectItems":
+			items := []views.Item{
+				testdata.NewItem(testdata.ItemID("a")),
+				testdata.NewItem(testdata.ItemID("b")),
+			}
+			return json.Unmarshal(testdata.JSON(testdata.NewSelectItems(items)), result)
+		case "clarify.dataFrame":
+			req = r
+			return json.Unmarshal([]byte(`{"meta":{},"data":{"times":[],"series":{}},"included":{}}`), result)
+		}
+		return fmt.Errorf("unexpected method %q", r.Method)
+	})
+	c := clarify.NewClient("integration", h)
+	ctx := context.Background()
+	rollup := fields.Data().RollupWindow()
+
+	_, err := c.Clarify().DataFrame(fields.Query(), rollup).
+		Aggregates(fields.TimeAggregationAvg, fields.TimeAggregationMax).
+		Do(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	b, err := json.Marshal(req.Params.(map[string]any)["data"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data struct {
+		Filter struct {
+			Series struct {
+				In []string `json:"$in"`
+			} `json:"series"`
+		} `json:"filter"`
+	}
+	if err := json.Unmarshal(b, &data); err != nil {
+		t.Fatal(err)
+	}
+	if in, expect := data.Filter.Series.In, []string{"a_avg", "a_max", "b_avg", "b_max"}; !slices.Equal(in, expect) {
+		t.Errorf("Unexpected series filter:\n got: %v\nwant: %v", in, expect)
+	}
+
+	_, err = c.Clarify().DataFrame(fields.Query(), rollup).
+		Aggregates(fields.TimeAggregationSeconds).
+		Do(ctx)
+	if !errors.Is(err, clarify.ErrBadRequest) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, clarify.ErrBadRequest)
+	}
+
+	// Raw data queries have no aggregate series.
+	_, err = c.Clarify().DataFrame(fields.Query(), fields.Data()).
+		Aggregates(fields.TimeAggregationAvg).
+		Do(ctx)
+	if !errors.Is(err, clarify.ErrBadRequest) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, clarify.ErrBadRequest)
+	}
+}
+
+func TestDataFrameExplain(t *testing.T) {
+	h := testutil.HandlerFunc(func(ctx context.Context, r jsonrpc.Request, result any) error {
+		if r.Method != "clarify.selectItems" {
+			return fmt.Errorf("unexpected method %q", r.Method)
+		}
+		items := []views.Item{
+			testdata.NewItem(testdata.ItemID("a"), testdata.ItemSampleInterval(time.Minute)),
+			testdata.NewItem(testdata.ItemID("b"), testdata.ItemSampleInterval(10*time.Second)),
+		}
+		return json.Unmarshal(testdata.JSON(testdata.NewSelectItems(items)), result)
+	})
+	c := clarify.NewClient("integration", h)
+	gte := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	test := func(data fields.DataQuery, aggregates []fields.TimeAggregation, expectSeries, expectPerSeries, expectSamples, expectUnknown int) func(t *testing.T) {
+		return func(t *testing.T) {
+			e, err := c.Clarify().DataFrame(fields.Query(), data).Aggregates(aggregates...).Explain(context.Background())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if e.Items != 2 || e.TotalItems != 2 {
+				t.Errorf("Unexpected item counts:\n got: %d/%d\nwant: 2/2", e.Items, e.TotalItems)
+			}
+			if e.SeriesPerItem != expectSeries || e.SamplesPerSeries != expectPerSeries || e.Samples != expectSamples {
+				t.Errorf("Unexpected estimate:\n got: series=%d perSeries=%d samples=%d\nwant: series=%d perSeries=%d samples=%d",
+					e.SeriesPerItem, e.SamplesPerSeries, e.Samples, expectSeries, expectPerSeries, expectSamples)
+			}
+			if len(e.Unknown) != expectUnknown {
+				t.Errorf("Unexpected Unknown:\n got: %v\nwant: %d reasons", e.Unknown, expectUnknown)
+			}
+			if expectSamples > 0 && e.PayloadSize <= 0 {
+				t.Errorf("Unexpected PayloadSize: %d", e.PayloadSize)
+			}
+		}
+	}
+
+	t.Run("Raw", test(fields.Data().Where(fields.TimeRange(gte, gte.Add(time.Hour))), nil, 1, 360, 420, 0))
+	t.Run("RawLast", test(fields.Data().Where(fields.TimeRange(gte, gte.Add(time.Hour))).Last(100), nil, 1, 100, 160, 0))
+	t.Run("Rollup", test(fields.Data().Where(fields.TimeRange(gte, gte.Add(24*time.Hour))).RollupDuration(time.Hour, time.Monday), nil, 5, 25, 250, 0))
+	t.Run("RollupAggregates", test(fields.Data().Where(fields.TimeRange(gte, gte.Add(24*time.Hour))).RollupDuration(time.Hour, time.Monday), []fields.TimeAggregation{fields.TimeAggregationAvg}, 1, 25, 50, 0))
+	t.Run("Window", test(fields.Data().Where(fields.Since(gte)).RollupWindow(), nil, 5, 1, 10, 1))
+	t.Run("Unbounded", test(fields.Data(), nil, 1, 0, 0, 1))
+
+	e, _ := c.Clarify().DataFrame(fields.Query(), fields.Data().Where(fields.TimeRange(gte, gte.Add(time.Hour)))).Explain(context.Background())
+	if pages, perPage := e.Pages(1); pages != 2 || perPage != 360 {
+		t.Errorf("Unexpected pages:\n got: %d, %d\nwant: 2, 360", pages, perPage)
+	}
+}
+
+func TestDataFrameDoLastBefore(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ts := fields.AsTimestamp(t0)
+	source := views.DataFrame{
+		"a": {},
+		"b": {ts.Add(-3 * time.Hour): 1, ts.Add(-4 * time.Hour): 2},
+		"c": {},
+	}
+	for i := range 10 {
+		source["a"][ts.Add(-time.Duration(i)*time.Minute)] = float64(i)
+	}
+
+	var dataRequests int
+	h := testutil.HandlerFunc(func(ctx context.Context, r jsonrpc.Request, result any) error {
+		switch r.Method {
+		case "clarify.selectItems":
+			items := []views.Item{
+				testdata.NewItem(testdata.ItemID("a")),
+				testdata.NewItem(testdata.ItemID("b")),
+				testdata.NewItem(testdata.ItemID("c")),
+			}
+			return json.Unmarshal(testdata.JSON(testdata.NewSelectItems(items)), result)
+		case "clarify.dataFrame":
+			dataRequests++
+			dq := r.Params.(map[string]any)["data"].(fields.DataQuery)
+			gte, lt := dq.GetTimeRange()
+			var q struct {
+				Filter struct {
+					Series struct {
+						In []string `json:"$in"`
+					} `json:"series"`
+				} `json:"filter"`
+			}
+			b, _ := json.Marshal(dq)
+			_ = json.Unmarshal(b, &q)
+
+			df := views.DataFrame{}
+			var included []views.Item
+			for _, k := range q.Filter.Series.In {
+				included = append(included, testdata.NewItem(testdata.ItemID(k)))
+				var times []fields.Timestamp
+				for t := range source[k] {
+					if (gte.IsZero() || !t.Time().Before(gte)) && t.Time().Before(lt) {
+						times = append(times, t)
+					}
+				}
+				slices.Sort(times)
+				if n := dq.GetLast(); n > 0 && len(times) > n {
+					times = times[len(times)-n:]
+				}
+				for _, t := range times {
+					if df[k] == nil {
+						df[k] = views.DataSeries{}
+					}
+					df[k][t] = source[k][t]
+				}
+			}
+			res := result.(*clarify.DataFrameResult)
+			res.Data = df
+			res.Included.Items = included
+			return nil
+		}
+		return fmt.Errorf("unexpected method %q", r.Method)
+	})
+	c := clarify.NewClient("integration", h)
+
+	result, err := c.Clarify().DataFrame(fields.Query(), fields.Data()).
+		DoLastBefore(context.Background(), 3, t0, time.Hour, 5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect := views.DataFrame{
+		"a": {ts.Add(-time.Minute): 1, ts.Add(-2 * time.Minute): 2, ts.Add(-3 * time.Minute): 3},
+		"b": {ts.Add(-3 * time.Hour): 1, ts.Add(-4 * time.Hour): 2},
+	}
+	if !reflect.DeepEqual(result.Data, expect) {
+		t.Errorf("Unexpected data:\n got: %v\nwant: %v", result.Data, expect)
+	}
+	if dataRequests != 5 {
+		t.Errorf("Unexpected number of data requests:\n got: %d\nwant: %d", dataRequests, 5)
+	}
+	var includedIDs []string
+	for _, item := range result.Included.Items {
+		includedIDs = append(includedIDs, item.ID)
+	}
+	if expect := []string{"a", "b", "c"}; !slices.Equal(includedIDs, expect) {
+		t.Errorf("Unexpected included items:\n got: %v\nwant: %v", includedIDs, expect)
+	}
+
+	_, err = c.Clarify().DataFrame(fields.Query(), fields.Data().RollupWindow()).
+		DoLastBefore(context.Background(), 1, t0, time.Hour, 1)
+	if !errors.Is(err, clarify.ErrBadRequest) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, clarify.ErrBadRequest)
+	}
+}
+
+func TestLatestValues(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ts := fields.AsTimestamp(t0)
+
+	var got fields.DataQuery
+	h := testutil.HandlerFunc(func(ctx context.Context, r jsonrpc.Request, result any) error {
+		got = r.Params.(map[string]any)["data"].(fields.DataQuery)
+		res := result.(*clarify.DataFrameResult)
+		res.Data = views.DataFrame{
+			"a": {ts: 1, ts.Add(-time.Minute): 2},
+			"b": {ts.Add(-time.Hour): math.NaN()},
+		}
+		return nil
+	})
+	c := clarify.NewClient("integration", h)
+
+	values, err := c.Clarify().LatestValues(fields.Query()).Before(t0).Do(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect := map[string]views.Sample{
+		"a": {Time: ts, Value: 1},
+	}
+	if !reflect.DeepEqual(values, expect) {
+		t.Errorf("Unexpected values:\n got: %v\nwant: %v", values, expect)
+	}
+	if expect := fields.Data().LastBefore(1, t0); !reflect.DeepEqual(got, expect) {
+		t.Errorf("Unexpected data query:\n got: %v\nwant: %v", got, expect)
+	}
+}
+
+func TestDataFrameDoTimeRanges(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	var lock sync.Mutex
+	var ranges []string
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		gte, lt := req.Params.(map[string]any)["data"].(fields.DataQuery).GetTimeRange()
+		lock.Lock()
+		ranges = append(ranges, gte.Format("02")+"-"+lt.Format("02"))
+		lock.Unlock()
+		return json.Unmarshal([]byte(fmt.Sprintf(`{"meta":{},"data":{"times":[%q],"series":{"a":[1]}},"included":{}}`, gte.Format(time.RFC3339))), result)
+	})
+	c := clarify.NewClient("integration", h)
+
+	res, err := c.Clarify().DataFrame(fields.Query(), fields.Data()).DoTimeRanges(context.Background(), 2,
+		fields.TimeRange(day(1), day(2)),
+		fields.TimeRange(day(8), day(9)),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	slices.Sort(ranges)
+	if expect := []string{"01-02", "08-09"}; !slices.Equal(ranges, expect) {
+		t.Errorf("Unexpected requested ranges:\n got: %v\nwant: %v", ranges, expect)
+	}
+	if n := len(res.Data["a"]); n != 2 {
+		t.Errorf("Unexpected number of merged values:\n got: %d\nwant: %d", n, 2)
+	}
+}
+
+func TestClientDryRun(t *testing.T) {
+	var methods []string
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		methods = append(methods, req.Method)
+		return nil
+	})
+	c := clarify.NewClient("integration", h, clarify.WithDryRun(true))
+	ctx := context.Background()
+
+	insertResult, err := c.Insert(views.DataFrame{"a": {}, "b": {}}).Do(ctx)
+	if err != nil {
+		t.Fatalf("Insert: unexpected error: %v", err)
+	}
+	if l := len(insertResult.SignalsByInput); l != 2 {
+		t.Errorf("Insert: unexpected number of results:\n got: %d\nwant: %d", l, 2)
+	}
+
+	saveResult, err := c.SaveSignals(map[string]views.SignalSave{"a": {}}).Do(ctx)
+	if err != nil {
+		t.Fatalf("SaveSignals: unexpected error: %v", err)
+	}
+	if l := len(saveResult.SignalsByInput); l != 1 {
+		t.Errorf("SaveSignals: unexpected number of results:\n got: %d\nwant: %d", l, 1)
+	}
+
+	publishResult, err := c.Admin().PublishSignals("integration", map[string]views.ItemSave{"s1": {}}).Do(ctx)
+	if err != nil {
+		t.Fatalf("PublishSignals: unexpected error: %v", err)
+	}
+	if s, ok := publishResult.ItemsBySignals["s1"]; !ok || s.Created || s.Updated {
+		t.Errorf("PublishSignals: unexpected result:\n got: %+v\nwant: map[s1:{ID: Created:false Updated:false}]", publishResult.ItemsBySignals)
+	}
+
+	if _, err := c.Clarify().SelectItems(fields.Query()).Do(ctx); err != nil {
+		t.Fatalf("SelectItems: unexpected error: %v", err)
+	}
+	if expect := []string{"clarify.selectItems"}; !slices.Equal(methods, expect) {
+		t.Errorf("Unexpected requests sent:\n got: %v\nwant: %v", methods, expect)
+	}
+}
+
+func TestClientReadOnly(t *testing.T) {
+	var methods []string
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		methods = append(methods, req.Method)
+		return nil
+	})
+	c := clarify.NewClient("integration", h, clarify.WithReadOnly(true), clarify.WithDryRun(true))
+	ctx := context.Background()
+
+	if _, err := c.Insert(views.DataFrame{"a": {}}).Do(ctx); !errors.Is(err, clarify.ErrReadOnly) {
+		t.Errorf("Insert: unexpected error:\n got: %v\nwant: %v", err, clarify.ErrReadOnly)
+	}
+	if _, err := c.SaveSignals(map[string]views.SignalSave{"a": {}}).Do(ctx); !errors.Is(err, clarify.ErrReadOnly) {
+		t.Errorf("SaveSignals: unexpected error:\n got: %v\nwant: %v", err, clarify.ErrReadOnly)
+	}
+	if _, err := c.Admin().PublishSignals("integration", map[string]views.ItemSave{"s1": {}}).Do(ctx); !errors.Is(err, clarify.ErrReadOnly) {
+		t.Errorf("PublishSignals: unexpected error:\n got: %v\nwant: %v", err, clarify.ErrReadOnly)
+	}
+
+	if _, err := c.Clarify().SelectItems(fields.Query()).Do(ctx); err != nil {
+		t.Fatalf("SelectItems: unexpected error: %v", err)
+	}
+	if expect := []string{"clarify.selectItems"}; !slices.Equal(methods, expect) {
+		t.Errorf("Unexpected requests sent:\n got: %v\nwant: %v", methods, expect)
+	}
+}
+
+func TestClientSignalValidation(t *testing.T) {
+	var calls int
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		calls++
+		return nil
+	})
+	ctx := context.Background()
+	inputs := map[string]views.SignalSave{
+		"bad key": {SignalSaveAttributes: views.SignalSaveAttributes{ValueType: "string"}},
+	}
+
+	c := clarify.NewClient("integration", h)
+	if _, err := c.SaveSignals(inputs).Do(ctx); err != nil {
+		t.Fatalf("Unexpected error without validation: %v", err)
+	}
+
+	c = clarify.NewClient("integration", h, clarify.WithSignalValidation(true))
+	_, err := c.SaveSignals(inputs).Do(ctx)
+	var pathErrs clarify.PathErrors
+	if !errors.Is(err, clarify.ErrBadRequest) || !errors.As(err, &pathErrs) {
+		t.Fatalf("Unexpected error with validation:\n got: %v\nwant: %v", err, clarify.ErrBadRequest)
+	}
+	paths := slices.Sorted(maps.Keys(pathErrs))
+	if expect := []string{"signalsByInput.bad key", "signalsByInput.bad key.valueType"}; !slices.Equal(paths, expect) {
+		t.Errorf("Unexpected issue paths:\n got: %v\nwant: %v", paths, expect)
+	}
+	if calls != 1 {
+		t.Errorf("Unexpected number of requests:\n got: %d\nwant: %d", calls, 1)
+	}
+}
+
+func TestClientPayloadLimit(t *testing.T) {
+	const maxSize = 300
+	t0 := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	data := views.DataFrame{}
+	for _, k := range []string{"a", "b", "c"} {
+		data[k] = views.DataSeries{}
+		for i := range 10 {
+			data[k][t0.Add(time.Duration(i)*time.Second)] = float64(i)
+		}
+	}
+
+	var sizes []int
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		size, _ := clarify.EstimatePayloadSize(req.Params)
+		sizes = append(sizes, size)
+		res := result.(*clarify.InsertResult)
+		res.SignalsByInput = make(map[string]views.CreateSummary)
+		for k := range req.Params.(map[string]any)["data"].(views.DataFrame) {
+			res.SignalsByInput[k] = views.CreateSummary{}
+		}
+		return nil
+	})
+	ctx := context.Background()
+
+	t.Run("Chunk", func(t *testing.T) {
+		sizes = nil
+		c := clarify.NewClient("integration", h, clarify.WithPayloadLimit(maxSize, clarify.PayloadChunk))
+		result, err := c.Insert(data).Do(ctx)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(sizes) < 2 {
+			t.Errorf("Expected multiple requests, got: %d", len(sizes))
+		}
+		for _, size := range sizes {
+			if size > maxSize {
+				t.Errorf("Unexpected request size:\n got: %d\nwant: <= %d", size, maxSize)
+			}
+		}
+		if l := len(result.SignalsByInput); l != 3 {
+			t.Errorf("Unexpected number of results:\n got: %d\nwant: %d", l, 3)
+		}
+	})
+	t.Run("ChunkByTime", func(t *testing.T) {
+		// Only the request containing the first sample reports the signal as
+		// created.
+		created := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+			res := result.(*clarify.InsertResult)
+			res.SignalsByInput = make(map[string]views.CreateSummary)
+			for k, s := range req.Params.(map[string]any)["data"].(views.DataFrame) {
+				_, first := s[t0]
+				res.SignalsByInput[k] = views.CreateSummary{ID: "id-" + k, Created: first}
+			}
+			return nil
+		})
+		c := clarify.NewClient("integration", created, clarify.WithPayloadLimit(maxSize, clarify.PayloadChunk))
+		result, err := c.Insert(views.DataFrame{"a": data["a"]}).Do(ctx)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expect := map[string]views.CreateSummary{"a": {ID: "id-a", Created: true}}
+		if !reflect.DeepEqual(result.SignalsByInput, expect) {
+			t.Errorf("Unexpected result:\n got: %v\nwant: %v", result.SignalsByInput, expect)
+		}
+	})
+	t.Run("Warn", func(t *testing.T) {
+		sizes = nil
+		c := clarify.NewClient("integration", h, clarify.WithPayloadLimit(maxSize, clarify.PayloadWarn))
+		if _, err := c.Insert(data).Do(ctx); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(sizes) != 1 {
+			t.Errorf("Unexpected number of requests:\n got: %d\nwant: %d", len(sizes), 1)
+		}
+	})
+	t.Run("PartialFailure", func(t *testing.T) {
+		failB := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+			if _, ok := req.Params.(map[string]any)["data"].(views.DataFrame)["b"]; ok {
+				return errors.New("failed")
+			}
+			return h.Do(ctx, req, result)
+		})
+		c := clarify.NewClient("integration", failB, clarify.WithPayloadLimit(maxSize, clarify.PayloadChunk))
+		_, err := c.Insert(data).Do(ctx)
+		var batchErr *clarify.BatchError
+		if !errors.As(err, &batchErr) {
+			t.Fatalf("Unexpected error:\n got: %v\nwant: *clarify.BatchError", err)
+		}
+		if !batchErr.Partial() || batchErr.AllFailed() {
+			t.Errorf("Expected partial failure, got: %v", batchErr)
+		}
+		if keys, expect := batchErr.FailedKeys(), []string{"b"}; !slices.Equal(keys, expect) {
+			t.Errorf("Unexpected failed keys:\n got: %v\nwant: %v", keys, expect)
+		}
+		partial, ok := batchErr.Result.(*clarify.InsertResult)
+		if !ok {
+			t.Fatalf("Unexpected partial result type:\n got: %T\nwant: %T", batchErr.Result, partial)
+		}
+		if keys, expect := slices.Sorted(maps.Keys(partial.SignalsByInput)), []string{"a", "c"}; !slices.Equal(keys, expect) {
+			t.Errorf("Unexpected partial result keys:\n got: %v\nwant: %v", keys, expect)
+		}
+	})
+	t.Run("TooLarge", func(t *testing.T) {
+		c := clarify.NewClient("integration", h, clarify.WithPayloadLimit(10, clarify.PayloadChunk))
+		if _, err := c.Insert(data).Do(ctx); !errors.Is(err, clarify.ErrPayloadTooLarge) {
+			t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, clarify.ErrPayloadTooLarge)
+		}
+	})
+}
+
+func TestClientTimestampValidation(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ts := fields.AsTimestamp(now)
+	data := views.DataFrame{
+		"a": {ts.Add(-48 * time.Hour): 1, ts: 2, ts.Add(time.Hour): 3, ts.Add(2 * time.Hour): 4},
+		"b": {ts: 5},
+		"c": {ts.Add(time.Minute): 6, ts.Add(time.Hour): 7},
+	}
+
+	test := func(action clarify.TimestampAction, expectData views.DataFrame, expectDropped map[string]int) func(t *testing.T) {
+		return func(t *testing.T) {
+			var sent views.DataFrame
+			h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+				sent = req.Params.(map[string]any)["data"].(views.DataFrame)
+				return nil
+			})
+			c := clarify.NewClient("integration", h,
+				clarify.WithClock(func() time.Time { return now }),
+				clarify.WithTimestampValidation(clarify.TimestampValidation{
+					MaxSkew: time.Minute,
+					MaxAge:  24 * time.Hour,
+					Action:  action,
+				}),
+			)
+			result, err := c.Insert(data).Do(context.Background())
+			if expectData == nil {
+				if !errors.Is(err, clarify.ErrBadTimestamp) || !errors.Is(err, clarify.ErrBadRequest) {
+					t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, clarify.ErrBadTimestamp)
+				}
+				if sent != nil {
+					t.Errorf("Unexpected request sent")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(sent, expectData) {
+				t.Errorf("Unexpected data sent:\n got: %v\nwant: %v", sent, expectData)
+			}
+			if !maps.Equal(result.DroppedByInput, expectDropped) {
+				t.Errorf("Unexpected DroppedByInput:\n got: %v\nwant: %v", result.DroppedByInput, expectDropped)
+			}
+		}
+	}
+
+	t.Run("Reject", test(clarify.TimestampReject, nil, nil))
+	t.Run("Drop", test(clarify.TimestampDrop,
+		views.DataFrame{"a": {ts: 2}, "b": {ts: 5}, "c": {ts.Add(time.Minute): 6}},
+		map[string]int{"a": 3, "c": 1},
+	))
+	t.Run("Clamp", test(clarify.TimestampClamp,
+		// A sample at the latest accepted time is not overwritten.
+		views.DataFrame{"a": {ts: 2, ts.Add(time.Minute): 4}, "b": {ts: 5}, "c": {ts.Add(time.Minute): 6}},
+		map[string]int{"a": 2, "c": 1},
+	))
+}
+
+func TestClientTimestampValidationError(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ts := fields.AsTimestamp(now)
+	data := views.DataFrame{
+		"a": {ts: 1, ts.Add(time.Hour): 2},
+	}
+
+	// Middleware observes the result even when the request fails.
+	var result *clarify.InsertResult
+	observe := func(next jsonrpc.Handler) jsonrpc.Handler {
+		return testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, res any) error {
+			err := next.Do(ctx, req, res)
+			result, _ = res.(*clarify.InsertResult)
+			return err
+		})
+	}
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		return clarify.HTTPError{StatusCode: 503}
+	})
+	c := clarify.NewClient("integration", h,
+		clarify.WithClock(func() time.Time { return now }),
+		clarify.WithTimestampValidation(clarify.TimestampValidation{
+			MaxSkew: time.Minute,
+			Action:  clarify.TimestampDrop,
+		}),
+		clarify.WithMiddleware(observe),
+	)
+	if _, err := c.Insert(data).Do(context.Background()); err == nil {
+		t.Fatalf("Expected error")
+	}
+	if result == nil {
+		t.Fatalf("Expected an insert result")
+	}
+	if expect := map[string]int{"a": 1}; !maps.Equal(result.DroppedByInput, expect) {
+		t.Errorf("Unexpected DroppedByInput:\n got: %v\nwant: %v", result.DroppedByInput, expect)
+	}
+}
+
+func TestSelectFormat(t *testing.T) {
+	var req jsonrpc.Request
+	h := testutil.HandlerFunc(func(ctx context.Context, r jsonrpc.Request, result any) error {
+		req = r
+		return json.Unmarshal([]byte(`{"meta":{},"data":[],"included":{}}`), result)
+	})
+	c := clarify.NewClient("integration", h)
+	ctx := context.Background()
+
+	if _, err := c.Clarify().SelectItems(fields.Query()).Do(ctx); err != nil {
+		t.Fatalf("SelectItems: unexpected error: %v", err)
+	}
+	if f, expect := req.Params.(map[string]any)["format"], views.DefaultSelectionFormat(); f != expect {
+		t.Errorf("SelectItems: unexpected format:\n got: %+v\nwant: %+v", f, expect)
+	}
+
+	format := views.DefaultSelectionFormat().WithDataAsArray(false).WithGroupIncludedByType(false)
+	raw, err := c.Admin().SelectSignals("integration", fields.Query()).Format(format).DoRaw(ctx)
+	if err != nil {
+		t.Fatalf("SelectSignals: unexpected error: %v", err)
+	}
+	if f := req.Params.(map[string]any)["format"]; f != format {
+		t.Errorf("SelectSignals: unexpected format:\n got: %+v\nwant: %+v", f, format)
+	}
+	if expect := `{"meta":{},"data":[],"included":{}}`; string(raw) != expect {
+		t.Errorf("SelectSignals: unexpected raw result:\n got: %s\nwant: %s", raw, expect)
+	}
+}
+
+func TestEvaluateSeriesInLast(t *testing.T) {
+	var req jsonrpc.Request
+	c := clarify.NewClient("integration", recordRPCHandler{&req})
+
+	_, err := c.Clarify().Evaluate(fields.Data()).
+		SeriesIn("a", "b").
+		SeriesIn("b", "c").
+		Last(1).
+		Do(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	b, err := json.Marshal(req.Params.(map[string]any)["data"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data struct {
+		Filter struct {
+			Series struct {
+				In []string `json:"$in"`
+			} `json:"series"`
+		} `json:"filter"`
+		Last int `json:"last"`
+	}
+	if err := json.Unmarshal(b, &data); err != nil {
+		t.Fatal(err)
+	}
+	if in := data.Filter.Series.In; !slices.Equal(in, []string{"b"}) {
+		t.Errorf("Unexpected series filter:\n got: %v\nwant: [b]", in)
+	}
+	if data.Last != 1 {
+		t.Errorf("Unexpected last:\n got: %d\nwant: 1", data.Last)
+	}
+}
+
+func TestAdminUsage(t *testing.T) {
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		params := req.Params.(map[string]any)
+		switch req.Method {
+		case "admin.selectIntegrations":
+			return json.Unmarshal([]byte(`{"meta":{"total":-1},"data":[
+				{"type":"integrations","id":"a"},
+				{"type":"integrations","id":"b"}
+			],"included":{}}`), result)
+		case "admin.selectSignals":
+			total := map[string]int{"a": 3, "b": 4}[params["integration"].(string)]
+			return json.Unmarshal([]byte(fmt.Sprintf(`{"meta":{"total":%d},"data":[],"included":{}}`, total)), result)
+		case "clarify.selectItems":
+			return json.Unmarshal([]byte(`{"meta":{"total":5},"data":[],"included":{}}`), result)
+		}
+		return fmt.Errorf("unexpected method %q", req.Method)
+	})
+	c := clarify.NewClient("integration", h)
+
+	result, err := c.Admin().Usage().Do(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect := clarify.UsageResult{
+		Integrations:         2,
+		Signals:              7,
+		SignalsByIntegration: map[string]int{"a": 3, "b": 4},
+		Items:                5,
+	}
+	if !reflect.DeepEqual(*result, expect) {
+		t.Errorf("Unexpected result:\n got: %+v\nwant: %+v", *result, expect)
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/clarify/clarify-go/jsonrpc"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 )
 
@@ -134,8 +135,8 @@ func (creds *Credentials) Validate() error {
 // Client returns a new Clarify client for the current credentials, assuming the
 // client credentials to be valid. If the credentials are invalid, this method
 // will return a non-functional client where all requests result return the
-// ErrBadCredentials error.
-func (creds Credentials) Client(ctx context.Context) *Client {
+// ErrBadCredentials error. Options are passed on to NewClient.
+func (creds Credentials) Client(ctx context.Context, opts ...ClientOption) *Client {
 	var h jsonrpc.Handler
 
 	h, err := creds.HTTPHandler(ctx)
@@ -143,12 +144,22 @@ func (creds Credentials) Client(ctx context.Context) *Client {
 		h = invalidRPCHandler{err: err}
 	}
 
-	return &Client{ns: IntegrationNamespace{integration: creds.Integration, h: h}}
+	return NewClient(creds.Integration, h, opts...)
 }
 
 // HTTPHandler returns a low-level RPC handler that communicates over HTTP using
 // the credentials in creds.
 func (creds Credentials) HTTPHandler(ctx context.Context) (*jsonrpc.HTTPHandler, error) {
+	return creds.HTTPHandlerWithTransport(ctx, nil)
+}
+
+// HTTPHandlerWithTransport is like HTTPHandler, but uses base as the
+// underlying HTTP transport, e.g. as returned by jsonrpc.TransportConfig. If
+// base is nil, http.DefaultTransport is used.
+func (creds Credentials) HTTPHandlerWithTransport(ctx context.Context, base http.RoundTripper) (*jsonrpc.HTTPHandler, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
 	if err := creds.Validate(); err != nil {
 		return nil, err
 	}
@@ -158,7 +169,7 @@ func (creds Credentials) HTTPHandler(ctx context.Context) (*jsonrpc.HTTPHandler,
 	switch creds.Credentials.Type {
 	case TypeBasicAuth:
 		c.Transport = basicAuthTransport{
-			parent: http.DefaultTransport,
+			parent: base,
 			user:   creds.Credentials.ClientID,
 			pass:   creds.Credentials.ClientSecret,
 		}
@@ -171,6 +182,7 @@ func (creds Credentials) HTTPHandler(ctx context.Context) (*jsonrpc.HTTPHandler,
 				"audience": {apiURL},
 			},
 		}
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: base})
 		c = *cfg.Client(ctx)
 	default:
 		// This code-path is impossible because creds.Validate() should have
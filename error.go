@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -16,6 +16,7 @@ package clarify
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/clarify/clarify-go/jsonrpc"
 )
@@ -42,6 +43,8 @@ type ServerError = jsonrpc.ServerError
 
 type HTTPError = jsonrpc.HTTPError
 
+type InvalidParamsError = jsonrpc.InvalidParamsError
+
 // Client errors.
 const (
 	ErrBadCredentials strError = "bad credentials"
@@ -49,6 +52,70 @@ const (
 	ErrBadRequest     strError = "bad request"
 )
 
+// BatchError is returned by operations that split work into multiple chunks,
+// when one or more of the chunks fail. Chunks that are not listed succeeded.
+type BatchError struct {
+	// Errors holds one entry per failed chunk.
+	Errors []ChunkError
+
+	// Total holds the total number of chunks.
+	Total int
+
+	// Result holds the merged result of the chunks that succeeded, using the
+	// same type as the result of the failed request, such as *InsertResult or
+	// *SaveSignalsResult. Because the request's Do method returns a nil result
+	// on error, this is the only way to access a partial result.
+	Result any
+}
+
+// ChunkError describe the failure of a single chunk in a batched operation.
+type ChunkError struct {
+	// Keys lists the input keys included in the chunk, such as series keys
+	// for Insert or input keys for SaveSignals.
+	Keys []string
+
+	// Err holds the error returned for the chunk.
+	Err error
+}
+
+func (err *BatchError) Error() string {
+	if len(err.Errors) == 0 {
+		return fmt.Sprintf("0 of %d chunks failed", err.Total)
+	}
+	return fmt.Sprintf("%d of %d chunks failed: %v", len(err.Errors), err.Total, err.Errors[0].Err)
+}
+
+// Unwrap returns the errors for all failed chunks.
+func (err *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(err.Errors))
+	for _, e := range err.Errors {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+// AllFailed returns true if all chunks failed.
+func (err *BatchError) AllFailed() bool {
+	return err.Total > 0 && len(err.Errors) >= err.Total
+}
+
+// Partial returns true if some, but not all, chunks failed.
+func (err *BatchError) Partial() bool {
+	return len(err.Errors) > 0 && len(err.Errors) < err.Total
+}
+
+// FailedKeys returns the sorted set of input keys included in failed chunks.
+// Note that when an input is split over multiple chunks, as for large data
+// series, some of the input's data may still have been written.
+func (err *BatchError) FailedKeys() []string {
+	var keys []string
+	for _, e := range err.Errors {
+		keys = append(keys, e.Keys...)
+	}
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
 type strError string
 
 func (err strError) Error() string { return string(err) }
@@ -1,4 +1,4 @@
-// Copyright 2022-2024 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -22,11 +22,13 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"maps"
 	"math/rand"
 	"os"
 	"slices"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	clarify "github.com/clarify/clarify-go"
@@ -105,6 +107,28 @@ func (p program) EncodeJSON(data any) error {
 	return nil
 }
 
+// tableRow describes a single resource in the table output format.
+type tableRow struct {
+	id, name  string
+	labels    fields.Labels
+	updatedAt time.Time
+}
+
+// EncodeTable writes rows as an aligned table with the ID, name, labels and
+// last updated time of each resource.
+func (p program) EncodeTable(rows []tableRow) error {
+	w := tabwriter.NewWriter(p.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tLABELS\tUPDATED")
+	for _, r := range rows {
+		pairs := make([]string, 0, len(r.labels))
+		for _, k := range slices.Sorted(maps.Keys(r.labels)) {
+			pairs = append(pairs, k+"="+strings.Join(r.labels[k], "|"))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.id, r.name, strings.Join(pairs, " "), r.updatedAt.Format(time.RFC3339))
+	}
+	return w.Flush()
+}
+
 func (p *program) init(ctx context.Context) {
 	creds, err := clarify.CredentialsFromFile(p.credentialsFile)
 	if err != nil {
@@ -329,6 +353,7 @@ type selectSignalsConfig struct {
 	integration, filter string
 	includeItem         bool
 	sort                []string
+	output              string
 }
 
 func (p *program) selectSignalsCommand() *ffcli.Command {
@@ -341,6 +366,8 @@ func (p *program) selectSignalsCommand() *ffcli.Command {
 	fs.StringVar(&config.filter, "filter", "", "Resource filter (JSON).")
 	fs.Var(stringSlice{target: &config.sort}, "sort", "Comma-separated list of fields to sort the result by.")
 	fs.BoolVar(&config.includeItem, "include-item", false, "Include related items.")
+	config.output = outputJSON
+	fs.Var(outputFlag{target: &config.output}, "output", "Output format; json or table.")
 
 	return &ffcli.Command{
 		Name:       "select-signals",
@@ -379,6 +406,18 @@ func (p *program) selectSignals(ctx context.Context, config selectSignalsConfig)
 	}
 
 	log.Printf("Selection summary: len(result.data): %d, len(result.included.items): %d", len(result.Data), len(result.Included.Items))
+	if config.output == outputTable {
+		rows := make([]tableRow, 0, len(result.Data))
+		for _, signal := range result.Data {
+			rows = append(rows, tableRow{
+				id:        signal.ID,
+				name:      signal.Attributes.Name,
+				labels:    signal.Attributes.Labels,
+				updatedAt: signal.Meta.UpdatedAt,
+			})
+		}
+		return p.EncodeTable(rows)
+	}
 	return p.EncodeJSON(result)
 }
 
@@ -508,6 +547,7 @@ type selectItemsConfig struct {
 	skip, limit int
 	filter      string
 	sort        []string
+	output      string
 }
 
 func (p *program) selectItemsCommand() *ffcli.Command {
@@ -518,6 +558,8 @@ func (p *program) selectItemsCommand() *ffcli.Command {
 	fs.IntVar(&config.limit, "n", 50, "Maximum number of items to return.")
 	fs.StringVar(&config.filter, "filter", "", "Resource filter (JSON).")
 	fs.Var(stringSlice{target: &config.sort}, "sort", "Comma-separated list of fields to sort the result by.")
+	config.output = outputJSON
+	fs.Var(outputFlag{target: &config.output}, "output", "Output format; json or table.")
 
 	return &ffcli.Command{
 		Name:       "select-items",
@@ -546,6 +588,18 @@ func (p *program) selectItems(ctx context.Context, config selectItemsConfig) err
 	}
 
 	log.Printf("Selection summary: len(result.data): %d", len(result.Data))
+	if config.output == outputTable {
+		rows := make([]tableRow, 0, len(result.Data))
+		for _, item := range result.Data {
+			rows = append(rows, tableRow{
+				id:        item.ID,
+				name:      item.Attributes.Name,
+				labels:    item.Attributes.Labels,
+				updatedAt: item.Meta.UpdatedAt,
+			})
+		}
+		return p.EncodeTable(rows)
+	}
 	return p.EncodeJSON(result)
 }
 
@@ -643,24 +697,14 @@ func (p *program) dataFrame(ctx context.Context, config dataFrameConfig) error {
 		return err
 	}
 
-	keys := make([]string, 0, len(result.Data))
-	for k := range result.Data {
-		keys = append(keys, k)
-	}
-	slices.Sort(keys)
-	var i, samples int
-	for _, k := range keys {
-		data := result.Data[k]
-		samples += len(data)
+	var samples int
+	for _, entry := range views.JoinItems(result.Data, result.Included.Items) {
+		samples += len(entry.Series)
 
-		if config.includeItems {
-			id, _, _ := strings.Cut(k, "_")
-			for id != result.Included.Items[i].ID {
-				i++
-			}
-			log.Printf("len(result.data['%s']): %d, name: %s\n", k, len(data), result.Included.Items[i].Attributes.Name)
+		if entry.Item != nil {
+			log.Printf("len(result.data['%s']): %d, name: %s\n", entry.Key, len(entry.Series), entry.Item.Attributes.Name)
 		} else {
-			log.Printf("len(result.data['%s']): %d\n", k, len(data))
+			log.Printf("len(result.data['%s']): %d\n", entry.Key, len(entry.Series))
 		}
 	}
 
@@ -2,6 +2,7 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"strings"
 	"time"
 )
@@ -55,6 +56,35 @@ func (tf stringSlice) Set(v string) error {
 	return nil
 }
 
+// Supported output formats.
+const (
+	outputJSON  = "json"
+	outputTable = "table"
+)
+
+// outputFlag is a flag.Value that allows selecting an output format.
+type outputFlag struct {
+	target *string
+}
+
+var _ flag.Value = outputFlag{}
+
+func (of outputFlag) String() string {
+	if of.target == nil {
+		return ""
+	}
+	return *of.target
+}
+
+func (of outputFlag) Set(v string) error {
+	switch v {
+	case outputJSON, outputTable:
+		*of.target = v
+		return nil
+	}
+	return fmt.Errorf("must be %q or %q", outputJSON, outputTable)
+}
+
 // nilWriter is no-operation writer.
 type nilWriter struct{}
 
@@ -0,0 +1,157 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clarify
+
+import (
+	"context"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+)
+
+// Approximate encoded sizes used by DataFrameRequest.Explain.
+const (
+	explainTimeSize   = 24 // RFC 3339 timestamp in the times array.
+	explainValueSize  = 10 // Numeric value in a series array.
+	explainSeriesSize = 48 // Series key and array overhead.
+)
+
+// rollupAggregates is the number of series per numeric item returned for
+// rollup queries; count, min, max, sum and avg.
+const rollupAggregates = 5
+
+// DataFrameExplain describe a client-side estimate of the cost of a
+// DataFrameRequest. See DataFrameRequest.Explain.
+type DataFrameExplain struct {
+	// Items holds the number of items that Do retrieves data for, taking the
+	// limit and skip of the items query into account.
+	Items int
+
+	// TotalItems holds the number of items matching the items query filter,
+	// which is the number of items that DoChunked retrieves data for.
+	TotalItems int
+
+	// SeriesPerItem holds the number of series per numeric item. State
+	// series for enum items are not accounted for.
+	SeriesPerItem int
+
+	// SamplesPerSeries holds the estimated maximum number of samples per
+	// series. For rollups, this is the number of buckets in the time range.
+	// For raw data, it's calculated from the sample interval of the items.
+	SamplesPerSeries int
+
+	// Samples holds the estimated total number of samples returned by Do.
+	Samples int
+
+	// PayloadSize holds the estimated encoded size in bytes of the data
+	// returned by Do.
+	PayloadSize int
+
+	// Unknown lists reasons why the estimate is incomplete, such as an
+	// unbounded time range, or items without a sample interval. When set,
+	// the actual cost may be higher than estimated.
+	Unknown []string
+}
+
+// Pages returns the number of requests DoChunked performs for the given chunk
+// size, and the estimated maximum number of samples per request. If size < 1,
+// the DoChunked default is used.
+func (e DataFrameExplain) Pages(size int) (pages, samplesPerPage int) {
+	if size < 1 {
+		size = defaultDataFrameChunkSize
+	}
+	pages = (e.TotalItems + size - 1) / size
+	return pages, min(size, e.TotalItems) * e.SeriesPerItem * e.SamplesPerSeries
+}
+
+// Explain returns an estimate of the number of samples and payload size the
+// request would return, without requesting any data. This allows
+// sanity-checking heavy queries before they are performed. The estimate is
+// calculated client-side, using a single clarify.selectItems request to look
+// up matching items and their sample intervals.
+func (req DataFrameRequest) Explain(ctx context.Context) (*DataFrameExplain, error) {
+	if err := req.checkAggregates(); err != nil {
+		return nil, err
+	}
+	res, err := req.selectItems(req.items.Total(true)).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	e := DataFrameExplain{
+		Items:         len(res.Data),
+		TotalItems:    max(res.Meta.Total, len(res.Data)),
+		SeriesPerItem: 1,
+	}
+	gte, lt := req.data.GetTimeRange()
+	bounded := !gte.IsZero() && !lt.IsZero()
+	if !bounded {
+		e.Unknown = append(e.Unknown, "unbounded time range")
+	}
+	last := req.data.GetLast()
+
+	if bucket, ok := req.data.GetRollup(); ok {
+		e.SeriesPerItem = rollupAggregates
+		if len(req.aggregates) > 0 {
+			e.SeriesPerItem = len(req.aggregates)
+		}
+		switch {
+		case bucket.IsZero():
+			e.SamplesPerSeries = 1
+		case bounded:
+			e.SamplesPerSeries = countBuckets(gte, lt, bucket)
+		}
+		if last > 0 && (e.SamplesPerSeries == 0 || last < e.SamplesPerSeries) {
+			e.SamplesPerSeries = last
+		}
+		e.Samples = e.Items * e.SeriesPerItem * e.SamplesPerSeries
+	} else {
+		var missing bool
+		for _, item := range res.Data {
+			n := last
+			if interval := item.Attributes.SampleInterval.Duration; interval > 0 && bounded {
+				n = int(lt.Sub(gte) / interval)
+				if last > 0 {
+					n = min(n, last)
+				}
+			} else if last == 0 {
+				missing = true
+			}
+			e.Samples += n
+			e.SamplesPerSeries = max(e.SamplesPerSeries, n)
+		}
+		if missing && bounded {
+			e.Unknown = append(e.Unknown, "items without sample interval")
+		}
+	}
+
+	e.PayloadSize = e.SamplesPerSeries*explainTimeSize +
+		e.Samples*explainValueSize +
+		e.Items*e.SeriesPerItem*explainSeriesSize
+	return &e, nil
+}
+
+// countBuckets returns the number of rollup buckets that overlap with the
+// time range [gte,lt), assuming worst case alignment.
+func countBuckets(gte, lt time.Time, bucket fields.CalendarDuration) int {
+	if d := bucket.Duration(); d > 0 && bucket.Months() == 0 {
+		return int((lt.Sub(gte)+d-1)/d) + 1
+	}
+	n := 1
+	for t := gte; t.Before(lt); t = bucket.AddToTime(t) {
+		n++
+	}
+	return n
+}
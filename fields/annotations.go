@@ -0,0 +1,94 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fields
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// AnnotationKeyPrefix is the prefix used for annotation keys that are owned by
+// this SDK. Applications should use their own prefix to avoid collisions.
+const AnnotationKeyPrefix = "clarify/clarify-go/"
+
+// AnnotationValue describe the types supported by GetAnnotation and
+// SetAnnotation.
+type AnnotationValue interface {
+	string | bool | int | int64 | float64 | time.Time | time.Duration
+}
+
+// GetAnnotation returns the value for key in a, parsed as T. If the key is not
+// set, found is false. If the value can not be parsed, an error wrapping
+// ErrBadAnnotation is returned.
+//
+// Values are expected on the format written by SetAnnotation. Times must be
+// RFC 3339 timestamps, and durations must be RFC 3339 durations.
+func GetAnnotation[T AnnotationValue](a Annotations, key string) (v T, found bool, err error) {
+	s, found := a[key]
+	if !found {
+		return v, false, nil
+	}
+
+	var parsed any
+	switch any(v).(type) {
+	case string:
+		parsed = s
+	case bool:
+		parsed, err = strconv.ParseBool(s)
+	case int:
+		parsed, err = strconv.Atoi(s)
+	case int64:
+		parsed, err = strconv.ParseInt(s, 10, 64)
+	case float64:
+		parsed, err = strconv.ParseFloat(s, 64)
+	case time.Time:
+		parsed, err = time.Parse(time.RFC3339Nano, s)
+	case time.Duration:
+		var d FixedDurationNullZero
+		d, err = ParseFixedDuration(s)
+		parsed = d.Duration
+	}
+	if err != nil {
+		return v, true, fmt.Errorf("%w %q: %v", ErrBadAnnotation, key, err)
+	}
+	return parsed.(T), true, nil
+}
+
+// SetAnnotation sets key in a to the canonical string encoding of v. Times are
+// encoded as RFC 3339 timestamps in UTC, and durations as RFC 3339 durations.
+func SetAnnotation[T AnnotationValue](a *Annotations, key string, v T) {
+	var s string
+	switch v := any(v).(type) {
+	case string:
+		s = v
+	case bool:
+		s = strconv.FormatBool(v)
+	case int:
+		s = strconv.Itoa(v)
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case float64:
+		s = strconv.FormatFloat(v, 'g', -1, 64)
+	case time.Time:
+		s = v.UTC().Format(time.RFC3339Nano)
+	case time.Duration:
+		s = formatFixedDuration(v)
+		if v == 0 {
+			s = "PT0S"
+		}
+	}
+	a.Set(key, s)
+}
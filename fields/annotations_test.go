@@ -0,0 +1,65 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fields_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+)
+
+func testAnnotationRoundTrip[T fields.AnnotationValue](v T, expectRaw string) func(t *testing.T) {
+	return func(t *testing.T) {
+		var a fields.Annotations
+		fields.SetAnnotation(&a, "key", v)
+		if raw := a.Get("key"); raw != expectRaw {
+			t.Errorf("Unexpected raw value:\n got: %q\nwant: %q", raw, expectRaw)
+		}
+		result, found, err := fields.GetAnnotation[T](a, "key")
+		if err != nil || !found {
+			t.Fatalf("GetAnnotation: unexpected result:\n got: found=%v, err=%v\nwant: found=true, err=<nil>", found, err)
+		}
+		if any(result) != any(v) {
+			t.Errorf("Unexpected value:\n got: %v\nwant: %v", result, v)
+		}
+	}
+}
+
+func TestAnnotationRoundTrip(t *testing.T) {
+	t.Run("string", testAnnotationRoundTrip("a b", "a b"))
+	t.Run("bool", testAnnotationRoundTrip(true, "true"))
+	t.Run("int", testAnnotationRoundTrip(-42, "-42"))
+	t.Run("int64", testAnnotationRoundTrip(int64(1)<<40, "1099511627776"))
+	t.Run("float64", testAnnotationRoundTrip(0.25, "0.25"))
+	t.Run("time", testAnnotationRoundTrip(time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC), "2024-01-02T03:04:05.000000006Z"))
+	t.Run("duration", testAnnotationRoundTrip(90*time.Minute, "PT1H30M"))
+	t.Run("zero-duration", testAnnotationRoundTrip(time.Duration(0), "PT0S"))
+}
+
+func TestGetAnnotation(t *testing.T) {
+	a := fields.Annotations{"bad": "yes"}
+
+	v, found, err := fields.GetAnnotation[int](a, "missing")
+	if v != 0 || found || err != nil {
+		t.Errorf("Unexpected result for missing key:\n got: %v, %v, %v\nwant: 0, false, <nil>", v, found, err)
+	}
+
+	_, found, err = fields.GetAnnotation[bool](a, "bad")
+	if !found || !errors.Is(err, fields.ErrBadAnnotation) {
+		t.Errorf("Unexpected result for bad value:\n got: %v, %v\nwant: true, %v", found, err, fields.ErrBadAnnotation)
+	}
+}
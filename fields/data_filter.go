@@ -1,4 +1,4 @@
-// Copyright 2023 Searis AS
+// Copyright 2023-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -39,6 +39,26 @@ func (q *DataFilter) UnmarshalJSON(data []byte) error {
 	return json.Unmarshal(data, &q.filter)
 }
 
+// GetTimeRange returns the time range [gte,lt) of the filter. Zero values are
+// returned for unbounded ends.
+func (q DataFilter) GetTimeRange() (gte, lt time.Time) {
+	return q.filter.Times.GreaterOrEqual, q.filter.Times.Less
+}
+
+// GetSeriesIn returns a copy of the series keys the filter is reduced to, and
+// true if a series filter is set.
+func (q DataFilter) GetSeriesIn() ([]string, bool) {
+	if q.filter.Series.In == nil {
+		return nil, false
+	}
+	return slices.Clone(q.filter.Series.In), true
+}
+
+// Equal returns true if q and other are encoded to the same JSON value.
+func (q DataFilter) Equal(other DataFilter) bool {
+	return jsonEqual(q, other)
+}
+
 // DataAnd joins one or more data filters with logical and.
 func DataAnd(filters ...DataFilter) DataFilter {
 	var result DataFilter
@@ -94,6 +114,22 @@ type timesFilter struct {
 	Less           time.Time `json:"$lt,omitempty"`
 }
 
+// MarshalJSON omits zero times, which represent unbounded ends of the time
+// range.
+func (f timesFilter) MarshalJSON() ([]byte, error) {
+	var target struct {
+		GreaterOrEqual *time.Time `json:"$gte,omitempty"`
+		Less           *time.Time `json:"$lt,omitempty"`
+	}
+	if !f.GreaterOrEqual.IsZero() {
+		target.GreaterOrEqual = &f.GreaterOrEqual
+	}
+	if !f.Less.IsZero() {
+		target.Less = &f.Less
+	}
+	return json.Marshal(target)
+}
+
 // TimeRange return a TimesFilter that matches times in range [gte,lt).
 //
 // Be aware of API limits according to how large time ranges you can query with
@@ -113,6 +149,20 @@ func TimeRange(gte, lt time.Time) DataFilter {
 	}
 }
 
+// Since returns a DataFilter that matches times >= gte, with no upper bound.
+// Be aware that the API may still limit how large time ranges that can be
+// queried; see TimeRange.
+func Since(gte time.Time) DataFilter {
+	return TimeRange(gte, time.Time{})
+}
+
+// Until returns a DataFilter that matches times < lt, with no lower bound.
+// Be aware that the API may still limit how large time ranges that can be
+// queried; see TimeRange.
+func Until(lt time.Time) DataFilter {
+	return TimeRange(time.Time{}, lt)
+}
+
 type seriesFilter struct {
 	In []string `json:"$in,omitempty"`
 }
@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -129,3 +129,166 @@ func (dq DataQuery) Last(n int) DataQuery {
 	dq.query.Last = n
 	return dq
 }
+
+// LastBefore returns a new data query where only the last n non-empty
+// data-points per series before t are included, such as to get the latest
+// value as of time t. The query is equivalent to dq.Where(Until(t)).Last(n),
+// and any existing lower time bound of dq is kept. As the API may limit the
+// size of unbounded time ranges, consider setting a lower bound, or use
+// clarify.DataFrameRequest.DoLastBefore to search backwards in time windows.
+func (dq DataQuery) LastBefore(n int, t time.Time) DataQuery {
+	return dq.Where(Until(t)).Last(n)
+}
+
+// GetTimeRange returns the time range [gte,lt) of the data query filter. Zero
+// values are returned for unbounded ends.
+func (dq DataQuery) GetTimeRange() (gte, lt time.Time) {
+	return dq.query.Filter.GetTimeRange()
+}
+
+// GetFilter returns the data filter of the data query.
+func (dq DataQuery) GetFilter() DataFilter {
+	return dq.query.Filter
+}
+
+// GetRollup returns the rollup bucket of the data query, and true if a rollup
+// is set. For window rollups, a zero bucket is returned.
+func (dq DataQuery) GetRollup() (bucket CalendarDuration, ok bool) {
+	switch dq.query.Rollup {
+	case "":
+		return CalendarDuration{}, false
+	case "window":
+		return CalendarDuration{}, true
+	}
+	bucket, err := ParseCalendarDuration(dq.query.Rollup)
+	return bucket, err == nil
+}
+
+// GetLast returns the value set via Last, or 0 if no limit is applied.
+func (dq DataQuery) GetLast() int {
+	return dq.query.Last
+}
+
+// GetOrigin returns the custom rollup bucket origin, and true if an origin is
+// set.
+func (dq DataQuery) GetOrigin() (time.Time, bool) {
+	if dq.query.Origin == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, dq.query.Origin)
+	return t, err == nil
+}
+
+// GetFirstDayOfWeek returns the first day of the week used for aligning fixed
+// duration rollups, and true if it's set.
+func (dq DataQuery) GetFirstDayOfWeek() (time.Weekday, bool) {
+	if dq.query.FirstDayOfWeek == 0 {
+		return 0, false
+	}
+	return time.Weekday(dq.query.FirstDayOfWeek % 7), true
+}
+
+// GetTimeZone returns the time-zone name of the data query, or an empty string
+// if the default time-zone (UTC) is used.
+func (dq DataQuery) GetTimeZone() string {
+	return dq.query.TimeZone
+}
+
+// Equal returns true if dq and other are encoded to the same JSON value. This
+// allows comparing queries in tests and middleware.
+func (dq DataQuery) Equal(other DataQuery) bool {
+	return jsonEqual(dq, other)
+}
+
+// ForTimeRanges returns one data query per filter in ranges, where each range
+// is combined with the filter of dq using DataAnd. As the API does not support
+// matching multiple time ranges in a single request, this can be used to fetch
+// multiple time windows with one request per window. Time ranges that do not
+// overlap with the time range of dq are omitted.
+func (dq DataQuery) ForTimeRanges(ranges ...DataFilter) []DataQuery {
+	result := make([]DataQuery, 0, len(ranges))
+	for _, r := range ranges {
+		q := dq.Where(r)
+		gte, lt := q.GetTimeRange()
+		if !gte.IsZero() && !lt.IsZero() && !gte.Before(lt) {
+			continue
+		}
+		result = append(result, q)
+	}
+	return result
+}
+
+// SplitTimeRange returns a list of data queries that together cover the time
+// range of dq, where each query spans at most window. For fixed duration
+// rollups, window is rounded up to a multiple of the rollup duration, and split
+// points are aligned to rollup bucket boundaries. For time-zones with daylight
+// saving time adjustments, bucket alignment is only guaranteed for rollup
+// durations below the adjustment offset.
+//
+// If the query does not have a bounded time range, uses a window or month
+// rollup, specifies a Last value, or if window is <= 0, a list containing only
+// dq is returned.
+func (dq DataQuery) SplitTimeRange(window time.Duration) []DataQuery {
+	gte, lt := dq.GetTimeRange()
+	q := dq.query
+	switch {
+	case window <= 0, gte.IsZero(), lt.IsZero(), !gte.Before(lt), q.Last > 0:
+		return []DataQuery{dq}
+	}
+
+	start := gte
+	if q.Rollup != "" {
+		bucket, ok := parseWeekToFraction(q.Rollup)
+		if !ok || bucket <= 0 {
+			// Window or month rollup.
+			return []DataQuery{dq}
+		}
+		if r := window % bucket; r != 0 {
+			window += bucket - r
+		}
+		origin, err := dq.origin()
+		if err != nil {
+			return []DataQuery{dq}
+		}
+		r := gte.Sub(origin) % bucket
+		if r < 0 {
+			r += bucket
+		}
+		start = gte.Add(-r)
+	}
+
+	var result []DataQuery
+	for t := start; t.Before(lt); t = t.Add(window) {
+		wGTE, wLT := t, t.Add(window)
+		if wGTE.Before(gte) {
+			wGTE = gte
+		}
+		if wLT.After(lt) {
+			wLT = lt
+		}
+		result = append(result, dq.Where(TimeRange(wGTE, wLT)))
+	}
+	return result
+}
+
+// origin returns the rollup bucket origin for the data query.
+func (dq DataQuery) origin() (time.Time, error) {
+	if dq.query.Origin != "" {
+		return time.Parse(time.RFC3339Nano, dq.query.Origin)
+	}
+	loc := time.UTC
+	if tz := dq.query.TimeZone; tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return time.Time{}, err
+		}
+	}
+	isoDay := dq.query.FirstDayOfWeek
+	if isoDay == 0 {
+		isoDay = 1
+	}
+	// Find the first date in 2000 where the weekday matches; 2000-01-01 is a
+	// Saturday (ISO weekday 6).
+	day := 1 + (isoDay-6+7)%7
+	return time.Date(2000, 1, day, 0, 0, 0, 0, loc), nil
+}
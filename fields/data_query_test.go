@@ -0,0 +1,196 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fields_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+)
+
+func TestDataQuerySplitTimeRange(t *testing.T) {
+	type window struct {
+		gte, lt string
+	}
+	test := func(dq fields.DataQuery, window time.Duration, expect []window) func(t *testing.T) {
+		return func(t *testing.T) {
+			t.Helper()
+
+			result := dq.SplitTimeRange(window)
+			if len(result) != len(expect) {
+				t.Fatalf("Unexpected number of windows:\n got: %d\nwant: %d", len(result), len(expect))
+			}
+			for i, e := range expect {
+				gte, lt := result[i].GetTimeRange()
+				if r := gte.Format(time.RFC3339); r != e.gte {
+					t.Errorf("Unexpected gte for window %d:\n got: %s\nwant: %s", i, r, e.gte)
+				}
+				if r := lt.Format(time.RFC3339); r != e.lt {
+					t.Errorf("Unexpected lt for window %d:\n got: %s\nwant: %s", i, r, e.lt)
+				}
+			}
+		}
+	}
+
+	gte := time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)
+	lt := time.Date(2024, 1, 1, 5, 0, 0, 0, time.UTC)
+	data := fields.Data().Where(fields.TimeRange(gte, lt))
+
+	t.Run("raw", test(data, 2*time.Hour, []window{
+		{"2024-01-01T00:30:00Z", "2024-01-01T02:30:00Z"},
+		{"2024-01-01T02:30:00Z", "2024-01-01T04:30:00Z"},
+		{"2024-01-01T04:30:00Z", "2024-01-01T05:00:00Z"},
+	}))
+	t.Run("rollup 1h", test(data.RollupDuration(time.Hour, time.Monday), 90*time.Minute, []window{
+		{"2024-01-01T00:30:00Z", "2024-01-01T02:00:00Z"},
+		{"2024-01-01T02:00:00Z", "2024-01-01T04:00:00Z"},
+		{"2024-01-01T04:00:00Z", "2024-01-01T05:00:00Z"},
+	}))
+	t.Run("rollup window", test(data.RollupWindow(), time.Hour, []window{
+		{"2024-01-01T00:30:00Z", "2024-01-01T05:00:00Z"},
+	}))
+	t.Run("last", test(data.Last(1), time.Hour, []window{
+		{"2024-01-01T00:30:00Z", "2024-01-01T05:00:00Z"},
+	}))
+}
+
+func TestDataQueryHalfOpenTimeRange(t *testing.T) {
+	test := func(filter fields.DataFilter, expect string) func(t *testing.T) {
+		return func(t *testing.T) {
+			t.Helper()
+			b, err := json.Marshal(filter)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(b) != expect {
+				t.Errorf("Unexpected JSON:\n got: %s\nwant: %s", b, expect)
+			}
+		}
+	}
+
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t.Run("Since", test(fields.Since(ts), `{"times":{"$gte":"2024-01-01T00:00:00Z"},"series":{}}`))
+	t.Run("Until", test(fields.Until(ts), `{"times":{"$lt":"2024-01-01T00:00:00Z"},"series":{}}`))
+	t.Run("DataAnd", test(
+		fields.DataAnd(fields.Since(ts), fields.Until(ts.Add(time.Hour))),
+		`{"times":{"$gte":"2024-01-01T00:00:00Z","$lt":"2024-01-01T01:00:00Z"},"series":{}}`,
+	))
+}
+
+func TestDataQueryForTimeRanges(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	data := fields.Data().Where(fields.Since(day(2)))
+
+	result := data.ForTimeRanges(
+		fields.TimeRange(day(1), day(2)), // omitted
+		fields.TimeRange(day(1), day(3)),
+		fields.TimeRange(day(5), day(6)),
+	)
+	expect := [][2]time.Time{
+		{day(2), day(3)},
+		{day(5), day(6)},
+	}
+	if len(result) != len(expect) {
+		t.Fatalf("Unexpected number of queries:\n got: %d\nwant: %d", len(result), len(expect))
+	}
+	for i, e := range expect {
+		gte, lt := result[i].GetTimeRange()
+		if !gte.Equal(e[0]) || !lt.Equal(e[1]) {
+			t.Errorf("Unexpected time range for query %d:\n got: [%v,%v)\nwant: [%v,%v)", i, gte, lt, e[0], e[1])
+		}
+	}
+}
+
+func TestDataQueryGetRollup(t *testing.T) {
+	test := func(dq fields.DataQuery, expect fields.CalendarDuration, expectOK bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			bucket, ok := dq.GetRollup()
+			if bucket != expect || ok != expectOK {
+				t.Errorf("Unexpected result:\n got: %v, %t\nwant: %v, %t", bucket, ok, expect, expectOK)
+			}
+		}
+	}
+	t.Run("None", test(fields.Data(), fields.CalendarDuration{}, false))
+	t.Run("Window", test(fields.Data().RollupWindow(), fields.CalendarDuration{}, true))
+	t.Run("Duration", test(fields.Data().RollupDuration(time.Hour, time.Monday), fields.FixedCalendarDuration(time.Hour), true))
+	t.Run("Months", test(fields.Data().RollupMonths(3), fields.MonthDuration(3), true))
+}
+
+func TestDataQueryLastBefore(t *testing.T) {
+	gte := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	lt := gte.Add(24 * time.Hour)
+	dq := fields.Data().Where(fields.Since(gte)).LastBefore(1, lt)
+	b, err := json.Marshal(dq)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect := `{"filter":{"times":{"$gte":"2024-01-01T00:00:00Z","$lt":"2024-01-02T00:00:00Z"},"series":{}},"last":1}`
+	if got := string(b); got != expect {
+		t.Errorf("Unexpected JSON:\n got: %s\nwant: %s", got, expect)
+	}
+}
+
+func TestDataQueryGetters(t *testing.T) {
+	gte := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	lt := gte.Add(24 * time.Hour)
+	origin := gte.Add(30 * time.Minute)
+	dq := fields.Data().
+		Where(fields.TimeRange(gte, lt)).
+		Where(fields.SeriesIn("a", "b")).
+		RollupDuration(time.Hour, time.Sunday).
+		Origin(origin).
+		TimeZone("Europe/Oslo")
+
+	if rGte, rLt := dq.GetFilter().GetTimeRange(); !rGte.Equal(gte) || !rLt.Equal(lt) {
+		t.Errorf("Unexpected time range:\n got: [%v,%v)\nwant: [%v,%v)", rGte, rLt, gte, lt)
+	}
+	if keys, ok := dq.GetFilter().GetSeriesIn(); !ok || len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+		t.Errorf("Unexpected series filter:\n got: %v (%t)\nwant: [a b] (true)", keys, ok)
+	}
+	if bucket, ok := dq.GetRollup(); !ok || bucket.Duration() != time.Hour {
+		t.Errorf("Unexpected rollup:\n got: %v (%t)\nwant: 1h (true)", bucket.Duration(), ok)
+	}
+	if day, ok := dq.GetFirstDayOfWeek(); !ok || day != time.Sunday {
+		t.Errorf("Unexpected first day of week:\n got: %v (%t)\nwant: Sunday (true)", day, ok)
+	}
+	if o, ok := dq.GetOrigin(); !ok || !o.Equal(origin) {
+		t.Errorf("Unexpected origin:\n got: %v (%t)\nwant: %v (true)", o, ok, origin)
+	}
+	if tz := dq.GetTimeZone(); tz != "Europe/Oslo" {
+		t.Errorf("Unexpected time zone:\n got: %q\nwant: %q", tz, "Europe/Oslo")
+	}
+	if _, ok := fields.Data().GetFilter().GetSeriesIn(); ok {
+		t.Errorf("Expected no series filter for empty query")
+	}
+
+	var decoded fields.DataQuery
+	b, err := json.Marshal(dq)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !decoded.Equal(dq) {
+		t.Errorf("Expected decoded query to equal original:\n got: %s", b)
+	}
+	if dq.Equal(dq.Last(1)) {
+		t.Errorf("Expected queries with different last values to differ")
+	}
+}
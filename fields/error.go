@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -19,6 +19,25 @@ const (
 	ErrBadCalendarDuration   strError = "must be RFC 3339 duration in range year to fraction"
 	ErrMixedCalendarDuration strError = "can not combine month or year components with day or time components"
 	ErrBadFixedDuration      strError = "must be RFC 3339 duration in range week to fraction"
+	ErrBadFilter             strError = "bad filter expression"
+)
+
+// Comparison errors.
+const (
+	ErrNotSimpleJSON  strError = "does not marshal to simple JSON type (string, number, bool or null)"
+	ErrNotOrderedJSON strError = "does not marshal to sortable JSON type (string or number)"
+)
+
+// Formula errors.
+const (
+	ErrBadFormulaParameter    strError = "bad formula parameter"
+	ErrUnboundPlaceholder     strError = "unbound formula placeholder"
+	ErrUnusedFormulaParameter strError = "unused formula parameter"
+)
+
+// Annotation errors.
+const (
+	ErrBadAnnotation strError = "bad annotation value"
 )
 
 type strError string
@@ -0,0 +1,137 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fields
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+var (
+	rePlaceholder = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+	reIdentifier  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// FormulaTemplate describe a calculation formula with named placeholders on
+// the format "{name}". Use Formula to initialize a template, and Bind to set
+// parameter values. Values are formatted and validated before they are
+// inserted, which avoids formatting bugs and injection of arbitrary formula
+// syntax.
+type FormulaTemplate struct {
+	template string
+	params   map[string]string
+	err      error
+}
+
+// Formula returns a new formula template. Example usage:
+//
+//	calc, err := fields.Formula("fire_rate > {threshold}").
+//		Bind("threshold", 0.2).
+//		Calculation("alert")
+func Formula(template string) FormulaTemplate {
+	return FormulaTemplate{template: template}
+}
+
+// Bind returns a new template where the named placeholder is bound to v.
+// Accepted values are finite numbers, and strings that are valid identifiers,
+// such as references to other calculation or item aliases. Errors are
+// reported when the formula is rendered.
+func (f FormulaTemplate) Bind(name string, v any) FormulaTemplate {
+	params := make(map[string]string, len(f.params)+1)
+	maps.Copy(params, f.params)
+	f.params = params
+
+	s, err := formatParameter(v)
+	if err != nil && f.err == nil {
+		f.err = fmt.Errorf("%w %q: %v", ErrBadFormulaParameter, name, err)
+	}
+	f.params[name] = s
+	return f
+}
+
+// Render returns the rendered formula, or an error if the template can not be
+// rendered. All placeholders must be bound, and all bound parameters must be
+// used.
+func (f FormulaTemplate) Render() (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+
+	used := make(map[string]bool, len(f.params))
+	var unbound []string
+	result := rePlaceholder.ReplaceAllStringFunc(f.template, func(m string) string {
+		name := m[1 : len(m)-1]
+		v, ok := f.params[name]
+		if !ok {
+			unbound = append(unbound, name)
+			return m
+		}
+		used[name] = true
+		return v
+	})
+	if len(unbound) > 0 {
+		return "", fmt.Errorf("%w: %q", ErrUnboundPlaceholder, unbound)
+	}
+	for _, name := range slices.Sorted(maps.Keys(f.params)) {
+		if !used[name] {
+			return "", fmt.Errorf("%w: %q", ErrUnusedFormulaParameter, name)
+		}
+	}
+	return result, nil
+}
+
+// Calculation returns a calculation with the passed in alias and the rendered
+// formula.
+func (f FormulaTemplate) Calculation(alias string) (Calculation, error) {
+	formula, err := f.Render()
+	if err != nil {
+		return Calculation{}, err
+	}
+	return Calculation{Alias: alias, Formula: formula}, nil
+}
+
+func formatParameter(v any) (string, error) {
+	var s string
+	switch v := v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		s = fmt.Sprintf("%d", v)
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return "", fmt.Errorf("number must be finite")
+		}
+		s = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return "", fmt.Errorf("number must be finite")
+		}
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		if !reIdentifier.MatchString(v) {
+			return "", fmt.Errorf("string %q is not a valid identifier", v)
+		}
+		return v, nil
+	default:
+		return "", fmt.Errorf("unsupported type %T", v)
+	}
+	if s[0] == '-' {
+		// Avoid changing the meaning of e.g. "a - {x}".
+		s = "(" + s + ")"
+	}
+	return s, nil
+}
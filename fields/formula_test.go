@@ -0,0 +1,51 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fields_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/clarify/clarify-go/fields"
+)
+
+func TestFormula(t *testing.T) {
+	test := func(f fields.FormulaTemplate, expect string, expectErr error) func(t *testing.T) {
+		return func(t *testing.T) {
+			t.Helper()
+			calc, err := f.Calculation("result")
+			if !errors.Is(err, expectErr) {
+				t.Fatalf("Unexpected error:\n got: %v\nwant: %v", err, expectErr)
+			}
+			if calc.Formula != expect {
+				t.Errorf("Unexpected formula:\n got: %q\nwant: %q", calc.Formula, expect)
+			}
+		}
+	}
+
+	base := fields.Formula("fire_rate > {threshold}")
+	t.Run("float", test(base.Bind("threshold", 0.2), "fire_rate > 0.2", nil))
+	t.Run("float32", test(base.Bind("threshold", float32(0.2)), "fire_rate > 0.2", nil))
+	t.Run("large", test(base.Bind("threshold", 1e21), "fire_rate > 1000000000000000000000", nil))
+	t.Run("negative", test(base.Bind("threshold", -3), "fire_rate > (-3)", nil))
+	t.Run("rebind", test(base.Bind("threshold", 1).Bind("threshold", 2), "fire_rate > 2", nil))
+	t.Run("identifier", test(fields.Formula("{a} + {b}").Bind("a", "x1").Bind("b", "x2"), "x1 + x2", nil))
+	t.Run("unbound", test(base, "", fields.ErrUnboundPlaceholder))
+	t.Run("unused", test(base.Bind("threshold", 1).Bind("other", 2), "", fields.ErrUnusedFormulaParameter))
+	t.Run("NaN", test(base.Bind("threshold", math.NaN()), "", fields.ErrBadFormulaParameter))
+	t.Run("injection", test(base.Bind("threshold", "1 || true"), "", fields.ErrBadFormulaParameter))
+	t.Run("type", test(base.Bind("threshold", true), "", fields.ErrBadFormulaParameter))
+}
@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -39,6 +39,11 @@ func (e EnumValues) MarshalJSON() ([]byte, error) {
 
 type Annotations map[string]string
 
+// Clone returns a clone of the annotations structure.
+func (m Annotations) Clone() Annotations {
+	return maps.Clone(m)
+}
+
 // Get returns the value for the given key or an empty string.
 func (m Annotations) Get(key string) string {
 	if m == nil {
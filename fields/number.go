@@ -1,4 +1,4 @@
-// Copyright 2022 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -52,3 +52,80 @@ func (f *Number) UnmarshalJSON(data []byte) error {
 	}
 	return json.Unmarshal(data, (*float64)(f))
 }
+
+// NullNumber is an optional float64 value that distinguish a missing value
+// (Valid is false) from a valid "not-a-number" value. A missing value is JSON
+// encoded as null. Unlike Number, valid NaN and infinity values are JSON
+// encoded as the strings "NaN", "+Inf" and "-Inf", and can therefore be
+// round-tripped by custom tooling. Note that the Clarify API does not accept
+// these encodings for data.
+type NullNumber struct {
+	Value float64
+	Valid bool
+}
+
+var (
+	_ json.Unmarshaler = (*NullNumber)(nil)
+	_ json.Marshaler   = NullNumber{}
+)
+
+// NumberOf returns a valid NullNumber for f.
+func NumberOf(f float64) NullNumber {
+	return NullNumber{Value: f, Valid: true}
+}
+
+// Number converts n to a Number, where a missing value is converted to NaN.
+func (n NullNumber) Number() Number {
+	if !n.Valid {
+		return Number(math.NaN())
+	}
+	return Number(n.Value)
+}
+
+// Int64 returns the value as an integer, and true if the value is valid and
+// holds an integer that can be exactly represented as a float64.
+func (n NullNumber) Int64() (int64, bool) {
+	const maxExact = 1 << 53
+	if !n.Valid || n.Value != math.Trunc(n.Value) || math.Abs(n.Value) > maxExact {
+		return 0, false
+	}
+	return int64(n.Value), true
+}
+
+func (n NullNumber) MarshalJSON() ([]byte, error) {
+	switch {
+	case !n.Valid:
+		return []byte(`null`), nil
+	case math.IsNaN(n.Value):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(n.Value, 1):
+		return []byte(`"+Inf"`), nil
+	case math.IsInf(n.Value, -1):
+		return []byte(`"-Inf"`), nil
+	}
+	return json.Marshal(n.Value)
+}
+
+func (n *NullNumber) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch string(data) {
+	case `null`:
+		*n = NullNumber{}
+		return nil
+	case `"NaN"`:
+		*n = NumberOf(math.NaN())
+		return nil
+	case `"+Inf"`:
+		*n = NumberOf(math.Inf(1))
+		return nil
+	case `"-Inf"`:
+		*n = NumberOf(math.Inf(-1))
+		return nil
+	}
+	var f float64
+	if err := json.Unmarshal(data, &f); err != nil {
+		return err
+	}
+	*n = NumberOf(f)
+	return nil
+}
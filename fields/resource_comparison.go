@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -117,12 +117,47 @@ type opComparison struct {
 	Regex          string            `json:"$regex,omitempty"`
 }
 
+// validate returns an error if any of the values are of an invalid type for
+// the operator.
+func (cmp *opComparison) validate() error {
+	if cmp == nil {
+		return nil
+	}
+	for _, b := range cmp.In {
+		if !isSimpleJSON(bytes.TrimSpace(b)) {
+			return fmt.Errorf("$in: %w", ErrNotSimpleJSON)
+		}
+	}
+	for _, b := range cmp.NotIn {
+		if !isSimpleJSON(bytes.TrimSpace(b)) {
+			return fmt.Errorf("$nin: %w", ErrNotSimpleJSON)
+		}
+	}
+	for _, op := range []struct {
+		name  string
+		value json.RawMessage
+	}{
+		{"$gt", cmp.Greater},
+		{"$gte", cmp.GreaterOrEqual},
+		{"$lt", cmp.Less},
+		{"$lte", cmp.LessOrEqual},
+	} {
+		if op.value != nil && !isOrderedJSON(bytes.TrimSpace(op.value)) {
+			return fmt.Errorf("%s: %w", op.name, ErrNotOrderedJSON)
+		}
+	}
+	return nil
+}
+
 func (cmp *opComparison) normalize() *opComparison {
 	// Normalizes the following:
 	//
 	//   - opComparison{In:{"null"}} -> nil
 	//   - opComparison{} -> nil
 	//
+	if cmp == nil {
+		return nil
+	}
 	isEmptyExceptIn := (cmp.NotIn == nil &&
 		cmp.Greater == nil &&
 		cmp.GreaterOrEqual == nil &&
@@ -142,90 +177,194 @@ func (cmp *opComparison) normalize() *opComparison {
 }
 
 // Equal returns a comparison that match values equal to v. Panics if v is not
-// JSON marshalled into a simple JSON type (string, number, bool or null).
+// JSON marshalled into a simple JSON type (string, number, bool or null). See
+// TryEqual for a variant that returns an error instead.
 func Equal(v any) Comparison {
-	return Comparison{
-		value: (&opComparison{In: []json.RawMessage{simpleJSON(v)}}).normalize(),
+	return mustComparison(TryEqual(v))
+}
+
+// TryEqual is like Equal, but returns an error instead of panicking.
+func TryEqual(v any) (Comparison, error) {
+	b, err := simpleJSON(v)
+	if err != nil {
+		return Comparison{}, err
 	}
+	return Comparison{
+		value: (&opComparison{In: []json.RawMessage{b}}).normalize(),
+	}, nil
 }
 
 // NotEqual returns a comparison that match values not equal to v. Panics if v
 // is not JSON marshalled into a simple JSON type (string, number, bool or
-// null).
+// null). See TryNotEqual for a variant that returns an error instead.
 func NotEqual(v any) Comparison {
-	return Comparison{
-		value: &opComparison{NotIn: []json.RawMessage{simpleJSON(v)}},
+	return mustComparison(TryNotEqual(v))
+}
+
+// TryNotEqual is like NotEqual, but returns an error instead of panicking.
+func TryNotEqual(v any) (Comparison, error) {
+	b, err := simpleJSON(v)
+	if err != nil {
+		return Comparison{}, err
 	}
+	return Comparison{
+		value: &opComparison{NotIn: []json.RawMessage{b}},
+	}, nil
 }
 
 // In returns a comparison that match values in elements. Panics if any element
 // is not JSON marshalled into an a simple JSON type (string, number, bool or
-// null).
+// null). See TryIn for a variant that returns an error instead.
 func In[E any](elements ...E) Comparison {
-	in := make([]json.RawMessage, 0, len(elements))
-	for _, elem := range elements {
-		in = append(in, simpleJSON(elem))
+	return mustComparison(TryIn(elements...))
+}
+
+// TryIn is like In, but returns an error instead of panicking.
+func TryIn[E any](elements ...E) (Comparison, error) {
+	in, err := simpleJSONList(elements)
+	if err != nil {
+		return Comparison{}, err
 	}
 	return Comparison{
 		value: (&opComparison{In: in}).normalize(),
-	}
+	}, nil
 }
 
 // NotIn returns a comparison that match values not in elements. Panics if any
 // element is not JSON marshalled into an a simple JSON type (string, number,
-// bool or null).
+// bool or null). See TryNotIn for a variant that returns an error instead.
 func NotIn[E any](elements ...E) Comparison {
-	nin := make([]json.RawMessage, 0, len(elements))
-	for _, elem := range elements {
-		nin = append(nin, simpleJSON(elem))
+	return mustComparison(TryNotIn(elements...))
+}
+
+// TryNotIn is like NotIn, but returns an error instead of panicking.
+func TryNotIn[E any](elements ...E) (Comparison, error) {
+	nin, err := simpleJSONList(elements)
+	if err != nil {
+		return Comparison{}, err
 	}
 	return Comparison{
 		value: &opComparison{NotIn: nin},
-	}
+	}, nil
 }
 
 // Greater returns a comparison that matches values > gte. Panics if gt is not
-// JSON marshalled into an a sortable JSON type (string or number).
+// JSON marshalled into an a sortable JSON type (string or number). See
+// TryGreater for a variant that returns an error instead.
 func Greater(gt any) Comparison {
-	return Comparison{
-		value: &opComparison{Greater: orderedJSONType(gt)},
+	return mustComparison(TryGreater(gt))
+}
+
+// TryGreater is like Greater, but returns an error instead of panicking.
+func TryGreater(gt any) (Comparison, error) {
+	b, err := orderedJSON(gt)
+	if err != nil {
+		return Comparison{}, err
 	}
+	return Comparison{
+		value: &opComparison{Greater: b},
+	}, nil
 }
 
 // GreaterOrEqual returns a comparison that matches values >= gte. Panics if gte
-// is not JSON marshalled into an a sortable JSON type (string or number).
+// is not JSON marshalled into an a sortable JSON type (string or number). See
+// TryGreaterOrEqual for a variant that returns an error instead.
 func GreaterOrEqual(gte any) Comparison {
-	return Comparison{
-		value: &opComparison{GreaterOrEqual: orderedJSONType(gte)},
+	return mustComparison(TryGreaterOrEqual(gte))
+}
+
+// TryGreaterOrEqual is like GreaterOrEqual, but returns an error instead of
+// panicking.
+func TryGreaterOrEqual(gte any) (Comparison, error) {
+	b, err := orderedJSON(gte)
+	if err != nil {
+		return Comparison{}, err
 	}
+	return Comparison{
+		value: &opComparison{GreaterOrEqual: b},
+	}, nil
 }
 
 // Less returns a comparison that matches values < lt. Panics if lt is not JSON
-// marshalled into an a sortable JSON type (string or number).
+// marshalled into an a sortable JSON type (string or number). See TryLess for a
+// variant that returns an error instead.
 func Less(lt any) Comparison {
-	return Comparison{
-		value: &opComparison{Less: orderedJSONType(lt)},
+	return mustComparison(TryLess(lt))
+}
+
+// TryLess is like Less, but returns an error instead of panicking.
+func TryLess(lt any) (Comparison, error) {
+	b, err := orderedJSON(lt)
+	if err != nil {
+		return Comparison{}, err
 	}
+	return Comparison{
+		value: &opComparison{Less: b},
+	}, nil
 }
 
 // LessOrEqual returns a comparison that matches values <= lte. Panics if lte is
-// not JSON marshalled into an a sortable JSON type (string or number).
+// not JSON marshalled into an a sortable JSON type (string or number). See
+// TryLessOrEqual for a variant that returns an error instead.
 func LessOrEqual(lte any) Comparison {
-	return Comparison{
-		value: &opComparison{LessOrEqual: orderedJSONType(lte)},
+	return mustComparison(TryLessOrEqual(lte))
+}
+
+// TryLessOrEqual is like LessOrEqual, but returns an error instead of
+// panicking.
+func TryLessOrEqual(lte any) (Comparison, error) {
+	b, err := orderedJSON(lte)
+	if err != nil {
+		return Comparison{}, err
 	}
+	return Comparison{
+		value: &opComparison{LessOrEqual: b},
+	}, nil
 }
 
 // Range is a short-hand for:
 //
 //	MergeComparisons(GreaterThanOrEqual(gte), LessThan(lt))
+//
+// See TryRange for a variant that returns an error instead of panicking.
 func Range(gte, lt any) Comparison {
+	return mustComparison(TryRange(gte, lt))
+}
+
+// TryRange is like Range, but returns an error instead of panicking.
+func TryRange(gte, lt any) (Comparison, error) {
+	bGTE, err := orderedJSON(gte)
+	if err != nil {
+		return Comparison{}, fmt.Errorf("gte: %w", err)
+	}
+	bLT, err := orderedJSON(lt)
+	if err != nil {
+		return Comparison{}, fmt.Errorf("lt: %w", err)
+	}
 	return Comparison{
 		value: &opComparison{
-			GreaterOrEqual: orderedJSONType(gte),
-			Less:           orderedJSONType(lt),
+			GreaterOrEqual: bGTE,
+			Less:           bLT,
 		},
-	}
+	}, nil
+}
+
+// Exists returns a comparison that match fields that are present and not null.
+//
+// The API has no dedicated existence operator, so Exists is a short-hand for
+// NotEqual(nil). As missing fields compare equal to null, this can be used to
+// match resources where a given annotation or label key is set, e.g.:
+//
+//	CompareField("annotations.example/key", Exists())
+func Exists() Comparison {
+	return NotEqual(nil)
+}
+
+// NotExists returns a comparison that match fields that are either missing or
+// null. It's a short-hand for Equal(nil), which can be used to match resources
+// where a given annotation or label key is absent.
+func NotExists() Comparison {
+	return Equal(nil)
 }
 
 // Regex returns a comparison that match values that matches the provided regexp
@@ -241,7 +380,13 @@ func (cmp Comparison) String() string {
 	return string(b)
 }
 
+// MarshalJSON returns an error if any of the comparison values are not of a
+// type that is valid for the operator. This can happen for comparisons that
+// are decoded from JSON.
 func (c Comparison) MarshalJSON() ([]byte, error) {
+	if err := c.value.validate(); err != nil {
+		return nil, err
+	}
 	return json.Marshal(c.value.normalize())
 }
 
@@ -279,26 +424,53 @@ func (c *Comparison) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func simpleJSON(v any) json.RawMessage {
-	b, err := json.Marshal(v)
+func mustComparison(c Comparison, err error) Comparison {
 	if err != nil {
 		panic(err)
 	}
+	return c
+}
+
+func simpleJSON(v any) (json.RawMessage, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
 	b = bytes.TrimSpace(b)
-	if len(b) == 0 || !strings.ContainsRune(`"0123456789.tfn`, rune(b[0])) {
-		panic("does not marshal to simple JSON type (string, number, bool or null)")
+	if !isSimpleJSON(b) {
+		return nil, ErrNotSimpleJSON
+	}
+	return b, nil
+}
+
+func simpleJSONList[E any](elements []E) ([]json.RawMessage, error) {
+	list := make([]json.RawMessage, 0, len(elements))
+	for i, elem := range elements {
+		b, err := simpleJSON(elem)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		list = append(list, b)
 	}
-	return b
+	return list, nil
 }
 
-func orderedJSONType(v any) json.RawMessage {
+func orderedJSON(v any) (json.RawMessage, error) {
 	b, err := json.Marshal(v)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	b = bytes.TrimSpace(b)
-	if len(b) == 0 || !strings.ContainsRune(`"0123456789.`, rune(b[0])) {
-		panic("does not marshal to sortable JSON type (string or number)")
+	if !isOrderedJSON(b) {
+		return nil, ErrNotOrderedJSON
 	}
-	return b
+	return b, nil
+}
+
+func isSimpleJSON(b []byte) bool {
+	return len(b) > 0 && strings.ContainsRune(`"-0123456789.tfn`, rune(b[0]))
+}
+
+func isOrderedJSON(b []byte) bool {
+	return len(b) > 0 && strings.ContainsRune(`"-0123456789.`, rune(b[0]))
 }
@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -15,6 +15,7 @@
 package fields
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -103,6 +104,11 @@ func (f ResourceFilter) matchAll() bool {
 	return len(f.and) == 0 && len(f.or) == 0 && len(f.paths) == 0
 }
 
+// Equal returns true if f and other are encoded to the same JSON value.
+func (f ResourceFilter) Equal(other ResourceFilter) bool {
+	return jsonEqual(f, other)
+}
+
 func (f ResourceFilter) String() string {
 	b, _ := f.MarshalJSON()
 	return string(b)
@@ -116,21 +122,21 @@ func (f ResourceFilter) MarshalJSON() ([]byte, error) {
 		}
 		j, err := json.Marshal(v)
 		if err != nil {
-			return nil, fmt.Errorf("path %s: %v", k, err)
+			return nil, fmt.Errorf("path %s: %w", k, err)
 		}
 		m[k] = j
 	}
 	if len(f.and) > 0 {
 		j, err := json.Marshal(f.and)
 		if err != nil {
-			return nil, fmt.Errorf("$and: %v", err)
+			return nil, fmt.Errorf("$and: %w", err)
 		}
 		m["$and"] = j
 	}
 	if len(f.or) > 0 {
-		j, err := json.Marshal(f.and)
+		j, err := json.Marshal(f.or)
 		if err != nil {
-			return nil, fmt.Errorf("$or: %v", err)
+			return nil, fmt.Errorf("$or: %w", err)
 		}
 		m["$or"] = j
 	}
@@ -180,3 +186,17 @@ func (f *ResourceFilter) UnmarshalJSON(data []byte) error {
 	}
 	return nil
 }
+
+// jsonEqual returns true if a and b are encoded to the same JSON value without
+// errors.
+func jsonEqual(a, b json.Marshaler) bool {
+	ja, err := a.MarshalJSON()
+	if err != nil {
+		return false
+	}
+	jb, err := b.MarshalJSON()
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(ja, jb)
+}
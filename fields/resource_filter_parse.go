@@ -0,0 +1,341 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fields
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseFilter parses a resource filter from a human friendly expression, such
+// as:
+//
+//	labels.location=pier AND createdAt>2024-01-01
+//
+// This allows command-line flags and configuration files to express filters
+// without raw JSON. An expression consist of comparisons on the format
+// <path><operator><value>, joined by AND and OR, where AND binds stronger than
+// OR. Parentheses can be used for grouping. The supported operators are =, !=,
+// <, <=, >, >= and ~ (regexp). A comparison can also be written as
+// <path> IN (<value>, ...) or <path> NOT IN (<value>, ...).
+//
+// Values can be double-quoted Go string literals, or bare words. Bare words
+// equal to null, true or false, or that parse as numbers, are decoded as such;
+// other bare words are treated as strings. Keywords are case insensitive. An
+// empty expression returns a filter that match all resources.
+//
+// Errors wrap ErrBadFilter.
+func ParseFilter(s string) (ResourceFilter, error) {
+	tokens, err := lexFilter(s)
+	if err != nil {
+		return ResourceFilter{}, err
+	}
+	p := filterParser{tokens: tokens}
+	if p.peek().kind == filterTokenEOF {
+		return FilterAll(), nil
+	}
+	f, err := p.parseOr()
+	if err != nil {
+		return ResourceFilter{}, err
+	}
+	if tok := p.peek(); tok.kind != filterTokenEOF {
+		return ResourceFilter{}, tok.errorf("unexpected %s", tok)
+	}
+	return f, nil
+}
+
+type filterTokenKind uint8
+
+const (
+	filterTokenEOF filterTokenKind = iota
+	filterTokenWord
+	filterTokenString
+	filterTokenOperator
+	filterTokenLParen
+	filterTokenRParen
+	filterTokenComma
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	pos  int
+}
+
+func (tok filterToken) String() string {
+	if tok.kind == filterTokenEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(tok.text)
+}
+
+func (tok filterToken) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: offset %d: %s", ErrBadFilter, tok.pos, fmt.Sprintf(format, args...))
+}
+
+// isKeyword returns true if tok is a bare word equal to keyword, ignoring
+// case.
+func (tok filterToken) isKeyword(keyword string) bool {
+	return tok.kind == filterTokenWord && strings.EqualFold(tok.text, keyword)
+}
+
+const filterSpecialChars = `()",=!<>~`
+
+func lexFilter(s string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(s); {
+		c := s[i]
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			return nil, fmt.Errorf("%w: offset %d: invalid UTF-8", ErrBadFilter, i)
+		case unicode.IsSpace(r):
+			i += size
+		case c == '(':
+			tokens = append(tokens, filterToken{kind: filterTokenLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, filterToken{kind: filterTokenRParen, text: ")", pos: i})
+			i++
+		case c == ',':
+			tokens = append(tokens, filterToken{kind: filterTokenComma, text: ",", pos: i})
+			i++
+		case c == '"':
+			end := i + 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("%w: offset %d: unterminated string", ErrBadFilter, i)
+			}
+			text, err := strconv.Unquote(s[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("%w: offset %d: %v", ErrBadFilter, i, err)
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenString, text: text, pos: i})
+			i = end + 1
+		case strings.IndexByte("=!<>~", c) >= 0:
+			op := s[i : i+1]
+			if i+1 < len(s) && s[i+1] == '=' && c != '=' && c != '~' {
+				op = s[i : i+2]
+			}
+			if op == "!" {
+				return nil, fmt.Errorf("%w: offset %d: unexpected \"!\"", ErrBadFilter, i)
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenOperator, text: op, pos: i})
+			i += len(op)
+		default:
+			end := i
+			for end < len(s) {
+				r, size := utf8.DecodeRuneInString(s[end:])
+				if r == utf8.RuneError && size == 1 {
+					return nil, fmt.Errorf("%w: offset %d: invalid UTF-8", ErrBadFilter, end)
+				}
+				if unicode.IsSpace(r) || strings.ContainsRune(filterSpecialChars, r) {
+					break
+				}
+				end += size
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenWord, text: s[i:end], pos: i})
+			i = end
+		}
+	}
+	return append(tokens, filterToken{kind: filterTokenEOF, pos: len(s)}), nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	i      int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.i]
+}
+
+func (p *filterParser) next() filterToken {
+	tok := p.tokens[p.i]
+	if tok.kind != filterTokenEOF {
+		p.i++
+	}
+	return tok
+}
+
+func (p *filterParser) expect(kind filterTokenKind, what string) (filterToken, error) {
+	tok := p.next()
+	if tok.kind != kind {
+		return tok, tok.errorf("expected %s, got %s", what, tok)
+	}
+	return tok, nil
+}
+
+func (p *filterParser) parseOr() (ResourceFilter, error) {
+	var filters []ResourceFilterType
+	for {
+		f, err := p.parseAnd()
+		if err != nil {
+			return ResourceFilter{}, err
+		}
+		filters = append(filters, f)
+		if !p.peek().isKeyword("OR") {
+			return Or(filters...), nil
+		}
+		p.next()
+	}
+}
+
+func (p *filterParser) parseAnd() (ResourceFilter, error) {
+	var filters []ResourceFilterType
+	for {
+		f, err := p.parseTerm()
+		if err != nil {
+			return ResourceFilter{}, err
+		}
+		filters = append(filters, f)
+		if !p.peek().isKeyword("AND") {
+			return And(filters...), nil
+		}
+		p.next()
+	}
+}
+
+func (p *filterParser) parseTerm() (ResourceFilter, error) {
+	if p.peek().kind == filterTokenLParen {
+		p.next()
+		f, err := p.parseOr()
+		if err != nil {
+			return ResourceFilter{}, err
+		}
+		if _, err := p.expect(filterTokenRParen, `")"`); err != nil {
+			return ResourceFilter{}, err
+		}
+		return f, nil
+	}
+
+	path, err := p.expect(filterTokenWord, "path")
+	if err != nil {
+		return ResourceFilter{}, err
+	}
+	var cmp Comparison
+	switch tok := p.next(); {
+	case tok.kind == filterTokenOperator:
+		cmp, err = p.parseOperator(tok)
+	case tok.isKeyword("IN"):
+		cmp, err = p.parseList(TryIn[any])
+	case tok.isKeyword("NOT"):
+		if tok := p.next(); !tok.isKeyword("IN") {
+			return ResourceFilter{}, tok.errorf(`expected "IN", got %s`, tok)
+		}
+		cmp, err = p.parseList(TryNotIn[any])
+	default:
+		return ResourceFilter{}, tok.errorf("expected operator, got %s", tok)
+	}
+	if err != nil {
+		return ResourceFilter{}, err
+	}
+	return CompareField(path.text, cmp).filter(), nil
+}
+
+func (p *filterParser) parseOperator(op filterToken) (Comparison, error) {
+	tok, v, err := p.parseValue()
+	if err != nil {
+		return Comparison{}, err
+	}
+
+	var cmp Comparison
+	switch op.text {
+	case "=":
+		cmp, err = TryEqual(v)
+	case "!=":
+		cmp, err = TryNotEqual(v)
+	case ">":
+		cmp, err = TryGreater(v)
+	case ">=":
+		cmp, err = TryGreaterOrEqual(v)
+	case "<":
+		cmp, err = TryLess(v)
+	case "<=":
+		cmp, err = TryLessOrEqual(v)
+	case "~":
+		s, ok := v.(string)
+		if !ok {
+			return Comparison{}, tok.errorf("regexp must be a string")
+		}
+		cmp = Regex(s)
+	default:
+		return Comparison{}, op.errorf("unknown operator %s", op)
+	}
+	if err != nil {
+		return Comparison{}, tok.errorf("%v", err)
+	}
+	return cmp, nil
+}
+
+func (p *filterParser) parseList(f func(...any) (Comparison, error)) (Comparison, error) {
+	start, err := p.expect(filterTokenLParen, `"("`)
+	if err != nil {
+		return Comparison{}, err
+	}
+	var values []any
+	for {
+		_, v, err := p.parseValue()
+		if err != nil {
+			return Comparison{}, err
+		}
+		values = append(values, v)
+		tok := p.next()
+		if tok.kind == filterTokenRParen {
+			break
+		}
+		if tok.kind != filterTokenComma {
+			return Comparison{}, tok.errorf(`expected "," or ")", got %s`, tok)
+		}
+	}
+	cmp, err := f(values...)
+	if err != nil {
+		return Comparison{}, start.errorf("%v", err)
+	}
+	return cmp, nil
+}
+
+func (p *filterParser) parseValue() (filterToken, any, error) {
+	tok := p.next()
+	switch tok.kind {
+	case filterTokenString:
+		return tok, tok.text, nil
+	case filterTokenWord:
+	default:
+		return tok, nil, tok.errorf("expected value, got %s", tok)
+	}
+
+	switch strings.ToLower(tok.text) {
+	case "null":
+		return tok, nil, nil
+	case "true":
+		return tok, true, nil
+	case "false":
+		return tok, false, nil
+	}
+	if _, err := strconv.ParseFloat(tok.text, 64); err == nil && json.Valid([]byte(tok.text)) {
+		return tok, json.Number(tok.text), nil
+	}
+	return tok, tok.text, nil
+}
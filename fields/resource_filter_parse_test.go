@@ -0,0 +1,90 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fields_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/clarify/clarify-go/fields"
+)
+
+func TestParseFilter(t *testing.T) {
+	test := func(expr string, expect string, expectErr error) func(t *testing.T) {
+		return func(t *testing.T) {
+			t.Helper()
+			f, err := fields.ParseFilter(expr)
+			if !errors.Is(err, expectErr) {
+				t.Fatalf("Unexpected error:\n got: %v\nwant: %v", err, expectErr)
+			}
+			if err != nil {
+				return
+			}
+			if result := f.String(); result != expect {
+				t.Errorf("Unexpected filter:\n got: %s\nwant: %s", result, expect)
+			}
+		}
+	}
+
+	t.Run("empty", test(``, `{}`, nil))
+	t.Run("equal", test(
+		`labels.location=pier`,
+		`{"labels.location":{"$in":["pier"]}}`,
+		nil,
+	))
+	t.Run("and", test(
+		`labels.location=pier AND createdAt>2024-01-01`,
+		`{"$and":[{"labels.location":{"$in":["pier"]}},{"createdAt":{"$gt":"2024-01-01"}}]}`,
+		nil,
+	))
+	t.Run("or binds weaker than and", test(
+		`a=1 or b="x y" and c!=true`,
+		`{"$or":[{"a":{"$in":[1]}},{"$and":[{"b":{"$in":["x y"]}},{"c":{"$nin":[true]}}]}]}`,
+		nil,
+	))
+	t.Run("parentheses", test(
+		`(a<=1 OR a>=10) AND name~"^temp"`,
+		`{"$and":[{"$or":[{"a":{"$lte":1}},{"a":{"$gte":10}}]},{"name":{"$regex":"^temp"}}]}`,
+		nil,
+	))
+	t.Run("in", test(
+		`id IN (a, "b", 3)`,
+		`{"id":{"$in":["a","b",3]}}`,
+		nil,
+	))
+	t.Run("not in", test(
+		`annotations.x not in (null)`,
+		`{"annotations.x":{"$nin":[null]}}`,
+		nil,
+	))
+	t.Run("non-ASCII value", test(
+		`labels.city=Ås AND labels.x=voilà`,
+		`{"$and":[{"labels.city":{"$in":["Ås"]}},{"labels.x":{"$in":["voilà"]}}]}`,
+		nil,
+	))
+	t.Run("non-ASCII continuation bytes", test(
+		`labels.x=  xƅ`,
+		`{"labels.x":{"$in":["xƅ"]}}`,
+		nil,
+	))
+	t.Run("invalid UTF-8", test("labels.x=voil\xc3", ``, fields.ErrBadFilter))
+	t.Run("missing value", test(`a=`, ``, fields.ErrBadFilter))
+	t.Run("missing operator", test(`a b`, ``, fields.ErrBadFilter))
+	t.Run("unbalanced", test(`(a=1`, ``, fields.ErrBadFilter))
+	t.Run("trailing", test(`a=1 b=2`, ``, fields.ErrBadFilter))
+	t.Run("unterminated string", test(`a="x`, ``, fields.ErrBadFilter))
+	t.Run("bad regexp value", test(`a~1`, ``, fields.ErrBadFilter))
+	t.Run("bad ordered value", test(`a>true`, ``, fields.ErrBadFilter))
+}
@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -15,6 +15,8 @@
 package fields_test
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -50,4 +52,63 @@ func TestFilter(t *testing.T) {
 		fields.Or(fields.FilterAll(), fields.CompareField("id", fields.Equal("a"))),
 		`{}`, // Optimized to empty query (match all).
 	))
+	t.Run(`fields.Field("annotations.a",fields.Exists())`, testStringer(
+		fields.And(fields.CompareField("annotations.a", fields.Exists())),
+		`{"annotations.a":{"$nin":[null]}}`,
+	))
+	t.Run(`fields.Field("annotations.a",fields.NotExists())`, testStringer(
+		fields.And(fields.CompareField("annotations.a", fields.NotExists())),
+		`{"annotations.a":null}`,
+	))
+}
+
+func TestTryComparison(t *testing.T) {
+	test := func(f func() (fields.Comparison, error), expect string, expectErr error) func(t *testing.T) {
+		return func(t *testing.T) {
+			t.Helper()
+			cmp, err := f()
+			if !errors.Is(err, expectErr) {
+				t.Fatalf("Unexpected error:\n got: %v\nwant: %v", err, expectErr)
+			}
+			if err != nil {
+				return
+			}
+			if result := cmp.String(); result != expect {
+				t.Errorf("Unexpected comparison:\n got: %s\nwant: %s", result, expect)
+			}
+		}
+	}
+
+	t.Run("TryEqual(-1)", test(func() (fields.Comparison, error) {
+		return fields.TryEqual(-1)
+	}, `{"$in":[-1]}`, nil))
+	t.Run("TryEqual(map)", test(func() (fields.Comparison, error) {
+		return fields.TryEqual(map[string]string{"a": "b"})
+	}, "", fields.ErrNotSimpleJSON))
+	t.Run("TryIn(string,slice)", test(func() (fields.Comparison, error) {
+		return fields.TryIn[any]("a", []string{"b"})
+	}, "", fields.ErrNotSimpleJSON))
+	t.Run("TryNotIn(a,b)", test(func() (fields.Comparison, error) {
+		return fields.TryNotIn("a", "b")
+	}, `{"$nin":["a","b"]}`, nil))
+	t.Run("TryGreater(true)", test(func() (fields.Comparison, error) {
+		return fields.TryGreater(true)
+	}, "", fields.ErrNotOrderedJSON))
+	t.Run("TryRange(0,null)", test(func() (fields.Comparison, error) {
+		return fields.TryRange(0, nil)
+	}, "", fields.ErrNotOrderedJSON))
+	t.Run("TryRange(0,10)", test(func() (fields.Comparison, error) {
+		return fields.TryRange(0, 10)
+	}, `{"$gte":0,"$lt":10}`, nil))
+}
+
+func TestFilterMarshalInvalidComparison(t *testing.T) {
+	var f fields.ResourceFilter
+	if err := json.Unmarshal([]byte(`{"$or":[{"name":{"$in":[{"a":"b"}]}},{"id":"a"}]}`), &f); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+	_, err := json.Marshal(f)
+	if !errors.Is(err, fields.ErrNotSimpleJSON) {
+		t.Errorf("Unexpected marshal error:\n got: %v\nwant: %v", err, fields.ErrNotSimpleJSON)
+	}
 }
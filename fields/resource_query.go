@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -16,6 +16,7 @@ package fields
 
 import (
 	"encoding/json"
+	"slices"
 )
 
 const defaultQueryLimit = 50
@@ -71,6 +72,11 @@ func (q ResourceQuery) Where(filter ResourceFilterType) ResourceQuery {
 	return q
 }
 
+// GetFilter returns the query filter.
+func (q ResourceQuery) GetFilter() ResourceFilter {
+	return q.query.Filter
+}
+
 // Sort returns a new query that sorts results using the provided fields. To get
 // descending sort, prefix the field with a minus (-).
 //
@@ -84,6 +90,11 @@ func (q ResourceQuery) Sort(fields ...string) ResourceQuery {
 	return q
 }
 
+// GetSort returns a copy of the query sort fields.
+func (q ResourceQuery) GetSort() []string {
+	return slices.Clone(q.query.Sort)
+}
+
 // Skip returns a query that skips the first n entries matching the fields.
 func (q ResourceQuery) Skip(n int) ResourceQuery {
 	q.query.Skip = n
@@ -111,6 +122,11 @@ func (q ResourceQuery) GetLimit() int {
 	return q.query.Limit
 }
 
+// HasLimit returns true if the limit value has been explicitly set.
+func (q ResourceQuery) HasLimit() bool {
+	return q.limitSet
+}
+
 // NextPage returns a new query where the skip value is incremented by the query
 // limit value.
 func (q ResourceQuery) NextPage() ResourceQuery {
@@ -125,3 +141,15 @@ func (q ResourceQuery) Total(force bool) ResourceQuery {
 	q.query.Total = force
 	return q
 }
+
+// GetTotal returns the query total value; see Total.
+func (q ResourceQuery) GetTotal() bool {
+	return q.query.Total
+}
+
+// Equal returns true if q and other are encoded to the same JSON value. This
+// allows comparing queries in tests and middleware. Note that a query with the
+// default limit equals a query where the same limit is set explicitly.
+func (q ResourceQuery) Equal(other ResourceQuery) bool {
+	return jsonEqual(q, other)
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fields_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/clarify/clarify-go/fields"
+)
+
+func TestResourceQueryGetters(t *testing.T) {
+	filter := fields.CompareField("name", fields.Equal("a"))
+	q := fields.Query().Where(filter).Sort("-id", "name").Total(true)
+
+	if f := q.GetFilter(); !f.Equal(fields.And(filter)) {
+		t.Errorf("Unexpected filter:\n got: %v\nwant: %v", f, fields.And(filter))
+	}
+	if sort := q.GetSort(); !slices.Equal(sort, []string{"-id", "name"}) {
+		t.Errorf("Unexpected sort:\n got: %v\nwant: %v", sort, []string{"-id", "name"})
+	}
+	if !q.GetTotal() {
+		t.Errorf("Unexpected total:\n got: false\nwant: true")
+	}
+
+	var decoded fields.ResourceQuery
+	b, err := json.Marshal(q)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !decoded.Equal(q) {
+		t.Errorf("Expected decoded query to equal original:\n got: %s", b)
+	}
+	if q.Equal(q.Limit(10)) {
+		t.Errorf("Expected queries with different limits to differ")
+	}
+	if q.GetFilter().Equal(fields.FilterAll()) {
+		t.Errorf("Expected filter to differ from FilterAll")
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fields
+
+import "time"
+
+// TimeRangeFunc describe a function that returns a time range [gte,lt)
+// relative to the passed in current time.
+//
+// Calendar aware time ranges are computed using local clock times in the
+// configured location, so that e.g. a day is 23 or 25 hours long when it
+// includes a daylight saving time (DST) adjustment.
+type TimeRangeFunc func(now time.Time) (gte, lt time.Time)
+
+// Filter returns a data filter that matches the time range relative to now.
+func (f TimeRangeFunc) Filter(now time.Time) DataFilter {
+	return TimeRange(f(now))
+}
+
+// Today returns a time range function for the current calendar day in loc,
+// from midnight to the next midnight. If loc is nil, UTC is used.
+func Today(loc *time.Location) TimeRangeFunc {
+	return LastDays(loc, 1)
+}
+
+// Yesterday returns a time range function for the previous calendar day in
+// loc. If loc is nil, UTC is used.
+func Yesterday(loc *time.Location) TimeRangeFunc {
+	return LastCompleteDays(loc, 1)
+}
+
+// LastDays returns a time range function that spans n calendar days in loc,
+// ending at the end of the current day. I.e. n=1 is equivalent to Today. If loc
+// is nil, UTC is used. If n <= 0, the time range is empty.
+func LastDays(loc *time.Location, n int) TimeRangeFunc {
+	loc = locationOrUTC(loc)
+	return func(now time.Time) (gte, lt time.Time) {
+		y, m, d := now.In(loc).Date()
+		lt = time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+		return time.Date(y, m, d+1-max(n, 0), 0, 0, 0, 0, loc), lt
+	}
+}
+
+// LastCompleteDays returns a time range function that spans the n calendar
+// days in loc before the current day. If loc is nil, UTC is used. If n <= 0,
+// the time range is empty.
+func LastCompleteDays(loc *time.Location, n int) TimeRangeFunc {
+	loc = locationOrUTC(loc)
+	return func(now time.Time) (gte, lt time.Time) {
+		y, m, d := now.In(loc).Date()
+		lt = time.Date(y, m, d, 0, 0, 0, 0, loc)
+		return time.Date(y, m, d-max(n, 0), 0, 0, 0, 0, loc), lt
+	}
+}
+
+// LastCompleteHours returns a time range function that spans the n whole hours
+// before the start of the current hour. Hours are aligned to whole hours in
+// UTC, which also hold for all time-zones with whole hour offsets. If n <= 0,
+// the time range is empty.
+func LastCompleteHours(n int) TimeRangeFunc {
+	return func(now time.Time) (gte, lt time.Time) {
+		lt = now.Truncate(time.Hour)
+		return lt.Add(-time.Duration(max(n, 0)) * time.Hour), lt
+	}
+}
+
+// ThisWeek returns a time range function for the current calendar week in loc,
+// where weeks start at midnight on weekStart. If loc is nil, UTC is used.
+func ThisWeek(loc *time.Location, weekStart time.Weekday) TimeRangeFunc {
+	loc = locationOrUTC(loc)
+	return func(now time.Time) (gte, lt time.Time) {
+		local := now.In(loc)
+		y, m, d := local.Date()
+		offset := (int(local.Weekday()) - int(weekStart) + 7) % 7
+		return time.Date(y, m, d-offset, 0, 0, 0, 0, loc), time.Date(y, m, d-offset+7, 0, 0, 0, 0, loc)
+	}
+}
+
+// ThisMonth returns a time range function for the current calendar month in
+// loc. If loc is nil, UTC is used.
+func ThisMonth(loc *time.Location) TimeRangeFunc {
+	loc = locationOrUTC(loc)
+	return func(now time.Time) (gte, lt time.Time) {
+		y, m, _ := now.In(loc).Date()
+		return time.Date(y, m, 1, 0, 0, 0, 0, loc), time.Date(y, m+1, 1, 0, 0, 0, 0, loc)
+	}
+}
+
+func locationOrUTC(loc *time.Location) *time.Location {
+	if loc == nil {
+		return time.UTC
+	}
+	return loc
+}
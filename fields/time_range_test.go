@@ -0,0 +1,60 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fields_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+)
+
+func TestTimeRangeFunc(t *testing.T) {
+	test := func(f fields.TimeRangeFunc, now, expectGTE, expectLT string) func(t *testing.T) {
+		return func(t *testing.T) {
+			t.Helper()
+
+			n, err := time.Parse(time.RFC3339, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gte, lt := f(n)
+			if r := gte.Format(time.RFC3339); r != expectGTE {
+				t.Errorf("Unexpected gte:\n got: %s\nwant: %s", r, expectGTE)
+			}
+			if r := lt.Format(time.RFC3339); r != expectLT {
+				t.Errorf("Unexpected lt:\n got: %s\nwant: %s", r, expectLT)
+			}
+		}
+	}
+
+	t.Run("Today UTC", test(fields.Today(nil), "2024-03-05T13:20:00Z", "2024-03-05T00:00:00Z", "2024-03-06T00:00:00Z"))
+	t.Run("Yesterday UTC", test(fields.Yesterday(nil), "2024-03-01T13:20:00Z", "2024-02-29T00:00:00Z", "2024-03-01T00:00:00Z"))
+	t.Run("LastDays 7", test(fields.LastDays(time.UTC, 7), "2024-03-05T13:20:00Z", "2024-02-28T00:00:00Z", "2024-03-06T00:00:00Z"))
+	t.Run("LastCompleteDays 0", test(fields.LastCompleteDays(time.UTC, 0), "2024-03-05T13:20:00Z", "2024-03-05T00:00:00Z", "2024-03-05T00:00:00Z"))
+	t.Run("LastCompleteHours 3", test(fields.LastCompleteHours(3), "2024-03-05T13:20:00Z", "2024-03-05T10:00:00Z", "2024-03-05T13:00:00Z"))
+	t.Run("ThisWeek Monday", test(fields.ThisWeek(nil, time.Monday), "2024-03-03T13:20:00Z", "2024-02-26T00:00:00Z", "2024-03-04T00:00:00Z"))
+	t.Run("ThisWeek Sunday", test(fields.ThisWeek(nil, time.Sunday), "2024-03-03T13:20:00Z", "2024-03-03T00:00:00Z", "2024-03-10T00:00:00Z"))
+	t.Run("ThisMonth", test(fields.ThisMonth(nil), "2024-12-31T23:59:59Z", "2024-12-01T00:00:00Z", "2025-01-01T00:00:00Z"))
+
+	loc, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Skipf("Time-zone database not available: %v", err)
+	}
+	// 2024-03-31 is 23 hours long in Europe/Oslo.
+	t.Run("Today DST start", test(fields.Today(loc), "2024-03-31T12:00:00Z", "2024-03-31T00:00:00+01:00", "2024-04-01T00:00:00+02:00"))
+	t.Run("Today DST end", test(fields.Today(loc), "2024-10-27T12:00:00Z", "2024-10-27T00:00:00+02:00", "2024-10-28T00:00:00+01:00"))
+	t.Run("ThisWeek DST", test(fields.ThisWeek(loc, time.Monday), "2024-03-31T22:30:00Z", "2024-04-01T00:00:00+02:00", "2024-04-08T00:00:00+02:00"))
+}
@@ -0,0 +1,385 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package csvloader provides a bulk loader for inserting data from CSV files
+// to Clarify signals; a common task when onboarding historical data.
+//
+// The first row of a file must hold column names. One column holds the sample
+// time, while other columns are mapped to signal input keys:
+//
+//	time,temperature,humidity
+//	2024-01-01T00:00:00Z,21.5,40
+//
+// Rows are inserted in batches. Invalid cells are reported per row, and
+// skipped, without aborting the load.
+package csvloader
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/internal/ingest"
+	"github.com/clarify/clarify-go/views"
+)
+
+// Loader errors.
+const (
+	ErrBadCSV           ingest.Error = "bad CSV"
+	ErrBadHeader        ingest.Error = "bad header"
+	ErrBadTime                       = ingest.ErrBadTime
+	ErrBadValue                      = ingest.ErrBadValue
+	ErrTooManyRowErrors ingest.Error = "too many row errors"
+)
+
+// TimeLayoutUnix can be used as a Loader.TimeLayout to parse times as Unix
+// time in seconds, with an optional fraction.
+const TimeLayoutUnix = "unix"
+
+const (
+	defaultTimeColumn = "time"
+	defaultBatchSize  = 1000
+)
+
+// Loader loads CSV files into signals. The zero-value is not usable; Client
+// must be set.
+type Loader struct {
+	// Client is the client to insert data with.
+	Client *clarify.Client
+
+	// TimeColumn names the column holding the sample time. The default is
+	// "time".
+	TimeColumn string
+
+	// TimeLayout describe the layout of the time column, as accepted by
+	// time.Parse, or TimeLayoutUnix. The default is RFC 3339.
+	TimeLayout string
+
+	// Location sets the time-zone to use for times without a time-zone
+	// offset. The default is UTC.
+	Location *time.Location
+
+	// Comma sets the column delimiter. The default is ','.
+	Comma rune
+
+	// DecimalSeparator sets the decimal separator used for values, such as
+	// ',' for many European locales. When set to ',', Comma should be set to
+	// another delimiter, such as ';', unless values are quoted. The default
+	// is '.'.
+	DecimalSeparator rune
+
+	// ThousandsSeparator, if set, is removed from values before they are
+	// parsed.
+	ThousandsSeparator rune
+
+	// Columns maps column names to signal input keys. If nil, all columns
+	// except the time column are loaded, using the column name as input key.
+	// When set, other columns are ignored.
+	Columns map[string]string
+
+	// InputPrefix is prepended to all input keys.
+	InputPrefix string
+
+	// BatchSize sets the number of rows to include per insert request. The
+	// default is 1000.
+	BatchSize int
+
+	// MaxRowErrors, if set, aborts the load with an error wrapping
+	// ErrTooManyRowErrors when more than MaxRowErrors row errors are found. This
+	// helps detect a wrong configuration early. The default is to continue
+	// the load regardless of errors.
+	MaxRowErrors int
+}
+
+// Result describe the result of a load.
+type Result struct {
+	// Rows holds the number of data rows read.
+	Rows int
+
+	// Samples holds the number of samples inserted.
+	Samples int
+
+	// RowErrors lists issues found per row. Rows or cells with errors are
+	// not inserted.
+	RowErrors []RowError
+
+	// SignalsByInput holds the merged insert results.
+	SignalsByInput map[string]views.CreateSummary
+}
+
+// RowError describe an issue with a single row.
+type RowError struct {
+	// Line holds the line number of the row, starting at 1 for the header.
+	Line int
+
+	// Column holds the name of the column with the issue, if any.
+	Column string
+
+	// Err holds the error.
+	Err error
+}
+
+func (err RowError) Error() string {
+	if err.Column == "" {
+		return fmt.Sprintf("line %d: %v", err.Line, err.Err)
+	}
+	return fmt.Sprintf("line %d: %s: %v", err.Line, err.Column, err.Err)
+}
+
+func (err RowError) Unwrap() error { return err.Err }
+
+// column describe a column to load.
+type column struct {
+	index int
+	name  string
+	input string
+}
+
+// Load reads CSV data from r and inserts it in batches. An error is returned
+// if the header is invalid, the file is malformed, MaxRowErrors is exceeded or
+// an insert fails. Batches inserted before the error are not rolled back, and
+// are reported in the returned result.
+func (l *Loader) Load(ctx context.Context, r io.Reader) (*Result, error) {
+	cr := csv.NewReader(r)
+	if l.Comma != 0 {
+		cr.Comma = l.Comma
+	}
+	cr.ReuseRecord = true
+
+	result := &Result{SignalsByInput: make(map[string]views.CreateSummary)}
+	header, err := cr.Read()
+	switch {
+	case errors.Is(err, io.EOF):
+		return result, nil
+	case err != nil:
+		return result, fmt.Errorf("%w: %v", ErrBadCSV, err)
+	}
+	// Copy the header, as the record is reused by the reader.
+	header = slices.Clone(header)
+	timeIndex, columns, err := l.columns(header)
+	if err != nil {
+		return result, err
+	}
+
+	df := make(views.DataFrame)
+	var rows int
+	flush := func() error {
+		if rows == 0 {
+			return nil
+		}
+		var samples int
+		for _, s := range df {
+			samples += len(s)
+		}
+		res, err := l.Client.Insert(df).Do(ctx)
+		if err != nil {
+			return err
+		}
+		views.MergeCreateSummaries(result.SignalsByInput, res.SignalsByInput)
+		result.Samples += samples
+		df, rows = make(views.DataFrame), 0
+		return nil
+	}
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount):
+			result.Rows++
+			err = l.rowError(result, RowError{Line: parseErr.StartLine, Err: fmt.Errorf("%w: %v", ErrBadCSV, parseErr.Err)})
+			if err != nil {
+				return result, err
+			}
+			continue
+		case err != nil:
+			return result, fmt.Errorf("%w: %v", ErrBadCSV, err)
+		}
+		result.Rows++
+		line, _ := cr.FieldPos(0)
+
+		t, err := l.parseTime(record[timeIndex])
+		if err != nil {
+			if err := l.rowError(result, RowError{Line: line, Column: header[timeIndex], Err: err}); err != nil {
+				return result, err
+			}
+			continue
+		}
+		ts := fields.AsTimestamp(t)
+
+		for _, c := range columns {
+			raw := strings.TrimSpace(record[c.index])
+			if raw == "" {
+				// Empty cells are treated as missing values.
+				continue
+			}
+			v, err := l.parseValue(raw)
+			if err != nil {
+				if err := l.rowError(result, RowError{Line: line, Column: c.name, Err: err}); err != nil {
+					return result, err
+				}
+				continue
+			}
+			s, ok := df[c.input]
+			if !ok {
+				s = make(views.DataSeries)
+				df[c.input] = s
+			}
+			s[ts] = v
+		}
+
+		rows++
+		if rows >= l.batchSize() {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// rowError adds err to result, and returns an error if MaxRowErrors is
+// exceeded.
+func (l *Loader) rowError(result *Result, err RowError) error {
+	result.RowErrors = append(result.RowErrors, err)
+	if l.MaxRowErrors > 0 && len(result.RowErrors) > l.MaxRowErrors {
+		return fmt.Errorf("%w: %d errors, last: %v", ErrTooManyRowErrors, len(result.RowErrors), err)
+	}
+	return nil
+}
+
+// columns normalizes the column names in header, and returns the index of the
+// time column and the columns to load.
+func (l *Loader) columns(header []string) (int, []column, error) {
+	timeColumn := l.TimeColumn
+	if timeColumn == "" {
+		timeColumn = defaultTimeColumn
+	}
+
+	timeIndex := -1
+	indices := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if i == 0 {
+			// Strip any UTF-8 byte order mark, as written by some spreadsheet
+			// applications.
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		header[i] = name
+		if _, ok := indices[name]; ok {
+			return 0, nil, fmt.Errorf("%w: duplicate column %q", ErrBadHeader, name)
+		}
+		indices[name] = i
+		if name == timeColumn {
+			timeIndex = i
+		}
+	}
+	if timeIndex < 0 {
+		return 0, nil, fmt.Errorf("%w: missing time column %q", ErrBadHeader, timeColumn)
+	}
+
+	var columns []column
+	add := func(i int, name, input string) error {
+		input = l.InputPrefix + input
+		if err := views.ValidateInputKey(input); err != nil {
+			return fmt.Errorf("%w: column %q: %w", ErrBadHeader, name, err)
+		}
+		columns = append(columns, column{index: i, name: name, input: input})
+		return nil
+	}
+	if l.Columns == nil {
+		for name, i := range indices {
+			if i == timeIndex {
+				continue
+			}
+			if err := add(i, name, name); err != nil {
+				return 0, nil, err
+			}
+		}
+	} else {
+		for name, input := range l.Columns {
+			i, ok := indices[name]
+			if !ok {
+				return 0, nil, fmt.Errorf("%w: missing column %q", ErrBadHeader, name)
+			}
+			if err := add(i, name, input); err != nil {
+				return 0, nil, err
+			}
+		}
+	}
+	slices.SortFunc(columns, func(a, b column) int { return a.index - b.index })
+	return timeIndex, columns, nil
+}
+
+func (l *Loader) parseTime(raw string) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	switch l.TimeLayout {
+	case "":
+		t, err := time.ParseInLocation(time.RFC3339Nano, raw, l.location())
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %q", ErrBadTime, raw)
+		}
+		return t, nil
+	case TimeLayoutUnix:
+		return ingest.ParseUnixTime(raw)
+	default:
+		t, err := time.ParseInLocation(l.TimeLayout, raw, l.location())
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %q does not match layout %q", ErrBadTime, raw, l.TimeLayout)
+		}
+		return t, nil
+	}
+}
+
+func (l *Loader) parseValue(raw string) (float64, error) {
+	s := raw
+	if l.ThousandsSeparator != 0 {
+		s = strings.ReplaceAll(s, string(l.ThousandsSeparator), "")
+	}
+	if l.DecimalSeparator != 0 && l.DecimalSeparator != '.' {
+		s = strings.ReplaceAll(s, string(l.DecimalSeparator), ".")
+	}
+	f, err := ingest.ParseFloat(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrBadValue, raw)
+	}
+	return f, nil
+}
+
+func (l *Loader) location() *time.Location {
+	if l.Location == nil {
+		return time.UTC
+	}
+	return l.Location
+}
+
+func (l *Loader) batchSize() int {
+	if l.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+	return l.BatchSize
+}
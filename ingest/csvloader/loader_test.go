@@ -0,0 +1,134 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csvloader_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/ingest/csvloader"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestLoader(t *testing.T) {
+	var batches []views.DataFrame
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		df := req.Params.(map[string]any)["data"].(views.DataFrame)
+		batches = append(batches, df)
+		// Signals are reported as created by the first batch only.
+		res := result.(*clarify.InsertResult)
+		res.SignalsByInput = make(map[string]views.CreateSummary)
+		for k := range df {
+			res.SignalsByInput[k] = views.CreateSummary{ID: k, Created: len(batches) == 1}
+		}
+		return nil
+	})
+	l := &csvloader.Loader{
+		Client:             clarify.NewClient("integration", h),
+		TimeColumn:         "Timestamp",
+		TimeLayout:         "02.01.2006 15:04",
+		Location:           time.UTC,
+		Comma:              ';',
+		DecimalSeparator:   ',',
+		ThousandsSeparator: ' ',
+		Columns:            map[string]string{"Temp": "temperature", "Power": "power"},
+		InputPrefix:        "site1.",
+		BatchSize:          2,
+	}
+	data := "" +
+		"\ufeffTimestamp;Temp;Power;Ignored\n" +
+		"01.01.2024 00:00;21,5;1 200,5;x\n" +
+		"01.01.2024 00:01;;1 300;x\n" +
+		"bad time;22;1;x\n" +
+		"01.01.2024 00:02;n/a;1 400;x\n" +
+		"01.01.2024 00:03;23\n"
+
+	result, err := l.Load(context.Background(), strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	t0 := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	expectBatches := []views.DataFrame{
+		{
+			"site1.temperature": {t0: 21.5},
+			"site1.power":       {t0: 1200.5, t0.Add(time.Minute): 1300},
+		},
+		{
+			"site1.power": {t0.Add(2 * time.Minute): 1400},
+		},
+	}
+	if !reflect.DeepEqual(batches, expectBatches) {
+		t.Errorf("Unexpected batches:\n got: %v\nwant: %v", batches, expectBatches)
+	}
+	if result.Rows != 5 || result.Samples != 4 {
+		t.Errorf("Unexpected counts:\n got: rows=%d samples=%d\nwant: rows=5 samples=4", result.Rows, result.Samples)
+	}
+	expectSignals := map[string]views.CreateSummary{
+		"site1.temperature": {ID: "site1.temperature", Created: true},
+		"site1.power":       {ID: "site1.power", Created: true},
+	}
+	if !reflect.DeepEqual(result.SignalsByInput, expectSignals) {
+		t.Errorf("Unexpected signals:\n got: %v\nwant: %v", result.SignalsByInput, expectSignals)
+	}
+
+	expectErrors := []struct {
+		line   int
+		column string
+		err    error
+	}{
+		{4, "Timestamp", csvloader.ErrBadTime},
+		{5, "Temp", csvloader.ErrBadValue},
+		{6, "", csvloader.ErrBadCSV},
+	}
+	if len(result.RowErrors) != len(expectErrors) {
+		t.Fatalf("Unexpected row errors:\n got: %v\nwant: %d errors", result.RowErrors, len(expectErrors))
+	}
+	for i, expect := range expectErrors {
+		got := result.RowErrors[i]
+		if got.Line != expect.line || got.Column != expect.column || !errors.Is(got, expect.err) {
+			t.Errorf("Unexpected row error %d:\n got: %v\nwant: line %d: %s: %v", i, got, expect.line, expect.column, expect.err)
+		}
+	}
+}
+
+func TestLoaderErrors(t *testing.T) {
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		return nil
+	})
+
+	test := func(l csvloader.Loader, data string, expect error) func(t *testing.T) {
+		return func(t *testing.T) {
+			l.Client = clarify.NewClient("integration", h)
+			_, err := l.Load(context.Background(), strings.NewReader(data))
+			if !errors.Is(err, expect) {
+				t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, expect)
+			}
+		}
+	}
+
+	t.Run("MissingTime", test(csvloader.Loader{}, "a,b\n1,2\n", csvloader.ErrBadHeader))
+	t.Run("MissingColumn", test(csvloader.Loader{Columns: map[string]string{"c": "c"}}, "time,a\n", csvloader.ErrBadHeader))
+	t.Run("BadInputKey", test(csvloader.Loader{}, "time,a b\n", csvloader.ErrBadHeader))
+	t.Run("MaxRowErrors", test(csvloader.Loader{MaxRowErrors: 1}, "time,a\nx,1\ny,2\n", csvloader.ErrTooManyRowErrors))
+}
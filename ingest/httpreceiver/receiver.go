@@ -0,0 +1,262 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httpreceiver provides an HTTP handler that accepts JSON or CSV
+// payloads, e.g. from webhook-emitting devices, and inserts them to Clarify.
+//
+// A payload consist of one or more records, where each record holds a sample
+// time and one or more values. For JSON, a record is an object, and the payload
+// is either a single record or an array of records:
+//
+//	[{"time":"2024-01-01T00:00:00Z","temperature":21.5,"humidity":40}]
+//
+// For CSV, the first row must hold field names, and each following row is a
+// record:
+//
+//	time,temperature,humidity
+//	2024-01-01T00:00:00Z,21.5,40
+package httpreceiver
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/internal/ingest"
+	"github.com/clarify/clarify-go/views"
+)
+
+// Payload errors.
+const (
+	ErrBadPayload ingest.Error = "bad payload"
+	ErrBadTime                 = ingest.ErrBadTime
+	ErrBadValue                = ingest.ErrBadValue
+	ErrBadInput                = views.ErrBadInputKey
+)
+
+const (
+	defaultTimeField   = "time"
+	defaultMaxBodySize = 1 << 20
+)
+
+var _ http.Handler = (*Receiver)(nil)
+
+// Receiver is an HTTP handler that accepts POST requests with JSON or CSV
+// payloads, maps record fields to signal inputs, and inserts the result using
+// Client. The payload format is selected by the Content-Type header; either
+// "application/json" or "text/csv".
+//
+// On success, the insert result is returned as JSON. Payloads that fail
+// validation are rejected with status 400 without inserting any data, and
+// failed inserts are reported with status 502.
+type Receiver struct {
+	// Client is the client to insert data with.
+	Client *clarify.Client
+
+	// TimeField names the record field holding the sample time. Strings are
+	// parsed as RFC 3339 times, while numbers are parsed as Unix time in
+	// seconds. Records without a time field use the time the request was
+	// received, as reported by the client clock; see clarify.WithClock. The
+	// default is "time".
+	TimeField string
+
+	// Fields maps record field names to signal input keys. If nil, all fields
+	// except the time field are inserted, using the field name as input key.
+	// When set, other fields are ignored.
+	Fields map[string]string
+
+	// InputPrefix is prepended to all input keys.
+	InputPrefix string
+
+	// MaxBodySize sets the maximum accepted request body size in bytes. The
+	// default is 1 MiB.
+	MaxBodySize int64
+
+	// ErrorLogger, if set, is called for requests that are rejected or fail.
+	ErrorLogger func(r *http.Request, status int, err error)
+}
+
+func (rec *Receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		rec.fail(w, r, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, rec.maxBodySize()))
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			rec.fail(w, r, http.StatusRequestEntityTooLarge, err)
+			return
+		}
+		rec.fail(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var records []map[string]any
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		records, err = decodeJSON(body)
+	case "text/csv":
+		records, err = decodeCSV(body)
+	default:
+		rec.fail(w, r, http.StatusUnsupportedMediaType, fmt.Errorf("unsupported content type %q", mediaType))
+		return
+	}
+	if err != nil {
+		rec.fail(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	df, err := rec.DataFrame(records, rec.Client.Now())
+	if err != nil {
+		rec.fail(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	result := &clarify.InsertResult{SignalsByInput: map[string]views.CreateSummary{}}
+	if len(df) > 0 {
+		result, err = rec.Client.Insert(df).Do(r.Context())
+		if err != nil {
+			rec.fail(w, r, http.StatusBadGateway, err)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// DataFrame maps records to a data frame, using now as the sample time for
+// records without a time field. An error is returned if any of the records
+// are invalid.
+func (rec *Receiver) DataFrame(records []map[string]any, now time.Time) (views.DataFrame, error) {
+	timeField := rec.TimeField
+	if timeField == "" {
+		timeField = defaultTimeField
+	}
+
+	df := make(views.DataFrame)
+	for i, record := range records {
+		t := now
+		if raw, ok := record[timeField]; ok {
+			var err error
+			if t, err = ingest.ParseTime(raw); err != nil {
+				return nil, fmt.Errorf("records[%d].%s: %w", i, timeField, err)
+			}
+		}
+		ts := fields.AsTimestamp(t)
+
+		for field, raw := range record {
+			if field == timeField || raw == nil {
+				continue
+			}
+			input := field
+			if rec.Fields != nil {
+				var ok bool
+				if input, ok = rec.Fields[field]; !ok {
+					continue
+				}
+			}
+			input = rec.InputPrefix + input
+			if err := views.ValidateInputKey(input); err != nil {
+				return nil, fmt.Errorf("records[%d].%s: %w", i, field, err)
+			}
+			v, err := ingest.ParseValue(raw)
+			if err != nil {
+				return nil, fmt.Errorf("records[%d].%s: %w", i, field, err)
+			}
+
+			series, ok := df[input]
+			if !ok {
+				series = make(views.DataSeries)
+				df[input] = series
+			}
+			series[ts] = v
+		}
+	}
+	return df, nil
+}
+
+func (rec *Receiver) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if rec.ErrorLogger != nil {
+		rec.ErrorLogger(r, status, err)
+	}
+	http.Error(w, err.Error(), status)
+}
+
+func (rec *Receiver) maxBodySize() int64 {
+	if rec.MaxBodySize <= 0 {
+		return defaultMaxBodySize
+	}
+	return rec.MaxBodySize
+}
+
+func decodeJSON(body []byte) ([]map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var payload any
+	if err := dec.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadPayload, err)
+	}
+	switch v := payload.(type) {
+	case map[string]any:
+		return []map[string]any{v}, nil
+	case []any:
+		records := make([]map[string]any, 0, len(v))
+		for i, elem := range v {
+			record, ok := elem.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%w: records[%d]: must be an object", ErrBadPayload, i)
+			}
+			records = append(records, record)
+		}
+		return records, nil
+	}
+	return nil, fmt.Errorf("%w: must be an object or an array of objects", ErrBadPayload)
+}
+
+func decodeCSV(body []byte) ([]map[string]any, error) {
+	rows, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadPayload, err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	header := rows[0]
+	records := make([]map[string]any, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		record := make(map[string]any, len(header))
+		for i, field := range header {
+			// Empty cells are treated as missing values.
+			if row[i] != "" {
+				record[field] = row[i]
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
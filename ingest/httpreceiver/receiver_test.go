@@ -0,0 +1,120 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpreceiver_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/ingest/httpreceiver"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestReceiver(t *testing.T) {
+	var inserted views.DataFrame
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		inserted = req.Params.(map[string]any)["data"].(views.DataFrame)
+		return nil
+	})
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	rec := &httpreceiver.Receiver{
+		Client:      clarify.NewClient("integration", h, clarify.WithClock(func() time.Time { return now })),
+		InputPrefix: "dev1.",
+	}
+
+	t0 := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	t1 := t0.Add(time.Minute)
+	test := func(method, contentType, body string, expectStatus int, expect views.DataFrame) func(t *testing.T) {
+		return func(t *testing.T) {
+			t.Helper()
+			inserted = nil
+			req := httptest.NewRequest(method, "/", strings.NewReader(body))
+			req.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+			rec.ServeHTTP(w, req)
+
+			if w.Code != expectStatus {
+				t.Errorf("Unexpected status:\n got: %d\nwant: %d\nbody: %s", w.Code, expectStatus, w.Body.String())
+			}
+			for input, series := range expect {
+				for ts, v := range series {
+					if got, ok := inserted[input][ts]; !ok || got != v {
+						t.Errorf("Unexpected value for %s at %v:\n got: %v (%t)\nwant: %v", input, ts.Time(), got, ok, v)
+					}
+				}
+			}
+			if expect == nil && inserted != nil {
+				t.Errorf("Expected no insert, got: %v", inserted)
+			}
+		}
+	}
+
+	t.Run("JSON object", test(http.MethodPost, "application/json",
+		`{"time":"2024-01-01T00:00:00Z","temp":21.5,"open":true,"missing":null}`,
+		http.StatusOK,
+		views.DataFrame{"dev1.temp": {t0: 21.5}, "dev1.open": {t0: 1}},
+	))
+	t.Run("JSON array", test(http.MethodPost, "application/json; charset=utf-8",
+		`[{"time":1704067200,"temp":21.5},{"time":1704067260,"temp":22}]`,
+		http.StatusOK,
+		views.DataFrame{"dev1.temp": {t0: 21.5, t1: 22}},
+	))
+	t.Run("CSV", test(http.MethodPost, "text/csv",
+		"time,temp,humidity\n2024-01-01T00:00:00Z,21.5,\n2024-01-01T00:01:00Z,22,40\n",
+		http.StatusOK,
+		views.DataFrame{"dev1.temp": {t0: 21.5, t1: 22}, "dev1.humidity": {t1: 40}},
+	))
+	t.Run("no time", test(http.MethodPost, "application/json",
+		`{"temp":21.5}`,
+		http.StatusOK,
+		views.DataFrame{"dev1.temp": {fields.AsTimestamp(now): 21.5}},
+	))
+	t.Run("bad value", test(http.MethodPost, "application/json",
+		`{"time":"2024-01-01T00:00:00Z","temp":"hot"}`,
+		http.StatusBadRequest,
+		nil,
+	))
+	t.Run("bad input key", test(http.MethodPost, "application/json",
+		`{"time":"2024-01-01T00:00:00Z","temp C":1}`,
+		http.StatusBadRequest,
+		nil,
+	))
+	t.Run("bad content type", test(http.MethodPost, "text/plain", `1`, http.StatusUnsupportedMediaType, nil))
+	t.Run("bad method", test(http.MethodGet, "application/json", ``, http.StatusMethodNotAllowed, nil))
+}
+
+func TestReceiverFields(t *testing.T) {
+	rec := &httpreceiver.Receiver{
+		TimeField: "ts",
+		Fields:    map[string]string{"t": "temperature"},
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	df, err := rec.DataFrame([]map[string]any{{"t": "1.5", "h": "40"}}, now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect := views.DataFrame{"temperature": {fields.AsTimestamp(now): 1.5}}
+	if len(df) != 1 || df["temperature"][fields.AsTimestamp(now)] != 1.5 {
+		t.Errorf("Unexpected data frame:\n got: %v\nwant: %v", df, expect)
+	}
+}
@@ -0,0 +1,346 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clarify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+// Ingestor errors.
+const (
+	// ErrIngestorFull is returned by Ingestor.Add when the buffer is full and
+	// the overflow policy is OverflowReject.
+	ErrIngestorFull strError = "ingestor buffer is full"
+
+	// ErrIngestorStopped is returned by Ingestor.Add when the buffer is full,
+	// the overflow policy is OverflowBlock, and Run is not running.
+	ErrIngestorStopped strError = "ingestor is not running"
+)
+
+const (
+	defaultIngestorFlushSize     = 1000
+	defaultIngestorFlushInterval = 5 * time.Second
+	defaultIngestorRetries       = 3
+	defaultIngestorRetryDelay    = time.Second
+	defaultIngestorFlushTimeout  = 10 * time.Second
+)
+
+// OverflowPolicy describe how an Ingestor handles new samples when the buffer
+// is full.
+type OverflowPolicy int
+
+// Overflow policies.
+const (
+	// OverflowBlock makes Add block until there is room in the buffer, or
+	// until the context passed to Add is canceled. As room is only made by
+	// flushes performed by Run, Add returns ErrIngestorStopped instead of
+	// blocking when Run is not running.
+	OverflowBlock OverflowPolicy = iota
+
+	// OverflowReject makes Add return ErrIngestorFull, dropping the new sample.
+	OverflowReject
+
+	// OverflowDropOldest makes Add drop the oldest buffered sample to make
+	// room for the new sample.
+	OverflowDropOldest
+)
+
+// Ingestor buffers samples added from any number of goroutines, and inserts
+// them to Clarify in batches. A flush happens when FlushSize samples are
+// buffered, or when FlushInterval has passed since the last flush, whichever
+// happens first. Flushes are only performed while Run is running.
+//
+// The zero-value is not usable; Client must be set. An Ingestor must not be
+// copied after first use.
+type Ingestor struct {
+	// Client is the client to insert data with.
+	Client *Client
+
+	// FlushSize sets the number of buffered samples that triggers a flush.
+	// The default is 1000.
+	FlushSize int
+
+	// FlushInterval sets the maximum duration between flushes. The default is
+	// 5 seconds.
+	FlushInterval time.Duration
+
+	// MaxBuffered sets the maximum number of buffered samples before the
+	// overflow policy applies. The default is 10 times FlushSize.
+	MaxBuffered int
+
+	// Overflow sets the policy for adding samples when the buffer is full.
+	// The default is OverflowBlock.
+	Overflow OverflowPolicy
+
+	// MaxRetries sets the number of times a failing insert is retried, with
+	// an exponential back-off starting at RetryDelay. Requests that fail with
+	// ErrBadRequest are not retried. The default is 3; set a negative value to
+	// disable retries.
+	MaxRetries int
+
+	// RetryDelay sets the initial retry delay. The default is 1 second.
+	RetryDelay time.Duration
+
+	// FinalFlushTimeout sets the maximum duration of the final flush that Run
+	// performs when its context is canceled. The default is 10 seconds.
+	FinalFlushTimeout time.Duration
+
+	// DropLogger, if set, is called when samples are dropped, either due to
+	// the overflow policy or because an insert failed after all retries. The
+	// err parameter holds the cause. DropLogger may be called from multiple
+	// goroutines at once.
+	DropLogger func(count int, err error)
+
+	lock     sync.Mutex
+	cond     *sync.Cond
+	samples  []ingestorSample
+	flushNow chan struct{}
+	running  int
+}
+
+type ingestorSample struct {
+	input string
+	t     fields.Timestamp
+	v     float64
+}
+
+// Add buffers a single sample for the signal with the given input key. When
+// Add blocks due to the overflow policy, ctx cancellation makes it return
+// ctx.Err().
+func (ing *Ingestor) Add(ctx context.Context, input string, t fields.Timestamp, v float64) error {
+	dropped, err := ing.add(ctx, ingestorSample{input: input, t: t, v: v})
+	if dropped > 0 {
+		ing.drop(dropped, ErrIngestorFull)
+	}
+	return err
+}
+
+// add buffers s, and returns the number of samples dropped by the overflow
+// policy.
+func (ing *Ingestor) add(ctx context.Context, s ingestorSample) (dropped int, err error) {
+	ing.lock.Lock()
+	defer ing.lock.Unlock()
+	ing.init()
+
+	var stop func() bool
+
+	for len(ing.samples) >= ing.maxBuffered() {
+		switch ing.Overflow {
+		case OverflowReject:
+			return dropped + 1, ErrIngestorFull
+		case OverflowDropOldest:
+			ing.samples = ing.samples[1:]
+			dropped++
+		default:
+			if ing.running == 0 {
+				return dropped, ErrIngestorStopped
+			}
+			if err := ctx.Err(); err != nil {
+				return dropped, err
+			}
+			if stop == nil {
+				// Wake up the wait below on cancellation.
+				stop = context.AfterFunc(ctx, func() {
+					ing.lock.Lock()
+					ing.cond.Broadcast()
+					ing.lock.Unlock()
+				})
+				defer stop()
+			}
+			ing.signalFlush()
+			ing.cond.Wait()
+		}
+	}
+	ing.samples = append(ing.samples, s)
+	if len(ing.samples) >= ing.flushSize() {
+		ing.signalFlush()
+	}
+	return dropped, nil
+}
+
+// Buffered returns the number of buffered samples.
+func (ing *Ingestor) Buffered() int {
+	ing.lock.Lock()
+	defer ing.lock.Unlock()
+	return len(ing.samples)
+}
+
+// Run flushes buffered samples until ctx is canceled. On cancellation, a final
+// flush is performed without retries before Run returns ctx.Err(). The final
+// flush is limited by FinalFlushTimeout.
+func (ing *Ingestor) Run(ctx context.Context) error {
+	ing.lock.Lock()
+	ing.init()
+	flushNow := ing.flushNow
+	ing.running++
+	ing.lock.Unlock()
+	defer func() {
+		// Wake up blocked calls to Add, so that they can detect that Run has
+		// returned.
+		ing.lock.Lock()
+		ing.running--
+		ing.cond.Broadcast()
+		ing.lock.Unlock()
+	}()
+
+	t := time.NewTicker(ing.flushInterval())
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ing.finalFlushTimeout())
+			ing.flush(flushCtx, false)
+			cancel()
+			return ctx.Err()
+		case <-t.C:
+		case <-flushNow:
+		}
+		ing.flush(ctx, true)
+	}
+}
+
+// Flush inserts all currently buffered samples, retrying on failure. Samples
+// that could not be inserted are dropped, and the last error is returned.
+func (ing *Ingestor) Flush(ctx context.Context) error {
+	return ing.flush(ctx, true)
+}
+
+func (ing *Ingestor) flush(ctx context.Context, retry bool) error {
+	ing.lock.Lock()
+	ing.init()
+	samples := ing.samples
+	ing.samples = nil
+	ing.cond.Broadcast()
+	ing.lock.Unlock()
+
+	if len(samples) == 0 {
+		return nil
+	}
+
+	df := make(views.DataFrame)
+	for _, s := range samples {
+		series, ok := df[s.input]
+		if !ok {
+			series = make(views.DataSeries)
+			df[s.input] = series
+		}
+		series[s.t] = s.v
+	}
+
+	err := ing.insert(ctx, df, retry)
+	if err != nil {
+		ing.drop(len(samples), err)
+	}
+	return err
+}
+
+func (ing *Ingestor) insert(ctx context.Context, df views.DataFrame, retry bool) error {
+	delay := ing.retryDelay()
+	for attempt := 0; ; attempt++ {
+		_, err := ing.Client.Insert(df).Do(jsonrpc.ContextWithAttempt(ctx, attempt+1))
+		switch {
+		case err == nil:
+			return nil
+		case !retry, attempt >= ing.maxRetries(), errors.Is(err, ErrBadRequest), ctx.Err() != nil:
+			return err
+		}
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+		case <-t.C:
+		}
+		delay *= 2
+	}
+}
+
+// init initializes internal state. The caller must hold the lock.
+func (ing *Ingestor) init() {
+	if ing.cond == nil {
+		ing.cond = sync.NewCond(&ing.lock)
+		ing.flushNow = make(chan struct{}, 1)
+	}
+}
+
+// signalFlush requests a flush without blocking. The caller must hold the
+// lock.
+func (ing *Ingestor) signalFlush() {
+	select {
+	case ing.flushNow <- struct{}{}:
+	default:
+	}
+}
+
+// drop reports dropped samples. The caller must not hold the lock, so that
+// the DropLogger can call methods on ing.
+func (ing *Ingestor) drop(count int, err error) {
+	if ing.DropLogger != nil {
+		ing.DropLogger(count, err)
+	}
+}
+
+func (ing *Ingestor) flushSize() int {
+	if ing.FlushSize <= 0 {
+		return defaultIngestorFlushSize
+	}
+	return ing.FlushSize
+}
+
+func (ing *Ingestor) flushInterval() time.Duration {
+	if ing.FlushInterval <= 0 {
+		return defaultIngestorFlushInterval
+	}
+	return ing.FlushInterval
+}
+
+func (ing *Ingestor) maxBuffered() int {
+	if ing.MaxBuffered <= 0 {
+		return 10 * ing.flushSize()
+	}
+	return ing.MaxBuffered
+}
+
+func (ing *Ingestor) maxRetries() int {
+	switch {
+	case ing.MaxRetries < 0:
+		return 0
+	case ing.MaxRetries == 0:
+		return defaultIngestorRetries
+	}
+	return ing.MaxRetries
+}
+
+func (ing *Ingestor) finalFlushTimeout() time.Duration {
+	if ing.FinalFlushTimeout <= 0 {
+		return defaultIngestorFlushTimeout
+	}
+	return ing.FinalFlushTimeout
+}
+
+func (ing *Ingestor) retryDelay() time.Duration {
+	if ing.RetryDelay <= 0 {
+		return defaultIngestorRetryDelay
+	}
+	return ing.RetryDelay
+}
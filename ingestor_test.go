@@ -0,0 +1,245 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clarify_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+// insertRecorder records inserted data, optionally failing the first n
+// requests.
+type insertRecorder struct {
+	lock     sync.Mutex
+	failures int
+	calls    int
+	samples  int
+}
+
+func (rec *insertRecorder) Do(ctx context.Context, req jsonrpc.Request, result any) error {
+	rec.lock.Lock()
+	defer rec.lock.Unlock()
+	rec.calls++
+	if rec.failures > 0 {
+		rec.failures--
+		return clarify.HTTPError{StatusCode: 503}
+	}
+	for _, series := range req.Params.(map[string]any)["data"].(views.DataFrame) {
+		rec.samples += len(series)
+	}
+	return nil
+}
+
+func (rec *insertRecorder) counts() (calls, samples int) {
+	rec.lock.Lock()
+	defer rec.lock.Unlock()
+	return rec.calls, rec.samples
+}
+
+func TestIngestorRun(t *testing.T) {
+	rec := &insertRecorder{}
+	ing := &clarify.Ingestor{
+		Client:        clarify.NewClient("integration", rec),
+		FlushSize:     10,
+		FlushInterval: time.Hour,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- ing.Run(ctx) }()
+
+	ts := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var wg sync.WaitGroup
+	for i := range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 5 {
+				if err := ing.Add(context.Background(), "input", ts.Add(time.Duration(i*5+j)*time.Second), 1); err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Unexpected Run error:\n got: %v\nwant: %v", err, context.Canceled)
+	}
+	if _, samples := rec.counts(); samples != 25 {
+		t.Errorf("Unexpected number of inserted samples:\n got: %d\nwant: %d", samples, 25)
+	}
+}
+
+func TestIngestorOverflow(t *testing.T) {
+	var dropped int
+	ing := &clarify.Ingestor{
+		Client:      clarify.NewClient("integration", &insertRecorder{}),
+		MaxBuffered: 2,
+		Overflow:    clarify.OverflowReject,
+		DropLogger:  func(count int, err error) { dropped += count },
+	}
+	ts := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	for i := range 3 {
+		err := ing.Add(context.Background(), "input", ts.Add(time.Duration(i)*time.Second), 1)
+		if i < 2 && err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if i == 2 && !errors.Is(err, clarify.ErrIngestorFull) {
+			t.Fatalf("Unexpected error:\n got: %v\nwant: %v", err, clarify.ErrIngestorFull)
+		}
+	}
+
+	ing.Overflow = clarify.OverflowDropOldest
+	if err := ing.Add(context.Background(), "input", ts.Add(3*time.Second), 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n := ing.Buffered(); n != 2 {
+		t.Errorf("Unexpected number of buffered samples:\n got: %d\nwant: %d", n, 2)
+	}
+	if dropped != 2 {
+		t.Errorf("Unexpected number of dropped samples:\n got: %d\nwant: %d", dropped, 2)
+	}
+}
+
+func TestIngestorBlockStopped(t *testing.T) {
+	var dropped, buffered int
+	var ing *clarify.Ingestor
+	ing = &clarify.Ingestor{
+		Client:      clarify.NewClient("integration", &insertRecorder{}),
+		MaxBuffered: 1,
+		DropLogger: func(count int, err error) {
+			// Calling methods on the ingestor must not deadlock.
+			dropped += count
+			buffered = ing.Buffered()
+		},
+	}
+	ts := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err := ing.Add(context.Background(), "input", ts, 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := ing.Add(context.Background(), "input", ts.Add(time.Second), 1); !errors.Is(err, clarify.ErrIngestorStopped) {
+		t.Fatalf("Unexpected error:\n got: %v\nwant: %v", err, clarify.ErrIngestorStopped)
+	}
+
+	ing.Overflow = clarify.OverflowDropOldest
+	if err := ing.Add(context.Background(), "input", ts.Add(2*time.Second), 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if dropped != 1 {
+		t.Errorf("Unexpected number of dropped samples:\n got: %d\nwant: %d", dropped, 1)
+	}
+	if buffered != 1 {
+		t.Errorf("Unexpected number of buffered samples:\n got: %d\nwant: %d", buffered, 1)
+	}
+}
+
+func TestIngestorBlockCanceled(t *testing.T) {
+	release := make(chan struct{})
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		<-release
+		return nil
+	})
+	ing := &clarify.Ingestor{
+		Client:        clarify.NewClient("integration", h),
+		FlushInterval: time.Hour,
+		MaxBuffered:   1,
+	}
+	runCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- ing.Run(runCtx) }()
+	defer func() {
+		close(release)
+		cancel()
+		<-done
+	}()
+
+	ts := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx := context.Background()
+	if err := ing.Add(ctx, "input", ts, 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Wait for Run to start and make room with a flush, which then blocks in
+	// the insert.
+	for {
+		err := ing.Add(ctx, "input", ts.Add(time.Second), 1)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, clarify.ErrIngestorStopped) {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancelAdd := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancelAdd()
+	if err := ing.Add(ctx, "input", ts.Add(2*time.Second), 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestIngestorFinalFlushTimeout(t *testing.T) {
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		// Simulate an unresponsive server.
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	var dropErr error
+	ing := &clarify.Ingestor{
+		Client:            clarify.NewClient("integration", h),
+		FlushInterval:     time.Hour,
+		FinalFlushTimeout: 10 * time.Millisecond,
+		DropLogger:        func(count int, err error) { dropErr = err },
+	}
+	ts := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err := ing.Add(context.Background(), "input", ts, 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := ing.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Unexpected Run error:\n got: %v\nwant: %v", err, context.Canceled)
+	}
+	if !errors.Is(dropErr, context.DeadlineExceeded) {
+		t.Errorf("Unexpected drop error:\n got: %v\nwant: %v", dropErr, context.DeadlineExceeded)
+	}
+}
+
+func TestIngestorFlushRetry(t *testing.T) {
+	rec := &insertRecorder{failures: 2}
+	ing := &clarify.Ingestor{
+		Client:     clarify.NewClient("integration", rec),
+		RetryDelay: time.Millisecond,
+	}
+	ts := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err := ing.Add(context.Background(), "input", ts, 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := ing.Flush(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls, samples := rec.counts(); calls != 3 || samples != 1 {
+		t.Errorf("Unexpected insert counts:\n got: calls=%d samples=%d\nwant: calls=3 samples=1", calls, samples)
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/internal/ingest"
+	"github.com/clarify/clarify-go/views"
+)
+
+// Errors returned by the bridge.
+const (
+	ErrNoMapping    ingest.Error = "no mapping for topic"
+	ErrBadTopic     ingest.Error = "bad topic filter"
+	ErrBadMapping   ingest.Error = "bad mapping"
+	ErrBadValue                  = ingest.ErrBadValue
+	ErrBadTimestamp              = ingest.ErrBadTime
+	ErrBadInput                  = views.ErrBadInputKey
+)
+
+// Mapping describe how to map messages on matching topics to signal inputs.
+type Mapping struct {
+	// Topic is an MQTT topic filter, which may contain the single-level (+)
+	// and multi-level (#) wildcards.
+	Topic string
+
+	// Input is a text/template for the signal input key. The template is
+	// executed with a TopicData value. If empty, the topic is used with "/"
+	// replaced by ".". Messages resulting in an invalid input key, see
+	// views.ValidateInputKey, are rejected with an error wrapping ErrBadInput.
+	Input string
+
+	// ValuePath is a dot-separated path to the value in a JSON object payload,
+	// such as "sensor.temperature" or "values.0". If empty, the whole payload
+	// is parsed as a value. Numbers, booleans and strings holding numbers or
+	// booleans are accepted, where booleans are converted to 0 or 1. A JSON
+	// null value is ignored.
+	ValuePath string
+
+	// TimePath is an optional dot-separated path to the sample time in a JSON
+	// object payload. Strings are parsed as RFC 3339 times, while numbers are
+	// parsed as Unix time in seconds. If empty, the time the message was
+	// handled is used, according to the clock of the ingestor's client. When
+	// set, ValuePath must also be set.
+	TimePath string
+}
+
+// TopicData is passed to Mapping.Input templates.
+type TopicData struct {
+	// Topic is the full message topic.
+	Topic string
+
+	// Levels contain the topic levels.
+	Levels []string
+
+	// Wildcards contain the topic levels matched by wildcards in the topic
+	// filter, in order. Levels matched by a multi-level wildcard are joined by
+	// "/" into a single element.
+	Wildcards []string
+}
+
+// Bridge maps MQTT messages to signal inputs, and adds them to an ingestor.
+// Use NewBridge to initialize a bridge.
+type Bridge struct {
+	ingestor *clarify.Ingestor
+	mappings []compiledMapping
+}
+
+type compiledMapping struct {
+	Mapping
+	filter []string
+	input  *template.Template
+}
+
+// NewBridge validates mappings, and returns a new bridge that adds samples to
+// ingestor. When a message matches several mappings, each mapping results in a
+// sample.
+func NewBridge(ingestor *clarify.Ingestor, mappings ...Mapping) (*Bridge, error) {
+	b := &Bridge{
+		ingestor: ingestor,
+		mappings: make([]compiledMapping, 0, len(mappings)),
+	}
+	for i, m := range mappings {
+		filter := strings.Split(m.Topic, "/")
+		for j, level := range filter {
+			switch {
+			case level == "#" && j != len(filter)-1,
+				level != "#" && level != "+" && strings.ContainsAny(level, "#+"):
+				return nil, fmt.Errorf("mappings[%d]: %w: %q", i, ErrBadTopic, m.Topic)
+			}
+		}
+		if m.TimePath != "" && m.ValuePath == "" {
+			return nil, fmt.Errorf("mappings[%d]: %w: TimePath requires ValuePath", i, ErrBadMapping)
+		}
+		cm := compiledMapping{Mapping: m, filter: filter}
+		if m.Input != "" {
+			tmpl, err := template.New(m.Topic).Option("missingkey=error").Parse(m.Input)
+			if err != nil {
+				return nil, fmt.Errorf("mappings[%d]: %w", i, err)
+			}
+			cm.input = tmpl
+		}
+		b.mappings = append(b.mappings, cm)
+	}
+	return b, nil
+}
+
+// HandleMessage maps a single message, and adds the resulting samples to the
+// ingestor. The method is safe for concurrent use, and is suitable for calling
+// from the message callback of an MQTT client library. An error wrapping
+// ErrNoMapping is returned if no mappings match the topic. When a mapping
+// fails, the remaining mappings are still handled, and the errors of all
+// failed mappings are returned. When adding a sample blocks, as determined by
+// the ingestor's overflow policy, ctx cancellation aborts the wait.
+func (b *Bridge) HandleMessage(ctx context.Context, topic string, payload []byte) error {
+	levels := strings.Split(topic, "/")
+	var matched bool
+	var errs []error
+	for i, m := range b.mappings {
+		wildcards, ok := matchTopic(m.filter, levels)
+		if !ok {
+			continue
+		}
+		matched = true
+		data := TopicData{Topic: topic, Levels: levels, Wildcards: wildcards}
+		if err := b.handle(ctx, m, data, payload); err != nil {
+			errs = append(errs, fmt.Errorf("mappings[%d]: %w", i, err))
+		}
+	}
+	switch {
+	case !matched:
+		return fmt.Errorf("%w: %s", ErrNoMapping, topic)
+	case len(errs) > 0:
+		return fmt.Errorf("topic %s: %w", topic, errors.Join(errs...))
+	}
+	return nil
+}
+
+func (b *Bridge) handle(ctx context.Context, m compiledMapping, data TopicData, payload []byte) error {
+	input := strings.ReplaceAll(data.Topic, "/", ".")
+	if m.input != nil {
+		var buf strings.Builder
+		if err := m.input.Execute(&buf, data); err != nil {
+			return err
+		}
+		input = buf.String()
+	}
+	if err := views.ValidateInputKey(input); err != nil {
+		return err
+	}
+
+	var doc any
+	if m.ValuePath != "" {
+		dec := json.NewDecoder(bytes.NewReader(payload))
+		dec.UseNumber()
+		if err := dec.Decode(&doc); err != nil {
+			return fmt.Errorf("%w: %v", ErrBadValue, err)
+		}
+	}
+
+	raw := any(string(bytes.TrimSpace(payload)))
+	if m.ValuePath != "" {
+		raw = lookupPath(doc, m.ValuePath)
+	}
+	if raw == nil {
+		return nil
+	}
+	v, err := ingest.ParseValue(raw)
+	if err != nil {
+		return err
+	}
+
+	t := b.ingestor.Client.Now()
+	if m.TimePath != "" {
+		if t, err = ingest.ParseTime(lookupPath(doc, m.TimePath)); err != nil {
+			return err
+		}
+	}
+	return b.ingestor.Add(ctx, input, fields.AsTimestamp(t), v)
+}
+
+// matchTopic returns the levels matched by wildcards in filter, and whether
+// the topic levels match the filter.
+func matchTopic(filter, levels []string) ([]string, bool) {
+	var wildcards []string
+	for i, f := range filter {
+		switch {
+		case f == "#":
+			return append(wildcards, strings.Join(levels[i:], "/")), true
+		case i >= len(levels):
+			return nil, false
+		case f == "+":
+			wildcards = append(wildcards, levels[i])
+		case f != levels[i]:
+			return nil, false
+		}
+	}
+	return wildcards, len(filter) == len(levels)
+}
+
+// lookupPath returns the value at the dot-separated path in doc, or nil if not
+// found.
+func lookupPath(doc any, path string) any {
+	for _, key := range strings.Split(path, ".") {
+		switch node := doc.(type) {
+		case map[string]any:
+			doc = node[key]
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil
+			}
+			doc = node[i]
+		default:
+			return nil
+		}
+	}
+	return doc
+}
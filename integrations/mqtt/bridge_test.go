@@ -0,0 +1,131 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go"
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/integrations/mqtt"
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestBridge(t *testing.T) {
+	inserted := make(views.DataFrame)
+	h := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		for k, s := range req.Params.(map[string]any)["data"].(views.DataFrame) {
+			inserted[k] = s
+		}
+		return nil
+	})
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := clarify.NewClient("integration", h, clarify.WithClock(func() time.Time { return t0 }))
+	ing := &clarify.Ingestor{Client: client}
+	bridge, err := mqtt.NewBridge(ing,
+		mqtt.Mapping{
+			Topic:     "sites/+/sensors/#",
+			Input:     `{{index .Wildcards 0}}_{{index .Wildcards 1}}`,
+			ValuePath: "data.value",
+			TimePath:  "data.time",
+		},
+		mqtt.Mapping{Topic: "raw/+"},
+		mqtt.Mapping{Topic: "multi", Input: "multi_a", ValuePath: "a"},
+		mqtt.Mapping{Topic: "multi", Input: "multi_b", ValuePath: "b"},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	messages := []struct {
+		topic   string
+		payload string
+	}{
+		{"sites/oslo/sensors/temp", `{"data":{"value":21.5,"time":"2024-01-01T00:00:00Z"}}`},
+		{"sites/oslo/sensors/door/open", `{"data":{"value":true,"time":1704067260}}`},
+		{"sites/bergen/sensors/temp", `{"data":{"value":null,"time":"2024-01-01T00:00:00Z"}}`},
+	}
+	for _, msg := range messages {
+		if err := bridge.HandleMessage(ctx, msg.topic, []byte(msg.payload)); err != nil {
+			t.Errorf("Unexpected error for %s: %v", msg.topic, err)
+		}
+	}
+	if err := bridge.HandleMessage(ctx, "raw/a", []byte(" 42 ")); err != nil {
+		t.Errorf("Unexpected error for raw/a: %v", err)
+	}
+
+	if err := bridge.HandleMessage(ctx, "other", []byte("1")); !errors.Is(err, mqtt.ErrNoMapping) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, mqtt.ErrNoMapping)
+	}
+	if err := bridge.HandleMessage(ctx, "raw/b", []byte(`"x"`)); !errors.Is(err, mqtt.ErrBadValue) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, mqtt.ErrBadValue)
+	}
+	if err := bridge.HandleMessage(ctx, "raw/b c", []byte("1")); !errors.Is(err, mqtt.ErrBadInput) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, mqtt.ErrBadInput)
+	}
+
+	// A failed mapping doesn't prevent other mappings from being handled.
+	if err := bridge.HandleMessage(ctx, "multi", []byte(`{"a":"x","b":2}`)); !errors.Is(err, mqtt.ErrBadValue) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, mqtt.ErrBadValue)
+	}
+
+	if err := ing.Flush(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect := map[string]map[fields.Timestamp]float64{
+		"oslo_temp":      {fields.AsTimestamp(t0): 21.5},
+		"oslo_door/open": {fields.AsTimestamp(t0.Add(time.Minute)): 1},
+	}
+	for k, series := range expect {
+		for ts, v := range series {
+			if got, ok := inserted[k][ts]; !ok || got != v {
+				t.Errorf("Unexpected value for %s at %v:\n got: %v (%t)\nwant: %v", k, ts.Time(), got, ok, v)
+			}
+		}
+	}
+	if v, ok := inserted["raw.a"][fields.AsTimestamp(t0)]; !ok || v != 42 {
+		t.Errorf("Unexpected value for raw.a at client time:\n got: %v (%t)\nwant: 42", v, ok)
+	}
+	if l := len(inserted["multi_b"]); l != 1 {
+		t.Errorf("Unexpected number of values for multi_b:\n got: %d\nwant: 1", l)
+	}
+	if _, ok := inserted["raw.b c"]; ok {
+		t.Errorf("Expected bad input key to be skipped")
+	}
+	if _, ok := inserted["bergen_temp"]; ok {
+		t.Errorf("Expected null value to be skipped")
+	}
+}
+
+func TestNewBridgeBadMapping(t *testing.T) {
+	_, err := mqtt.NewBridge(nil, mqtt.Mapping{Topic: "a", TimePath: "time"})
+	if !errors.Is(err, mqtt.ErrBadMapping) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, mqtt.ErrBadMapping)
+	}
+}
+
+func TestNewBridgeBadTopic(t *testing.T) {
+	for _, topic := range []string{"a/#/b", "a/b+"} {
+		if _, err := mqtt.NewBridge(nil, mqtt.Mapping{Topic: topic}); !errors.Is(err, mqtt.ErrBadTopic) {
+			t.Errorf("Unexpected error for %q:\n got: %v\nwant: %v", topic, err, mqtt.ErrBadTopic)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mqtt provides a bridge for ingesting MQTT messages into Clarify.
+// Messages are mapped to signal input keys and values via a list of mappings,
+// and are inserted through a clarify.Ingestor.
+//
+// To avoid a dependency on a particular MQTT client library, the package does
+// not connect to a broker itself. Instead, pass received messages to
+// Bridge.HandleMessage from the client library of your choice. E.g. with the
+// Eclipse Paho client, where ctx is canceled on shutdown:
+//
+//	token := client.Subscribe("sensors/#", 1, func(_ paho.Client, msg paho.Message) {
+//		_ = bridge.HandleMessage(ctx, msg.Topic(), msg.Payload())
+//	})
+package mqtt
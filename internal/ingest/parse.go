@@ -0,0 +1,103 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ingest contains helpers that are shared by the packages that ingest
+// data from external sources, such as ingest/csvloader, ingest/httpreceiver
+// and integrations/mqtt.
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Error describe a constant error. It allows the ingest packages to declare
+// their sentinel errors as constants of the same type as the errors below.
+type Error string
+
+func (err Error) Error() string { return string(err) }
+
+// Parse errors.
+const (
+	ErrBadTime  Error = "bad time"
+	ErrBadValue Error = "bad value"
+)
+
+// UnixTime returns the time that is sec seconds since the Unix epoch. The
+// fraction of sec is kept with nanosecond precision.
+func UnixTime(sec float64) time.Time {
+	whole, frac := math.Modf(sec)
+	return time.Unix(int64(whole), int64(frac*1e9))
+}
+
+// ParseUnixTime parses s as a Unix time in seconds, with an optional fraction.
+// Errors wrap ErrBadTime.
+func ParseUnixTime(s string) (time.Time, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrBadTime, s)
+	}
+	return UnixTime(f), nil
+}
+
+// ParseTime parses a decoded JSON value as a time. Strings are parsed as RFC
+// 3339 times, or as Unix times in seconds. Numbers must be decoded as
+// json.Number, and are parsed as Unix times in seconds. Errors wrap
+// ErrBadTime.
+func ParseTime(raw any) (time.Time, error) {
+	switch v := raw.(type) {
+	case json.Number:
+		return ParseUnixTime(string(v))
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t, nil
+		}
+		return ParseUnixTime(v)
+	}
+	return time.Time{}, fmt.Errorf("%w: unsupported type %T", ErrBadTime, raw)
+}
+
+// ParseFloat parses s as a finite floating point number. Errors wrap
+// ErrBadValue.
+func ParseFloat(s string) (float64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%w: %q", ErrBadValue, s)
+	}
+	return f, nil
+}
+
+// ParseValue parses a decoded JSON value as a finite floating point number.
+// Numbers must be decoded as json.Number. Strings are parsed as numbers, or as
+// booleans. Booleans are converted to 0 or 1. Errors wrap ErrBadValue.
+func ParseValue(raw any) (float64, error) {
+	switch v := raw.(type) {
+	case json.Number:
+		return ParseFloat(string(v))
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return ParseValue(b)
+		}
+		return ParseFloat(v)
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return 0, fmt.Errorf("%w: unsupported type %T", ErrBadValue, raw)
+}
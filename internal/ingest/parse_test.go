@@ -0,0 +1,68 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingest_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/internal/ingest"
+)
+
+func TestParseTime(t *testing.T) {
+	test := func(raw any, expect time.Time, expectErr error) func(t *testing.T) {
+		return func(t *testing.T) {
+			result, err := ingest.ParseTime(raw)
+			if !errors.Is(err, expectErr) {
+				t.Fatalf("Unexpected error:\n got: %v\nwant: %v", err, expectErr)
+			}
+			if !result.Equal(expect) {
+				t.Errorf("Unexpected result:\n got: %v\nwant: %v", result, expect)
+			}
+		}
+	}
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 500_000_000, time.UTC)
+
+	t.Run("RFC 3339", test("2024-01-01T00:00:00.5Z", t0, nil))
+	t.Run("unix string", test("1704067200.5", t0, nil))
+	t.Run("unix number", test(json.Number("1704067200.5"), t0, nil))
+	t.Run("NaN", test("NaN", time.Time{}, ingest.ErrBadTime))
+	t.Run("bad string", test("yesterday", time.Time{}, ingest.ErrBadTime))
+	t.Run("bad type", test(true, time.Time{}, ingest.ErrBadTime))
+}
+
+func TestParseValue(t *testing.T) {
+	test := func(raw any, expect float64, expectErr error) func(t *testing.T) {
+		return func(t *testing.T) {
+			result, err := ingest.ParseValue(raw)
+			if !errors.Is(err, expectErr) {
+				t.Fatalf("Unexpected error:\n got: %v\nwant: %v", err, expectErr)
+			}
+			if result != expect {
+				t.Errorf("Unexpected result:\n got: %v\nwant: %v", result, expect)
+			}
+		}
+	}
+
+	t.Run("number", test(json.Number("21.5"), 21.5, nil))
+	t.Run("string", test("21.5", 21.5, nil))
+	t.Run("bool string", test("true", 1, nil))
+	t.Run("bool", test(false, 0, nil))
+	t.Run("infinite", test("+Inf", 0, ingest.ErrBadValue))
+	t.Run("bad string", test("n/a", 0, ingest.ErrBadValue))
+	t.Run("bad type", test(map[string]any{}, 0, ingest.ErrBadValue))
+}
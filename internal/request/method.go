@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -16,10 +16,18 @@ package request
 
 import (
 	"context"
+	"time"
 
 	"github.com/clarify/clarify-go/jsonrpc"
 )
 
+// APIVersionHandler describes a handler that overrides the default API version
+// for all requests it handles. A request-level override takes precedence.
+type APIVersionHandler interface {
+	jsonrpc.Handler
+	APIVersion() string
+}
+
 // Method is a constructor for an RPC request for a specific RPC method and API
 // version.
 type Method[R any] struct {
@@ -39,32 +47,67 @@ func (cfg Method[R]) NewRequest(h jsonrpc.Handler, params ...jsonrpc.Param) Requ
 
 // Request describe an initialized RPC request with access to a request handler.
 type Request[R any] struct {
-	apiVersion string
-	method     string
+	apiVersion         string
+	apiVersionOverride string
+	method             string
 
 	baseParams []jsonrpc.Param
+	timeout    time.Duration
 
 	h jsonrpc.Handler
 }
 
+// APIVersion returns a request that is sent with the specified API version
+// instead of the default version for the method. An empty string resets to the
+// default.
+func (req Request[R]) APIVersion(version string) Request[R] {
+	req.apiVersionOverride = version
+	return req
+}
+
+// Timeout returns a request where the context passed to Do is given the
+// specified timeout for the duration of the call. This allows individual calls
+// to fail faster, or run longer, than other calls made with the same client.
+// A value <= 0 resets to no timeout.
+func (req Request[R]) Timeout(d time.Duration) Request[R] {
+	req.timeout = d
+	return req
+}
+
 // Do performs the request against the server and returns the result.
 func (req Request[R]) Do(ctx context.Context) (*R, error) {
 	return req.do(ctx)
 }
 
 func (req Request[R]) do(ctx context.Context, params ...jsonrpc.Param) (*R, error) {
+	var res R
+	if err := req.doInto(ctx, &res, params...); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// doInto performs the request, and decodes the result into result, which
+// must be a pointer.
+func (req Request[R]) doInto(ctx context.Context, result any, params ...jsonrpc.Param) error {
 	allParams := make([]jsonrpc.Param, 0, len(req.baseParams)+len(params))
 	allParams = append(allParams, req.baseParams...)
 	allParams = append(allParams, params...)
 
 	rpcReq := jsonrpc.NewRequest(req.method, allParams...)
-	if req.apiVersion != "" {
+	switch v, _ := req.h.(APIVersionHandler); {
+	case req.apiVersionOverride != "":
+		rpcReq.APIVersion = req.apiVersionOverride
+	case v != nil && v.APIVersion() != "":
+		rpcReq.APIVersion = v.APIVersion()
+	case req.apiVersion != "":
 		rpcReq.APIVersion = req.apiVersion
 	}
 
-	var res R
-	if err := req.h.Do(ctx, rpcReq, &res); err != nil {
-		return nil, err
+	if req.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.timeout)
+		defer cancel()
 	}
-	return &res, nil
+	return req.h.Do(ctx, rpcReq, result)
 }
@@ -1,4 +1,4 @@
-// Copyright 2023-2024 Searis AS
+// Copyright 2023-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -16,12 +16,16 @@ package request
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
 )
 
 const (
 	includeParam jsonrpc.ParamName = "include"
+	formatParam  jsonrpc.ParamName = "format"
 )
 
 // RelationalMethod is a constructor for an RPC request for a specific RPC
@@ -48,6 +52,7 @@ func (cfg RelationalMethod[R]) NewRequest(h jsonrpc.Handler, params ...jsonrpc.P
 type Relational[R any] struct {
 	parent  Request[R]
 	include []string
+	format  *views.SelectionFormat
 }
 
 // Include returns a request that appends the named relationships to the
@@ -60,13 +65,50 @@ func (req Relational[R]) Include(relationships ...string) Relational[R] {
 	return req
 }
 
+// APIVersion returns a request that is sent with the specified API version
+// instead of the default version for the method. An empty string resets to the
+// default.
+func (req Relational[R]) APIVersion(version string) Relational[R] {
+	req.parent = req.parent.APIVersion(version)
+	return req
+}
+
+// Timeout returns a request where the context passed to Do is given the
+// specified timeout for the duration of the call. A value <= 0 resets to no
+// timeout.
+func (req Relational[R]) Timeout(d time.Duration) Relational[R] {
+	req.parent = req.parent.Timeout(d)
+	return req
+}
+
+// Format returns a request that is sent with the specified selection format,
+// overriding the default format for the method. Note that the result type of
+// Do is only guaranteed to decode the default format; use DoRaw to decode
+// results in other formats.
+func (req Relational[R]) Format(format views.SelectionFormat) Relational[R] {
+	req.format = &format
+	return req
+}
+
 // Do performs the request against the server and returns the result.
 func (req Relational[R]) Do(ctx context.Context) (*R, error) {
-	return req.do(ctx, includeParam.Value(req.include))
+	return req.parent.do(ctx, req.params()...)
 }
 
-func (req Relational[R]) do(ctx context.Context, params ...jsonrpc.Param) (*R, error) {
-	arr := append(params, includeParam.Value(req.include))
+// DoRaw performs the request against the server and returns the undecoded
+// result.
+func (req Relational[R]) DoRaw(ctx context.Context) (json.RawMessage, error) {
+	var res json.RawMessage
+	if err := req.parent.doInto(ctx, &res, req.params()...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
 
-	return req.parent.do(ctx, arr...)
+func (req Relational[R]) params() []jsonrpc.Param {
+	params := []jsonrpc.Param{includeParam.Value(req.include)}
+	if req.format != nil {
+		params = append(params, formatParam.Value(*req.format))
+	}
+	return params
 }
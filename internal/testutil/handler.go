@@ -0,0 +1,32 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testutil provides helpers that are shared between tests.
+package testutil
+
+import (
+	"context"
+
+	"github.com/clarify/clarify-go/jsonrpc"
+)
+
+// HandlerFunc allows a function to be used as a jsonrpc.Handler, e.g. to
+// mock the Clarify API in tests.
+type HandlerFunc func(ctx context.Context, req jsonrpc.Request, result any) error
+
+var _ jsonrpc.Handler = HandlerFunc(nil)
+
+func (f HandlerFunc) Do(ctx context.Context, req jsonrpc.Request, result any) error {
+	return f(ctx, req, result)
+}
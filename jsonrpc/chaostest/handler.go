@@ -0,0 +1,155 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chaostest offers a handler for injecting failures into JSON RPC
+// requests, such as latency, dropped responses and error codes. This allows
+// testing that automation routines and retry logic behave correctly under
+// degraded conditions. Wrap the handler used by the client under test:
+//
+//	h := &chaostest.Handler{
+//		Next: next,
+//		Faults: []chaostest.Fault{
+//			{Methods: []string{"integration.insert"}, Probability: 0.1, Err: chaostest.ServerError(-32015, "Try again")},
+//			{Probability: 0.5, Latency: 2 * time.Second},
+//		},
+//	}
+//	client := clarify.NewClient(integrationID, h)
+package chaostest
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/clarify/clarify-go/jsonrpc"
+)
+
+// ErrDropped is returned for requests where the response is dropped.
+const ErrDropped strError = "chaostest: response dropped"
+
+type strError string
+
+func (err strError) Error() string { return string(err) }
+
+// Fault describe a failure to inject into matching requests.
+type Fault struct {
+	// Methods lists the RPC methods the fault applies to. If empty, the fault
+	// applies to all methods.
+	Methods []string
+
+	// Probability sets the probability, in the range [0,1], that the fault is
+	// injected into a matching request.
+	Probability float64
+
+	// Latency sets a delay to add before the request is handled. The delay is
+	// aborted if the request context is done.
+	Latency time.Duration
+
+	// Drop, if true, passes the request on to the next handler, but discards
+	// the response and returns ErrDropped. This simulates a response that is
+	// lost after the server has handled the request.
+	Drop bool
+
+	// Err, if set, is returned without passing the request on to the next
+	// handler.
+	Err error
+}
+
+func (f Fault) matches(method string) bool {
+	return len(f.Methods) == 0 || slices.Contains(f.Methods, method)
+}
+
+var _ jsonrpc.Handler = (*Handler)(nil)
+
+// Handler wraps another handler and injects faults into requests. For each
+// request, all matching faults are evaluated in order. Latencies of injected
+// faults are added together, and the first injected fault with Err or Drop set
+// decides the outcome.
+//
+// A Handler must not be copied after first use.
+type Handler struct {
+	// Next is the handler to wrap.
+	Next jsonrpc.Handler
+
+	// Faults lists the faults to inject.
+	Faults []Fault
+
+	// Rand, if set, returns a random number in the range [0,1) that is used
+	// to decide if a fault is injected. The default is rand.Float64. Set this
+	// to get deterministic tests.
+	Rand func() float64
+
+	injected atomic.Int64
+}
+
+// Injected returns the number of faults injected so far.
+func (h *Handler) Injected() int {
+	return int(h.injected.Load())
+}
+
+func (h *Handler) Do(ctx context.Context, req jsonrpc.Request, result any) error {
+	random := h.Rand
+	if random == nil {
+		random = rand.Float64
+	}
+
+	var latency time.Duration
+	var outcome *Fault
+	for i, f := range h.Faults {
+		if !f.matches(req.Method) || random() >= f.Probability {
+			continue
+		}
+		h.injected.Add(1)
+		latency += f.Latency
+		if outcome == nil && (f.Err != nil || f.Drop) {
+			outcome = &h.Faults[i]
+		}
+	}
+
+	if latency > 0 {
+		t := time.NewTimer(latency)
+		defer t.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+	switch {
+	case outcome == nil:
+		return h.Next.Do(ctx, req, result)
+	case outcome.Err != nil:
+		return outcome.Err
+	}
+	if err := h.Next.Do(ctx, req, result); err != nil {
+		return err
+	}
+	return ErrDropped
+}
+
+// ServerError returns a server error with the specified code and message, as
+// if returned by the RPC server. For example, code -32015 indicates rate
+// limiting, and -32603 an internal error.
+func ServerError(code int, message string) error {
+	return &jsonrpc.ServerError{Code: code, Message: message}
+}
+
+// HTTPError returns a transport-layer error with the specified HTTP status
+// code, as if returned by an HTTP handler.
+func HTTPError(statusCode int) error {
+	return jsonrpc.HTTPError{StatusCode: statusCode, Body: http.StatusText(statusCode)}
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaostest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/jsonrpc/chaostest"
+)
+
+func TestHandler(t *testing.T) {
+	var calls int
+	next := testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+		calls++
+		return nil
+	})
+	errTryAgain := errors.New("try again")
+
+	test := func(faults []chaostest.Fault, random float64, method string, expectErr error, expectCalls int) func(t *testing.T) {
+		return func(t *testing.T) {
+			calls = 0
+			h := &chaostest.Handler{
+				Next:   next,
+				Faults: faults,
+				Rand:   func() float64 { return random },
+			}
+			err := h.Do(context.Background(), jsonrpc.NewRequest(method), nil)
+			if !errors.Is(err, expectErr) {
+				t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, expectErr)
+			}
+			if calls != expectCalls {
+				t.Errorf("Unexpected calls to next handler:\n got: %d\nwant: %d", calls, expectCalls)
+			}
+		}
+	}
+	insertErr := []chaostest.Fault{{Methods: []string{"integration.insert"}, Probability: 0.5, Err: errTryAgain}}
+	drop := []chaostest.Fault{{Probability: 1, Drop: true}}
+
+	t.Run("error injected", test(insertErr, 0.4, "integration.insert", errTryAgain, 0))
+	t.Run("error not injected", test(insertErr, 0.6, "integration.insert", nil, 1))
+	t.Run("error other method", test(insertErr, 0, "clarify.selectItems", nil, 1))
+	t.Run("drop", test(drop, 0.99, "clarify.selectItems", chaostest.ErrDropped, 1))
+	t.Run("first outcome wins", test(append(drop, insertErr...), 0, "integration.insert", chaostest.ErrDropped, 1))
+}
+
+func TestServerError(t *testing.T) {
+	h := &chaostest.Handler{
+		Faults: []chaostest.Fault{{Probability: 1, Err: chaostest.ServerError(-32015, "Try again")}},
+	}
+	err := h.Do(context.Background(), jsonrpc.NewRequest("clarify.selectItems"), nil)
+	var serverErr *jsonrpc.ServerError
+	if !errors.As(err, &serverErr) {
+		t.Fatalf("Unexpected error type:\n got: %T\nwant: %T", err, serverErr)
+	}
+	if serverErr.Code != -32015 {
+		t.Errorf("Unexpected error code:\n got: %d\nwant: %d", serverErr.Code, -32015)
+	}
+	if !jsonrpc.IsTryAgain(err) {
+		t.Errorf("Expected IsTryAgain to report true for %v", err)
+	}
+}
+
+func TestHandlerLatency(t *testing.T) {
+	h := &chaostest.Handler{
+		Next: testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+			return nil
+		}),
+		Faults: []chaostest.Fault{{Probability: 1, Latency: time.Hour}},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := h.Do(ctx, jsonrpc.NewRequest("clarify.selectItems"), nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, context.DeadlineExceeded)
+	}
+	if n := h.Injected(); n != 1 {
+		t.Errorf("Unexpected injected count:\n got: %d\nwant: 1", n)
+	}
+
+	h.Faults[0].Latency = time.Millisecond
+	start := time.Now()
+	if err := h.Do(context.Background(), jsonrpc.NewRequest("clarify.selectItems"), nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if d := time.Since(start); d < time.Millisecond {
+		t.Errorf("Unexpected latency:\n got: %v\nwant: >= %v", d, time.Millisecond)
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by CircuitBreaker when requests are rejected
+// without being sent to the server.
+const ErrCircuitOpen strError = "circuit breaker is open"
+
+const (
+	defaultCircuitThreshold = 5
+	defaultCircuitCoolDown  = 30 * time.Second
+
+	// Server error code that indicate a degraded server, in addition to
+	// errors matched by IsTryAgain.
+	codeInternal = -32603
+)
+
+// CircuitState describes the state of a CircuitBreaker.
+type CircuitState string
+
+// Circuit breaker states.
+const (
+	// CircuitClosed indicates that requests are sent to the server.
+	CircuitClosed CircuitState = "closed"
+
+	// CircuitOpen indicates that requests are rejected with ErrCircuitOpen.
+	CircuitOpen CircuitState = "open"
+
+	// CircuitHalfOpen indicates that the cool-down period has passed, and
+	// that a single trial request is allowed to decide whether to close or
+	// reopen the circuit.
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+var _ Handler = (*CircuitBreaker)(nil)
+
+// CircuitBreaker wraps a handler to stop sending requests to a degraded server.
+// After Threshold consecutive failures, the circuit opens, and requests fail
+// fast with ErrCircuitOpen for the duration of CoolDown. After the cool-down, a
+// single trial request is let through; if it succeeds, the circuit closes,
+// otherwise it opens again.
+//
+// The zero-value is not usable; Handler must be set. A CircuitBreaker must not
+// be copied after first use.
+type CircuitBreaker struct {
+	// Handler is the handler to wrap.
+	Handler Handler
+
+	// Threshold sets the number of consecutive failures before the circuit
+	// opens. The default is 5.
+	Threshold int
+
+	// CoolDown sets for how long the circuit stays open before a trial request
+	// is allowed. The default is 30 seconds.
+	CoolDown time.Duration
+
+	// IsFailure, if set, decides which errors count as failures. The default
+	// is to count transport errors, HTTP errors with status 429 or 5xx, and
+	// server errors that indicate an internal error or rate limiting. Errors
+	// caused by the request context being done are never counted.
+	IsFailure func(error) bool
+
+	// StateLogger, if set, is called on each state change. The err parameter
+	// holds the error that caused the change, if any. StateLogger is called
+	// without holding the internal lock, and may be called from multiple
+	// goroutines at once.
+	StateLogger func(from, to CircuitState, err error)
+
+	lock     sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// State returns the current state of the circuit.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	return cb.currentState()
+}
+
+// Do sends req to the wrapped handler, unless the circuit is open.
+func (cb *CircuitBreaker) Do(ctx context.Context, req Request, result any) error {
+	if err := cb.acquire(); err != nil {
+		return err
+	}
+	err := cb.Handler.Do(ctx, req, result)
+	cb.release(ctx, err)
+	return err
+}
+
+// acquire returns ErrCircuitOpen if the request should be rejected.
+func (cb *CircuitBreaker) acquire() error {
+	cb.lock.Lock()
+	change, err := cb.acquireLocked()
+	cb.lock.Unlock()
+	cb.logChange(change)
+	return err
+}
+
+func (cb *CircuitBreaker) acquireLocked() (stateChange, error) {
+	switch cb.currentState() {
+	case CircuitOpen:
+		return stateChange{}, ErrCircuitOpen
+	case CircuitHalfOpen:
+		if cb.trial {
+			// Only one trial request is allowed at a time.
+			return stateChange{}, ErrCircuitOpen
+		}
+		cb.trial = true
+		return cb.setState(CircuitHalfOpen, nil), nil
+	}
+	return stateChange{}, nil
+}
+
+// release records the outcome of a request.
+func (cb *CircuitBreaker) release(ctx context.Context, err error) {
+	cb.lock.Lock()
+	change := cb.releaseLocked(ctx, err)
+	cb.lock.Unlock()
+	cb.logChange(change)
+}
+
+func (cb *CircuitBreaker) releaseLocked(ctx context.Context, err error) stateChange {
+	trial := cb.trial
+	cb.trial = false
+
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// Not the server's fault; don't count.
+	case err != nil && cb.isFailure(err):
+		cb.failures++
+		if trial || cb.failures >= cb.threshold() {
+			cb.openedAt = time.Now()
+			return cb.setState(CircuitOpen, err)
+		}
+	default:
+		cb.failures = 0
+		return cb.setState(CircuitClosed, nil)
+	}
+	return stateChange{}
+}
+
+// currentState returns the current state, taking the cool-down period into
+// account. The caller must hold the lock.
+func (cb *CircuitBreaker) currentState() CircuitState {
+	switch {
+	case cb.state == "":
+		return CircuitClosed
+	case cb.state == CircuitOpen && time.Since(cb.openedAt) >= cb.coolDown():
+		return CircuitHalfOpen
+	}
+	return cb.state
+}
+
+// stateChange describes a state change to report to the StateLogger.
+type stateChange struct {
+	from, to CircuitState
+	err      error
+}
+
+// setState changes the state, and returns the change to report. The caller
+// must hold the lock.
+func (cb *CircuitBreaker) setState(to CircuitState, err error) stateChange {
+	from := cb.state
+	if from == "" {
+		from = CircuitClosed
+	}
+	cb.state = to
+	return stateChange{from: from, to: to, err: err}
+}
+
+// logChange reports c to the StateLogger, if the state changed. The caller
+// must not hold the lock, so that the StateLogger can call State.
+func (cb *CircuitBreaker) logChange(c stateChange) {
+	if c.from != c.to && cb.StateLogger != nil {
+		cb.StateLogger(c.from, c.to, c.err)
+	}
+}
+
+func (cb *CircuitBreaker) threshold() int {
+	if cb.Threshold <= 0 {
+		return defaultCircuitThreshold
+	}
+	return cb.Threshold
+}
+
+func (cb *CircuitBreaker) coolDown() time.Duration {
+	if cb.CoolDown <= 0 {
+		return defaultCircuitCoolDown
+	}
+	return cb.CoolDown
+}
+
+func (cb *CircuitBreaker) isFailure(err error) bool {
+	if cb.IsFailure != nil {
+		return cb.IsFailure(err)
+	}
+
+	if IsTryAgain(err) {
+		return true
+	}
+
+	var serverErr *ServerError
+	var httpErr HTTPError
+	switch {
+	case errors.As(err, &serverErr):
+		return serverErr.Code == codeInternal
+	case errors.As(err, &httpErr):
+		return httpErr.StatusCode >= 500
+	case errors.Is(err, ErrBadRequest), errors.Is(err, ErrBadResponse):
+		return false
+	}
+	return true
+}
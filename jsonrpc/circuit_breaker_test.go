@@ -0,0 +1,94 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/internal/testutil"
+	"github.com/clarify/clarify-go/jsonrpc"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var fail bool
+	var calls int
+	var transitions []string
+	var cb *jsonrpc.CircuitBreaker
+	cb = &jsonrpc.CircuitBreaker{
+		Handler: testutil.HandlerFunc(func(ctx context.Context, req jsonrpc.Request, result any) error {
+			calls++
+			if fail {
+				return jsonrpc.HTTPError{StatusCode: 503}
+			}
+			return nil
+		}),
+		Threshold: 2,
+		CoolDown:  10 * time.Millisecond,
+		StateLogger: func(from, to jsonrpc.CircuitState, err error) {
+			// Calling methods on the circuit breaker must not deadlock.
+			if s := cb.State(); s != to {
+				t.Errorf("Unexpected state in StateLogger:\n got: %s\nwant: %s", s, to)
+			}
+			transitions = append(transitions, string(from)+"->"+string(to))
+		},
+	}
+	ctx := context.Background()
+	req := jsonrpc.NewRequest("test.method")
+
+	fail = true
+	_ = cb.Do(ctx, req, nil)
+	_ = cb.Do(ctx, req, nil)
+	if s := cb.State(); s != jsonrpc.CircuitOpen {
+		t.Fatalf("Unexpected state:\n got: %s\nwant: %s", s, jsonrpc.CircuitOpen)
+	}
+	if err := cb.Do(ctx, req, nil); !errors.Is(err, jsonrpc.ErrCircuitOpen) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, jsonrpc.ErrCircuitOpen)
+	}
+	if calls != 2 {
+		t.Errorf("Unexpected call count:\n got: %d\nwant: 2", calls)
+	}
+
+	// A failed trial request re-opens the circuit.
+	time.Sleep(20 * time.Millisecond)
+	_ = cb.Do(ctx, req, nil)
+	if s := cb.State(); s != jsonrpc.CircuitOpen {
+		t.Fatalf("Unexpected state:\n got: %s\nwant: %s", s, jsonrpc.CircuitOpen)
+	}
+
+	// A successful trial request closes the circuit.
+	time.Sleep(20 * time.Millisecond)
+	fail = false
+	if err := cb.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s := cb.State(); s != jsonrpc.CircuitClosed {
+		t.Fatalf("Unexpected state:\n got: %s\nwant: %s", s, jsonrpc.CircuitClosed)
+	}
+
+	expect := []string{
+		"closed->open",
+		"open->half-open",
+		"half-open->open",
+		"open->half-open",
+		"half-open->closed",
+	}
+	if !slices.Equal(transitions, expect) {
+		t.Errorf("Unexpected transitions:\n got: %v\nwant: %v", transitions, expect)
+	}
+}
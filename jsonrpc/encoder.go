@@ -0,0 +1,41 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import "encoding/json"
+
+// RequestEncoder describe how to serialize a request body. This allows
+// alternative wire formats, such as a binary ingestion format, to be used
+// for high-volume methods when supported by the server. Responses are always
+// decoded as JSON.
+type RequestEncoder interface {
+	// ContentType returns the value to use for the Content-Type header.
+	ContentType() string
+
+	// Encode returns the encoded request body for req.
+	Encode(req Request) ([]byte, error)
+}
+
+var _ RequestEncoder = JSONEncoder{}
+
+// JSONEncoder encodes requests as JSON. It's the default request encoder for
+// HTTPHandler.
+type JSONEncoder struct{}
+
+func (JSONEncoder) ContentType() string { return "application/json" }
+
+func (JSONEncoder) Encode(req Request) ([]byte, error) {
+	return json.Marshal(req)
+}
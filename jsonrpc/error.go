@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -16,8 +16,12 @@ package jsonrpc
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
+	"strings"
 )
 
 // Client errors.
@@ -72,24 +76,85 @@ type HTTPError struct {
 	StatusCode int
 	Body       string
 	Headers    http.Header
+
+	// RequestID holds the ID of the failed request, if known.
+	RequestID string
 }
 
 func (err HTTPError) Error() string {
+	if err.RequestID != "" {
+		return fmt.Sprintf("%s (status: %d, id: %s, headers: %+v)", err.Body, err.StatusCode, err.RequestID, err.Headers)
+	}
 	return fmt.Sprintf("%s (status: %d, headers: %+v)", err.Body, err.StatusCode, err.Headers)
 }
 
+// codeTryAgain is the server error code for requests that are rejected due to
+// rate limiting.
+const codeTryAgain = -32015
+
+// IsTryAgain returns true if err indicates that a request was rejected due to
+// rate limiting or temporary unavailability, and can be retried after a delay.
+// This is the case for server errors with code -32015 (try again), and HTTP
+// errors with status 429 (too many requests) or 503 (service unavailable).
+func IsTryAgain(err error) bool {
+	var serverErr *ServerError
+	var httpErr HTTPError
+	switch {
+	case errors.As(err, &serverErr):
+		return serverErr.Code == codeTryAgain
+	case errors.As(err, &httpErr):
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode == http.StatusServiceUnavailable
+	}
+	return false
+}
+
 // ServerError describes the error format returned by the RPC server.
 type ServerError struct {
 	Code    int       `json:"code"`
 	Message string    `json:"message"`
 	Data    ErrorData `json:"data"`
+
+	// RequestID holds the ID of the failed request, if known.
+	RequestID string `json:"-"`
 }
 
 func (err ServerError) Error() string {
 	jd, _ := json.Marshal(err.Data)
+	if err.RequestID != "" {
+		return fmt.Sprintf("%s (code: %d, id: %s, data: %s)", err.Message, err.Code, err.RequestID, jd)
+	}
 	return fmt.Sprintf("%s (code: %d, data: %s)", err.Message, err.Code, jd)
 }
 
+// Unwrap returns an InvalidParamsError when the error data lists issues for
+// one or more parameters. Otherwise nil is returned.
+func (err ServerError) Unwrap() error {
+	if len(err.Data.Params) == 0 {
+		return nil
+	}
+	return InvalidParamsError{Issues: err.Data.Params}
+}
+
+// InvalidParamsError describes issues per parameter path as reported by the
+// RPC server when rejecting request parameters. Nested fields are reported as
+// `<param>.<field>`. Use errors.As on an error returned from a handler to
+// access the issues.
+type InvalidParamsError struct {
+	Issues map[string][]string
+}
+
+func (err InvalidParamsError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("invalid params: ")
+	for i, k := range slices.Sorted(maps.Keys(err.Issues)) {
+		if i > 0 {
+			sb.WriteString("; ")
+		}
+		fmt.Fprintf(&sb, "%s: %s", k, strings.Join(err.Issues[k], ", "))
+	}
+	return sb.String()
+}
+
 // ErrorData describes possible error data fields for the Clarify RPC server.
 type ErrorData struct {
 	Trace            string              `json:"trace"`
@@ -0,0 +1,68 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/clarify/clarify-go/jsonrpc"
+)
+
+func TestServerErrorInvalidParams(t *testing.T) {
+	var err error = &jsonrpc.ServerError{
+		Code:    -32602,
+		Message: "Invalid params",
+		Data: jsonrpc.ErrorData{
+			Params: map[string][]string{
+				"data.series.a": {"too long"},
+				"integration":   {"required", "not found"},
+			},
+		},
+	}
+	err = fmt.Errorf("insert: %w", err)
+
+	var paramsErr jsonrpc.InvalidParamsError
+	if !errors.As(err, &paramsErr) {
+		t.Fatalf("errors.As(err, InvalidParamsError) = false, want true")
+	}
+	expect := "invalid params: data.series.a: too long; integration: required, not found"
+	if s := paramsErr.Error(); s != expect {
+		t.Errorf("Unexpected error string:\n got: %s\nwant: %s", s, expect)
+	}
+
+	err = &jsonrpc.ServerError{Code: -32603, Message: "Internal error"}
+	if errors.As(err, &paramsErr) {
+		t.Errorf("errors.As(err, InvalidParamsError) = true for error without params, want false")
+	}
+}
+
+func TestIsTryAgain(t *testing.T) {
+	test := func(err error, expect bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := jsonrpc.IsTryAgain(err); got != expect {
+				t.Errorf("Unexpected result:\n got: %t\nwant: %t", got, expect)
+			}
+		}
+	}
+
+	t.Run("server try again", test(fmt.Errorf("insert: %w", &jsonrpc.ServerError{Code: -32015}), true))
+	t.Run("server internal", test(&jsonrpc.ServerError{Code: -32603}, false))
+	t.Run("http 429", test(jsonrpc.HTTPError{StatusCode: 429}, true))
+	t.Run("http 503", test(jsonrpc.HTTPError{StatusCode: 503}, true))
+	t.Run("http 500", test(jsonrpc.HTTPError{StatusCode: 500}, false))
+	t.Run("other", test(errors.New("boom"), false))
+}
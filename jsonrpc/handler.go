@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -22,7 +22,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
 	"runtime/debug"
+	"strings"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -54,14 +56,110 @@ type Handler interface {
 
 // HTTPHandler performs RPC requests via HTTP POST against the specified URL.
 type HTTPHandler struct {
-	Client        http.Client
-	URL           string
+	Client http.Client
+	URL    string
+
+	// RequestLogger, if set, is called after each request. For more details,
+	// such as request and response sizes, use Observer. Request parameters
+	// may contain proprietary label and annotation values; consider using a
+	// Redactor before logging them.
 	RequestLogger func(request Request, trace string, latency time.Duration, err error)
+
+	// Strict, if set, makes the handler return an ErrBadResponse error when
+	// the response contain fields that are unknown to the result type. This is
+	// useful in tests to discover issues with models. The default is to ignore
+	// unknown fields, so that additive server changes does not break older
+	// versions of the SDK.
+	Strict bool
+
+	// UnknownFieldsLogger, if set, is called when the response contain fields
+	// that are unknown to the result type and Strict is false. The err
+	// parameter describes the first unknown field.
+	UnknownFieldsLogger func(request Request, trace string, err error)
+
+	// ResponseMetaLogger, if set, is called for each HTTP response received
+	// from the server, before the response body is decoded. This allows
+	// callers to detect e.g. deprecation warnings before a method version is
+	// removed.
+	ResponseMetaLogger func(request Request, meta ResponseMeta)
+
+	// ConnLogger, if set, is called for each request with information about
+	// the connection that was used, such as whether it was reused or newly
+	// dialed. This helps diagnose transport issues, such as clients that
+	// re-dial TLS connections for every request. See TransportConfig for
+	// tuning options.
+	ConnLogger func(request Request, info ConnInfo)
+
+	// Observer, if set, is notified about each request and its outcome. It
+	// provides a single extension point for logging, metrics and tracing.
+	Observer Observer
+
+	// Encoders, if set, holds request encoders keyed by method name, such as
+	// "integration.insert". Methods without an encoder are encoded using
+	// JSONEncoder. Only configure encoders for content types that the server
+	// accepts for the given method.
+	Encoders map[string]RequestEncoder
+
+	// RequestIDGenerator, if set, is used to generate the RequestID for
+	// requests that don't have one. The default is NewRequestID. The chosen ID
+	// is reported to loggers and observers via the request, by errors
+	// returned from the handler, and to any recorder set by
+	// WithRequestIDRecorder, so that calls can be correlated with server logs
+	// and support tickets.
+	RequestIDGenerator func() string
+}
+
+// ResponseMeta holds transport layer meta-data from an HTTP response.
+type ResponseMeta struct {
+	// StatusCode holds the HTTP status code.
+	StatusCode int
+
+	// APIVersion holds the API version reported by the server.
+	APIVersion string
+
+	// Trace holds the traceparent header value.
+	Trace string
+
+	// Deprecation holds the value of the Deprecation header, if set by the
+	// server. The value is either a date, or "true".
+	Deprecation string
+
+	// Sunset holds the value of the Sunset header, if set by the server. The
+	// value is an HTTP date for when the method version is expected to stop
+	// working.
+	Sunset string
+
+	// Warnings holds the values of any Warning headers.
+	Warnings []string
+}
+
+// Deprecated returns true if the server reported the called method version as
+// deprecated.
+func (m ResponseMeta) Deprecated() bool {
+	return m.Deprecation != "" || m.Sunset != ""
+}
+
+func newResponseMeta(resp *http.Response) ResponseMeta {
+	return ResponseMeta{
+		StatusCode:  resp.StatusCode,
+		APIVersion:  resp.Header.Get(headerAPIVersion),
+		Trace:       resp.Header.Get("traceparent"),
+		Deprecation: resp.Header.Get("Deprecation"),
+		Sunset:      resp.Header.Get("Sunset"),
+		Warnings:    resp.Header.Values("Warning"),
+	}
 }
 
 // Do sends the passed in request to the server, and decodes the result or error
 // from the response. Result must be a pointer.
 func (c *HTTPHandler) Do(ctx context.Context, req Request, result any) (retErr error) {
+	if req.RequestID == "" {
+		req.RequestID = c.newRequestID()
+	}
+	if r := requestIDRecorderFromContext(ctx); r != nil {
+		r.record(req.RequestID)
+	}
+
 	var trace string
 	var err error
 	if c.RequestLogger != nil {
@@ -71,11 +169,40 @@ func (c *HTTPHandler) Do(ctx context.Context, req Request, result any) (retErr e
 		}()
 	}
 
-	body, err := json.Marshal(req)
+	enc := c.encoder(req.Method)
+	body, err := enc.Encode(req)
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrBadRequest, err)
 	}
 
+	var respSize int
+	var meta ResponseMeta
+	if c.Observer != nil {
+		start := time.Now()
+		attempt := AttemptFromContext(ctx)
+		c.Observer.OnRequest(ctx, RequestEvent{
+			Request:     req,
+			Attempt:     attempt,
+			RequestSize: len(body),
+		})
+		defer func() {
+			e := ResponseEvent{
+				Request:      req,
+				Attempt:      attempt,
+				Trace:        trace,
+				RequestSize:  len(body),
+				ResponseSize: respSize,
+				Latency:      time.Since(start),
+				Meta:         meta,
+			}
+			if retErr != nil {
+				c.Observer.OnError(ctx, e, retErr)
+			} else {
+				c.Observer.OnResponse(ctx, e)
+			}
+		}()
+	}
+
 	httpReq, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
@@ -88,9 +215,23 @@ func (c *HTTPHandler) Do(ctx context.Context, req Request, result any) (retErr e
 	defer appendOnError(&retErr, httpReq.Body.Close, "; ")
 
 	httpReq.Header.Set(headerAPIVersion, req.APIVersion)
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("User-Agent", userAgent)
+	httpReq.Header.Set("Content-Type", enc.ContentType())
+	if req.UserAgent != "" {
+		httpReq.Header.Set("User-Agent", userAgent+" "+req.UserAgent)
+	} else {
+		httpReq.Header.Set("User-Agent", userAgent)
+	}
+	var connInfo ConnInfo
+	if c.ConnLogger != nil {
+		httpReq = withConnTrace(httpReq, &connInfo)
+	}
 	httpResp, err := c.Client.Do(httpReq)
+	if c.ConnLogger != nil {
+		if httpResp != nil {
+			connInfo.Proto = httpResp.Proto
+		}
+		c.ConnLogger(req, connInfo)
+	}
 
 	var authErr *oauth2.RetrieveError
 	switch {
@@ -100,6 +241,7 @@ func (c *HTTPHandler) Do(ctx context.Context, req Request, result any) (retErr e
 			StatusCode: authErr.Response.StatusCode,
 			Headers:    authErr.Response.Header,
 			Body:       string(authErr.Body),
+			RequestID:  req.RequestID,
 		}
 	case err != nil:
 		return err
@@ -107,13 +249,19 @@ func (c *HTTPHandler) Do(ctx context.Context, req Request, result any) (retErr e
 
 	trace = httpResp.Header.Get("traceparent")
 	defer appendOnError(&retErr, httpResp.Body.Close, "; ")
+	meta = newResponseMeta(httpResp)
+	if c.ResponseMetaLogger != nil {
+		c.ResponseMetaLogger(req, meta)
+	}
 
 	if httpResp.StatusCode != http.StatusOK {
 		b, _ := io.ReadAll(httpResp.Body)
+		respSize = len(b)
 		return HTTPError{
 			StatusCode: httpResp.StatusCode,
 			Headers:    httpResp.Header,
 			Body:       string(b),
+			RequestID:  req.RequestID,
 		}
 	}
 	resp := rpcResponse{
@@ -122,31 +270,95 @@ func (c *HTTPHandler) Do(ctx context.Context, req Request, result any) (retErr e
 	}
 
 	var buf bytes.Buffer
-	dec := json.NewDecoder(io.TeeReader(httpResp.Body, &buf))
+	if _, err := io.Copy(&buf, httpResp.Body); err != nil {
+		return fmt.Errorf("%w: %v (id: %s, traceparent: %s)", ErrBadResponse, err, req.RequestID, trace)
+	}
+	respSize = buf.Len()
+	dec := json.NewDecoder(bytes.NewReader(buf.Bytes()))
 	dec.DisallowUnknownFields()
-	if err := dec.Decode(&resp); err != nil {
+	err = dec.Decode(&resp)
+	if err != nil && !c.Strict && isUnknownFieldError(err) {
+		if c.UnknownFieldsLogger != nil {
+			c.UnknownFieldsLogger(req, trace, err)
+		}
+		// Decode again from scratch without the strict check.
+		resetResult(result)
+		resp = rpcResponse{
+			Result:     result,
+			APIVersion: resp.APIVersion,
+		}
+		err = json.Unmarshal(buf.Bytes(), &resp)
+	}
+	if err != nil {
 		data := buf.Bytes()
-		return fmt.Errorf("%w: %v (traceparent: %s, body: %s)", ErrBadResponse, err, trace, data)
+		return fmt.Errorf("%w: %v (id: %s, traceparent: %s, body: %s)", ErrBadResponse, err, req.RequestID, trace, data)
 	}
 	if resp.JSONRPC != "2.0" {
 		data := buf.Bytes()
-		return fmt.Errorf(`%w: jsonrpc must be "2.0" (traceparent: %s, body: %s)`, ErrBadResponse, trace, data)
+		return fmt.Errorf(`%w: jsonrpc must be "2.0" (id: %s, traceparent: %s, body: %s)`, ErrBadResponse, req.RequestID, trace, data)
 	}
-	if resp.ID != req.ID {
+	if !matchRequestID(resp.ID, req) {
 		data := buf.Bytes()
-		return fmt.Errorf(`%w: id must match request (traceparent: %s, body: %s)`, ErrBadResponse, trace, data)
+		return fmt.Errorf(`%w: id must match request (id: %s, traceparent: %s, body: %s)`, ErrBadResponse, req.RequestID, trace, data)
 	}
 	if err := resp.Error; err != nil {
+		err.RequestID = req.RequestID
 		return err
 	}
 	return nil
 }
 
+func (c *HTTPHandler) encoder(method string) RequestEncoder {
+	if enc, ok := c.Encoders[method]; ok {
+		return enc
+	}
+	return JSONEncoder{}
+}
+
+// matchRequestID returns true if the encoded response ID id matches the ID
+// that req is sent with.
+func matchRequestID(id json.RawMessage, req Request) bool {
+	var v any
+	if err := json.Unmarshal(id, &v); err != nil {
+		return false
+	}
+	switch v := v.(type) {
+	case string:
+		return req.RequestID != "" && v == req.RequestID
+	case float64:
+		return req.RequestID == "" && v == float64(req.ID)
+	}
+	return false
+}
+
+func (c *HTTPHandler) newRequestID() string {
+	if c.RequestIDGenerator == nil {
+		return NewRequestID()
+	}
+	return c.RequestIDGenerator()
+}
+
+// isUnknownFieldError returns true if err is returned from a JSON decoder with
+// DisallowUnknownFields set due to an unknown field. The encoding/json package
+// does not expose a typed error for this case.
+func isUnknownFieldError(err error) bool {
+	return strings.HasPrefix(err.Error(), "json: unknown field ")
+}
+
+// resetResult sets the value pointed to by result to its zero value, if result
+// is a non-nil pointer.
+func resetResult(result any) {
+	v := reflect.ValueOf(result)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v.Elem().SetZero()
+	}
+}
+
 type rpcResponse struct {
-	JSONRPC string       `json:"jsonrpc"`
-	Error   *ServerError `json:"error"`
-	ID      int          `json:"id"`
-	Result  any          `json:"result"`
+	JSONRPC string          `json:"jsonrpc"`
+	Error   *ServerError    `json:"error"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result"`
 
 	// Transport layer parameters.
 	APIVersion string `json:"-"`
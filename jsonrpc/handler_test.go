@@ -0,0 +1,399 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/clarify/clarify-go/jsonrpc"
+)
+
+// fixedRequestID is a request ID generator matching the ID in static test
+// responses.
+func fixedRequestID() string { return "1" }
+
+func TestHTTPHandlerUnknownFields(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"name":"a","added":true}}`))
+	}))
+	defer srv.Close()
+
+	type result struct {
+		Name string `json:"name"`
+	}
+	req := jsonrpc.NewRequest("test.method")
+
+	t.Run("lenient", func(t *testing.T) {
+		var logged error
+		h := jsonrpc.HTTPHandler{
+			URL:                srv.URL,
+			RequestIDGenerator: fixedRequestID,
+			UnknownFieldsLogger: func(_ jsonrpc.Request, _ string, err error) {
+				logged = err
+			},
+		}
+		var res result
+		if err := h.Do(context.Background(), req, &res); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if res.Name != "a" {
+			t.Errorf("Unexpected result:\n got: %q\nwant: %q", res.Name, "a")
+		}
+		if logged == nil {
+			t.Errorf("Expected unknown fields to be logged")
+		}
+	})
+	t.Run("strict", func(t *testing.T) {
+		h := jsonrpc.HTTPHandler{
+			URL:                srv.URL,
+			RequestIDGenerator: fixedRequestID,
+			Strict:             true,
+		}
+		var res result
+		if err := h.Do(context.Background(), req, &res); !errors.Is(err, jsonrpc.ErrBadResponse) {
+			t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, jsonrpc.ErrBadResponse)
+		}
+	})
+}
+
+func TestHTTPHandlerResponseMeta(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-API-Version", "1.1")
+		w.Header().Set("Sunset", "Wed, 01 Jan 2025 00:00:00 GMT")
+		w.Header().Add("Warning", `299 - "method is deprecated"`)
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{}}`))
+	}))
+	defer srv.Close()
+
+	var meta jsonrpc.ResponseMeta
+	h := jsonrpc.HTTPHandler{
+		URL:                srv.URL,
+		RequestIDGenerator: fixedRequestID,
+		ResponseMetaLogger: func(_ jsonrpc.Request, m jsonrpc.ResponseMeta) {
+			meta = m
+		},
+	}
+	var res struct{}
+	if err := h.Do(context.Background(), jsonrpc.NewRequest("test.method"), &res); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if meta.APIVersion != "1.1" {
+		t.Errorf("Unexpected APIVersion:\n got: %q\nwant: %q", meta.APIVersion, "1.1")
+	}
+	if meta.StatusCode != http.StatusOK {
+		t.Errorf("Unexpected StatusCode:\n got: %d\nwant: %d", meta.StatusCode, http.StatusOK)
+	}
+	if !meta.Deprecated() {
+		t.Errorf("Expected Deprecated to be true")
+	}
+	if len(meta.Warnings) != 1 {
+		t.Errorf("Unexpected Warnings:\n got: %q\nwant: 1 element", meta.Warnings)
+	}
+}
+
+// recordObserver records events.
+type recordObserver struct {
+	jsonrpc.NopObserver
+	requests  []jsonrpc.RequestEvent
+	responses []jsonrpc.ResponseEvent
+	errs      []error
+}
+
+func (o *recordObserver) OnRequest(_ context.Context, e jsonrpc.RequestEvent) {
+	o.requests = append(o.requests, e)
+}
+
+func (o *recordObserver) OnError(_ context.Context, e jsonrpc.ResponseEvent, err error) {
+	o.responses = append(o.responses, e)
+	o.errs = append(o.errs, err)
+}
+
+func TestHTTPHandlerObserver(t *testing.T) {
+	const body = `{"jsonrpc":"2.0","id":"1","result":{}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		if r.Header.Get("X-API-Version") == "fail" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("traceparent", "00-trace")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	obs := &recordObserver{}
+	h := jsonrpc.HTTPHandler{URL: srv.URL, Observer: obs, RequestIDGenerator: fixedRequestID}
+	req := jsonrpc.NewRequest("test.method")
+	var res struct{}
+
+	if err := h.Do(jsonrpc.ContextWithAttempt(context.Background(), 2), req, &res); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// The embedded NopObserver handles OnResponse.
+	if len(obs.requests) != 1 || len(obs.responses) != 0 {
+		t.Fatalf("Unexpected number of events:\n got: %d requests, %d errors\nwant: 1 requests, 0 errors", len(obs.requests), len(obs.responses))
+	}
+	if e := obs.requests[0]; e.Attempt != 2 || e.RequestSize == 0 {
+		t.Errorf("Unexpected request event:\n got: %+v\nwant: Attempt=2 and RequestSize>0", e)
+	}
+
+	req.APIVersion = "fail"
+	if err := h.Do(context.Background(), req, &res); err == nil {
+		t.Fatalf("Expected error")
+	}
+	if len(obs.requests) != 2 || len(obs.responses) != 1 {
+		t.Fatalf("Unexpected number of events:\n got: %d requests, %d errors\nwant: 2 requests, 1 errors", len(obs.requests), len(obs.responses))
+	}
+	if e := obs.responses[0]; e.Attempt != 1 || e.Meta.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Unexpected error event:\n got: %+v\nwant: Attempt=1 and Meta.StatusCode=503", e)
+	}
+}
+
+// sizeObserver records the response size of successful requests.
+type sizeObserver struct {
+	jsonrpc.NopObserver
+	size, attempt int
+	trace         string
+}
+
+func (o *sizeObserver) OnResponse(_ context.Context, e jsonrpc.ResponseEvent) {
+	o.size, o.attempt, o.trace = e.ResponseSize, e.Attempt, e.Trace
+}
+
+func TestHTTPHandlerObserverResponse(t *testing.T) {
+	const body = `{"jsonrpc":"2.0","id":"1","result":{}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("traceparent", "00-trace")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	obs := &sizeObserver{}
+	h := jsonrpc.HTTPHandler{URL: srv.URL, Observer: obs, RequestIDGenerator: fixedRequestID}
+	var res struct{}
+	if err := h.Do(context.Background(), jsonrpc.NewRequest("test.method"), &res); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if obs.size != len(body) || obs.attempt != 1 || obs.trace != "00-trace" {
+		t.Errorf("Unexpected response event:\n got: size=%d attempt=%d trace=%q\nwant: size=%d attempt=1 trace=%q", obs.size, obs.attempt, obs.trace, len(body), "00-trace")
+	}
+}
+
+func TestHTTPHandlerRequestID(t *testing.T) {
+	var ids []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonrpc.Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		ids = append(ids, req.RequestID)
+		w.Header().Set("Content-Type", "application/json")
+		if req.Method == "test.ok" {
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%q,"result":{}}`, req.RequestID)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%q,"error":{"code":-32000,"message":"fail"}}`, req.RequestID)
+	}))
+	defer srv.Close()
+
+	h := jsonrpc.HTTPHandler{URL: srv.URL}
+	var res struct{}
+	for range 2 {
+		err := h.Do(context.Background(), jsonrpc.NewRequest("test.method"), &res)
+		var serverErr *jsonrpc.ServerError
+		if !errors.As(err, &serverErr) {
+			t.Fatalf("Unexpected error:\n got: %v\nwant: *jsonrpc.ServerError", err)
+		}
+		if serverErr.RequestID != ids[len(ids)-1] {
+			t.Errorf("Unexpected error request ID:\n got: %q\nwant: %q", serverErr.RequestID, ids[len(ids)-1])
+		}
+	}
+	reUUIDv7 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	for _, id := range ids {
+		if !reUUIDv7.MatchString(id) {
+			t.Errorf("Unexpected request ID format: %q", id)
+		}
+	}
+	if ids[0] == ids[1] {
+		t.Errorf("Expected unique request IDs, got: %v", ids)
+	}
+
+	req := jsonrpc.NewRequest("test.method")
+	req.RequestID = "custom"
+	_ = h.Do(context.Background(), req, &res)
+	if id := ids[len(ids)-1]; id != "custom" {
+		t.Errorf("Unexpected request ID:\n got: %q\nwant: %q", id, "custom")
+	}
+
+	var rec jsonrpc.RequestIDRecorder
+	ctx := jsonrpc.WithRequestIDRecorder(context.Background(), &rec)
+	if err := h.Do(ctx, jsonrpc.NewRequest("test.ok"), &res); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if id, expect := rec.Last(), ids[len(ids)-1]; id != expect {
+		t.Errorf("Unexpected recorded request ID:\n got: %q\nwant: %q", id, expect)
+	}
+}
+
+func TestRequestJSON(t *testing.T) {
+	test := func(req jsonrpc.Request, expect string) func(t *testing.T) {
+		return func(t *testing.T) {
+			b, err := json.Marshal(req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(b) != expect {
+				t.Errorf("Unexpected JSON:\n got: %s\nwant: %s", b, expect)
+			}
+			var decoded jsonrpc.Request
+			if err := json.Unmarshal(b, &decoded); err != nil {
+				t.Fatalf("Unexpected decode error: %v", err)
+			}
+			if decoded.ID != req.ID || decoded.RequestID != req.RequestID {
+				t.Errorf("Unexpected decoded IDs:\n got: %d, %q\nwant: %d, %q", decoded.ID, decoded.RequestID, req.ID, req.RequestID)
+			}
+		}
+	}
+	req := jsonrpc.NewRequest("test.method")
+	req.Params = nil
+	t.Run("numeric ID", test(req, `{"jsonrpc":"2.0","method":"test.method","id":1,"params":null}`))
+	req.ID, req.RequestID = 0, "custom"
+	t.Run("request ID", test(req, `{"jsonrpc":"2.0","method":"test.method","id":"custom","params":null}`))
+}
+
+func TestHTTPHandlerConnLogger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{}}`))
+	}))
+	defer srv.Close()
+
+	var infos []jsonrpc.ConnInfo
+	h := jsonrpc.HTTPHandler{
+		Client:             http.Client{Transport: jsonrpc.TransportConfig{MaxIdleConnsPerHost: 4}.Transport()},
+		URL:                srv.URL,
+		RequestIDGenerator: fixedRequestID,
+		ConnLogger: func(_ jsonrpc.Request, info jsonrpc.ConnInfo) {
+			infos = append(infos, info)
+		},
+	}
+	for range 2 {
+		var res struct{}
+		if err := h.Do(context.Background(), jsonrpc.NewRequest("test.method"), &res); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if len(infos) != 2 {
+		t.Fatalf("Unexpected number of logged connections:\n got: %d\nwant: %d", len(infos), 2)
+	}
+	if infos[0].Reused || !infos[1].Reused {
+		t.Errorf("Unexpected connection reuse:\n got: %v, %v\nwant: false, true", infos[0].Reused, infos[1].Reused)
+	}
+	if infos[0].Proto != "HTTP/1.1" {
+		t.Errorf("Unexpected protocol:\n got: %q\nwant: %q", infos[0].Proto, "HTTP/1.1")
+	}
+}
+
+func TestTransportConfigDisableHTTP2(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{}}`))
+	}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	test := func(cfg jsonrpc.TransportConfig, expect string) func(t *testing.T) {
+		return func(t *testing.T) {
+			tr := cfg.Transport()
+			tr.TLSClientConfig = srv.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+			var proto string
+			h := jsonrpc.HTTPHandler{
+				Client:             http.Client{Transport: tr},
+				URL:                srv.URL,
+				RequestIDGenerator: fixedRequestID,
+				ConnLogger: func(_ jsonrpc.Request, info jsonrpc.ConnInfo) {
+					proto = info.Proto
+				},
+			}
+			var res struct{}
+			if err := h.Do(context.Background(), jsonrpc.NewRequest("test.method"), &res); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if proto != expect {
+				t.Errorf("Unexpected protocol:\n got: %q\nwant: %q", proto, expect)
+			}
+		}
+	}
+	t.Run("default", test(jsonrpc.TransportConfig{}, "HTTP/2.0"))
+	t.Run("disabled", test(jsonrpc.TransportConfig{DisableHTTP2: true}, "HTTP/1.1"))
+}
+
+type testEncoder struct{}
+
+func (testEncoder) ContentType() string { return "application/x-test" }
+
+func (testEncoder) Encode(req jsonrpc.Request) ([]byte, error) {
+	return []byte(req.Method), nil
+}
+
+func TestHTTPHandlerEncoders(t *testing.T) {
+	var contentType, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		contentType, body = r.Header.Get("Content-Type"), string(b)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{}}`))
+	}))
+	defer srv.Close()
+
+	h := jsonrpc.HTTPHandler{
+		URL:                srv.URL,
+		RequestIDGenerator: fixedRequestID,
+		Encoders:           map[string]jsonrpc.RequestEncoder{"test.binary": testEncoder{}},
+	}
+	test := func(method, expectContentType, expectBody string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if err := h.Do(context.Background(), jsonrpc.NewRequest(method), &struct{}{}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if contentType != expectContentType {
+				t.Errorf("Unexpected Content-Type:\n got: %q\nwant: %q", contentType, expectContentType)
+			}
+			if body != expectBody {
+				t.Errorf("Unexpected body:\n got: %s\nwant: %s", body, expectBody)
+			}
+		}
+	}
+	t.Run("Custom", test("test.binary", "application/x-test", "test.binary"))
+	t.Run("Default", test("test.json", "application/json", `{"jsonrpc":"2.0","method":"test.json","id":"1","params":{}}`))
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+type logAttrsKey struct{}
+
+// ContextWithLogAttrs returns a context that holds attrs in addition to any
+// log attributes already stored in ctx. This can be used to stash
+// request-scoped meta-data, such as a job ID, tenant or run ID, so that it's
+// included in logs written via a handler from NewContextLogHandler.
+func ContextWithLogAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	if len(attrs) == 0 {
+		return ctx
+	}
+	prev := LogAttrsFromContext(ctx)
+	return context.WithValue(ctx, logAttrsKey{}, append(slices.Clip(prev), attrs...))
+}
+
+// LogAttrsFromContext returns the log attributes stored in ctx.
+func LogAttrsFromContext(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(logAttrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+// NewContextLogHandler returns a slog handler that adds the log attributes
+// stored in the context passed to Handle, see ContextWithLogAttrs, to each
+// record before passing it on to h. The attributes are added at the top level,
+// also for loggers that have opened groups via WithGroup. If h is already a
+// context log handler, it is returned as is.
+func NewContextLogHandler(h slog.Handler) slog.Handler {
+	if _, ok := h.(*contextLogHandler); ok {
+		return h
+	}
+	return &contextLogHandler{Handler: h}
+}
+
+type contextLogHandler struct {
+	slog.Handler
+
+	// root holds the handler from before the first group was opened, and
+	// groups the groups and attributes added since. They are replayed on top
+	// of the context attributes, so that these are kept at the top level.
+	root   slog.Handler
+	groups []groupOrAttrs
+}
+
+// groupOrAttrs holds either a group name or a list of attributes.
+type groupOrAttrs struct {
+	group string
+	attrs []slog.Attr
+}
+
+func (h *contextLogHandler) Handle(ctx context.Context, r slog.Record) error {
+	attrs := LogAttrsFromContext(ctx)
+	switch {
+	case len(attrs) == 0:
+		return h.Handler.Handle(ctx, r)
+	case len(h.groups) == 0:
+		r = r.Clone()
+		r.AddAttrs(attrs...)
+		return h.Handler.Handle(ctx, r)
+	}
+
+	next := h.root.WithAttrs(attrs)
+	for _, g := range h.groups {
+		if g.group != "" {
+			next = next.WithGroup(g.group)
+		} else {
+			next = next.WithAttrs(g.attrs)
+		}
+	}
+	return next.Handle(ctx, r)
+}
+
+func (h *contextLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := &contextLogHandler{Handler: h.Handler.WithAttrs(attrs), root: h.root}
+	if len(h.groups) > 0 {
+		h2.groups = append(slices.Clip(h.groups), groupOrAttrs{attrs: attrs})
+	}
+	return h2
+}
+
+func (h *contextLogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := &contextLogHandler{Handler: h.Handler.WithGroup(name), root: h.root}
+	if len(h.groups) == 0 {
+		h2.root = h.Handler
+	}
+	h2.groups = append(slices.Clip(h.groups), groupOrAttrs{group: name})
+	return h2
+}
+
+var _ Observer = LogObserver{}
+
+// LogObserver is an Observer that logs the outcome of each request, including
+// log attributes stored in the request context; see ContextWithLogAttrs.
+// Successful requests are logged at the DEBUG level, and failed requests at
+// the WARN level.
+type LogObserver struct {
+	NopObserver
+
+	// Logger holds the logger to use. If nil, slog.Default() is used.
+	Logger *slog.Logger
+}
+
+func (o LogObserver) logger() *slog.Logger {
+	l := o.Logger
+	if l == nil {
+		l = slog.Default()
+	}
+	return slog.New(NewContextLogHandler(l.Handler()))
+}
+
+func (o LogObserver) OnResponse(ctx context.Context, e ResponseEvent) {
+	o.logger().LogAttrs(ctx, slog.LevelDebug, "RPC request completed", responseEventAttrs(e)...)
+}
+
+func (o LogObserver) OnError(ctx context.Context, e ResponseEvent, err error) {
+	attrs := append(responseEventAttrs(e), slog.Any("error", err))
+	o.logger().LogAttrs(ctx, slog.LevelWarn, "RPC request failed", attrs...)
+}
+
+func responseEventAttrs(e ResponseEvent) []slog.Attr {
+	return []slog.Attr{
+		slog.String("method", e.Request.Method),
+		slog.String("id", e.Request.RequestID),
+		slog.Int("attempt", e.Attempt),
+		slog.String("trace", e.Trace),
+		slog.Duration("latency", e.Latency),
+	}
+}
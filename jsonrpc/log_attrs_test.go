@@ -0,0 +1,97 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/clarify/clarify-go/jsonrpc"
+)
+
+func TestContextLogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := jsonrpc.NewContextLogHandler(slog.NewJSONHandler(&buf, nil))
+	if h2 := jsonrpc.NewContextLogHandler(h); h2 != h {
+		t.Errorf("Expected handler to not be wrapped twice")
+	}
+	logger := slog.New(h).With(slog.String("static", "s"))
+
+	ctx := jsonrpc.ContextWithLogAttrs(context.Background(), slog.String("tenant", "t1"))
+	ctx = jsonrpc.ContextWithLogAttrs(ctx, slog.String("job", "j1"))
+	logger.InfoContext(ctx, "hello")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for k, v := range map[string]string{"static": "s", "tenant": "t1", "job": "j1"} {
+		if record[k] != v {
+			t.Errorf("Unexpected value for %q:\n got: %v\nwant: %v", k, record[k], v)
+		}
+	}
+}
+
+func TestContextLogHandlerGroup(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(jsonrpc.NewContextLogHandler(slog.NewJSONHandler(&buf, nil))).
+		With(slog.String("static", "s")).
+		WithGroup("g").
+		With(slog.String("a", "1"))
+
+	ctx := jsonrpc.ContextWithLogAttrs(context.Background(), slog.String("tenant", "t1"))
+	logger.InfoContext(ctx, "hello", slog.String("b", "2"))
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for k, v := range map[string]string{"static": "s", "tenant": "t1"} {
+		if record[k] != v {
+			t.Errorf("Unexpected value for %q:\n got: %v\nwant: %v", k, record[k], v)
+		}
+	}
+	group, _ := record["g"].(map[string]any)
+	for k, v := range map[string]string{"a": "1", "b": "2"} {
+		if group[k] != v {
+			t.Errorf("Unexpected value for g.%s:\n got: %v\nwant: %v", k, group[k], v)
+		}
+	}
+	if _, ok := group["tenant"]; ok {
+		t.Errorf("Expected context attributes to be kept at the top level, got: %s", buf.Bytes())
+	}
+}
+
+func TestLogObserver(t *testing.T) {
+	var buf bytes.Buffer
+	o := jsonrpc.LogObserver{Logger: slog.New(slog.NewJSONHandler(&buf, nil))}
+	ctx := jsonrpc.ContextWithLogAttrs(context.Background(), slog.String("run", "r1"))
+	o.OnError(ctx, jsonrpc.ResponseEvent{
+		Request: jsonrpc.NewRequest("integration.insert"),
+		Attempt: 1,
+	}, errors.New("failed"))
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if record["run"] != "r1" || record["method"] != "integration.insert" || record["error"] != "failed" {
+		t.Errorf("Unexpected record: %v", record)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"context"
+	"time"
+)
+
+// Observer describe the interface for observing requests performed by a
+// handler, e.g. for logging, metrics or tracing. For each call to OnRequest,
+// exactly one call to either OnResponse or OnError follows. Implementations
+// must be safe for concurrent use.
+//
+// Embed NopObserver to only implement a subset of the methods.
+type Observer interface {
+	// OnRequest is called before a request is sent.
+	OnRequest(ctx context.Context, e RequestEvent)
+
+	// OnResponse is called after a successful request.
+	OnResponse(ctx context.Context, e ResponseEvent)
+
+	// OnError is called after a failed request.
+	OnError(ctx context.Context, e ResponseEvent, err error)
+}
+
+// RequestEvent describe a request that is about to be sent.
+type RequestEvent struct {
+	Request Request
+
+	// Attempt holds the attempt number, starting at 1. See ContextWithAttempt.
+	Attempt int
+
+	// RequestSize holds the size of the encoded request body in bytes.
+	RequestSize int
+}
+
+// ResponseEvent describe the outcome of a request.
+type ResponseEvent struct {
+	Request Request
+
+	// Attempt holds the attempt number, starting at 1. See ContextWithAttempt.
+	Attempt int
+
+	// Trace holds the traceparent header value, if known.
+	Trace string
+
+	// RequestSize holds the size of the encoded request body in bytes.
+	RequestSize int
+
+	// ResponseSize holds the size of the response body in bytes, if read.
+	ResponseSize int
+
+	// Latency holds the duration from the request was sent until the
+	// response was handled.
+	Latency time.Duration
+
+	// Meta holds response meta-data. The zero value is used if no HTTP
+	// response was received.
+	Meta ResponseMeta
+}
+
+var _ Observer = NopObserver{}
+
+// NopObserver is an Observer that does nothing.
+type NopObserver struct{}
+
+func (NopObserver) OnRequest(context.Context, RequestEvent)       {}
+func (NopObserver) OnResponse(context.Context, ResponseEvent)     {}
+func (NopObserver) OnError(context.Context, ResponseEvent, error) {}
+
+type attemptKey struct{}
+
+// ContextWithAttempt returns a context that reports attempt as the attempt
+// number for requests. It's intended to be used by handlers or routines that
+// retry requests.
+func ContextWithAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// AttemptFromContext returns the attempt number stored in ctx, or 1 if not
+// set.
+func AttemptFromContext(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptKey{}).(int); ok && attempt > 0 {
+		return attempt
+	}
+	return 1
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// RedactedValue is the value used by Redactor to replace redacted values,
+// unless hashing is enabled.
+const RedactedValue = "[REDACTED]"
+
+// Redactor hides label and annotation values in request parameters, so that
+// requests can be logged without leaking proprietary meta-data. Values are
+// redacted for any JSON object named "labels" or "annotations" in the
+// parameters, and for JSON object keys with a "labels." or "annotations."
+// prefix, as used in resource filters. Keys listed in AllowKeys are kept as
+// is.
+type Redactor struct {
+	// AllowKeys lists label and annotation keys with values that are kept as
+	// is. The special key "*" allows all keys.
+	AllowKeys []string
+
+	// Hash, if set, replaces redacted values with a truncated SHA-256 hash of
+	// the value. This allows equal values to be correlated across log entries
+	// without revealing them. The default is to replace values with
+	// RedactedValue.
+	Hash bool
+}
+
+// Request returns a copy of req where the parameters are redacted.
+func (r Redactor) Request(req Request) Request {
+	req.Params = r.Params(req.Params)
+	return req
+}
+
+// Params returns a JSON compatible copy of params where label and annotation
+// values are redacted. If params can't be encoded as JSON, RedactedValue is
+// returned.
+func (r Redactor) Params(params any) any {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return RedactedValue
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return RedactedValue
+	}
+	return r.walk(v)
+}
+
+func (r Redactor) walk(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if m, ok := child.(map[string]any); ok && (k == "labels" || k == "annotations") {
+				for key, value := range m {
+					if !r.allowed(key) {
+						m[key] = r.redact(value)
+					}
+				}
+				continue
+			}
+			if key, ok := metaKey(k); ok {
+				if !r.allowed(key) {
+					v[k] = r.redact(child)
+				}
+				continue
+			}
+			v[k] = r.walk(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = r.walk(child)
+		}
+	}
+	return v
+}
+
+// metaKey returns the label or annotation key for a JSON object key with a
+// "labels." or "annotations." prefix, such as "labels.site" in a filter.
+func metaKey(k string) (string, bool) {
+	if key, ok := strings.CutPrefix(k, "labels."); ok {
+		return key, true
+	}
+	return strings.CutPrefix(k, "annotations.")
+}
+
+func (r Redactor) allowed(key string) bool {
+	return slices.Contains(r.AllowKeys, key) || slices.Contains(r.AllowKeys, "*")
+}
+
+func (r Redactor) redact(v any) any {
+	switch v := v.(type) {
+	case []any:
+		for i, s := range v {
+			v[i] = r.redact(s)
+		}
+		return v
+	case map[string]any:
+		// Filter operators, such as {"$in": [...]}.
+		for k, s := range v {
+			v[k] = r.redact(s)
+		}
+		return v
+	case nil:
+		return nil
+	case string:
+		if !r.Hash {
+			return RedactedValue
+		}
+		sum := sha256.Sum256([]byte(v))
+		return "sha256:" + hex.EncodeToString(sum[:8])
+	default:
+		return RedactedValue
+	}
+}
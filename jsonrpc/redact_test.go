@@ -0,0 +1,80 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/clarify/clarify-go/jsonrpc"
+)
+
+func TestRedactor(t *testing.T) {
+	req := jsonrpc.NewRequest("admin.publishSignals",
+		jsonrpc.ParamName("itemsBySignal").Value(map[string]any{
+			"s1": map[string]any{
+				"name":        "Temperature",
+				"labels":      map[string][]string{"site": {"pier"}, "customer": {"acme"}},
+				"annotations": map[string]string{"secret": "x"},
+			},
+		}),
+	)
+
+	test := func(r jsonrpc.Redactor, want string) func(t *testing.T) {
+		return func(t *testing.T) {
+			b, err := json.Marshal(r.Request(req).Params)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := string(b); got != want {
+				t.Errorf("Unexpected params:\n got: %s\nwant: %s", got, want)
+			}
+		}
+	}
+
+	t.Run("Default", test(jsonrpc.Redactor{},
+		`{"itemsBySignal":{"s1":{"annotations":{"secret":"[REDACTED]"},"labels":{"customer":["[REDACTED]"],"site":["[REDACTED]"]},"name":"Temperature"}}}`,
+	))
+	t.Run("AllowKeys", test(jsonrpc.Redactor{AllowKeys: []string{"site"}},
+		`{"itemsBySignal":{"s1":{"annotations":{"secret":"[REDACTED]"},"labels":{"customer":["[REDACTED]"],"site":["pier"]},"name":"Temperature"}}}`,
+	))
+	t.Run("AllowAll", test(jsonrpc.Redactor{AllowKeys: []string{"*"}},
+		`{"itemsBySignal":{"s1":{"annotations":{"secret":"x"},"labels":{"customer":["acme"],"site":["pier"]},"name":"Temperature"}}}`,
+	))
+	t.Run("Filter", func(t *testing.T) {
+		req := jsonrpc.NewRequest("clarify.selectItems",
+			jsonrpc.ParamName("query").Value(map[string]any{
+				"filter": map[string]any{
+					"name":               "Temperature",
+					"labels.site":        map[string]any{"$in": []string{"pier"}},
+					"labels.customer":    "acme",
+					"annotations.secret": map[string]any{"$ne": "x"},
+				},
+			}),
+		)
+		b, _ := json.Marshal(jsonrpc.Redactor{AllowKeys: []string{"site"}}.Request(req).Params)
+		want := `{"query":{"filter":{"annotations.secret":{"$ne":"[REDACTED]"},"labels.customer":"[REDACTED]","labels.site":{"$in":["pier"]},"name":"Temperature"}}}`
+		if got := string(b); got != want {
+			t.Errorf("Unexpected params:\n got: %s\nwant: %s", got, want)
+		}
+	})
+	t.Run("Hash", func(t *testing.T) {
+		b, _ := json.Marshal(jsonrpc.Redactor{Hash: true}.Request(req).Params)
+		if s := string(b); strings.Contains(s, "acme") || !strings.Contains(s, `"customer":["sha256:`) {
+			t.Errorf("Unexpected params: %s", s)
+		}
+	})
+}
@@ -1,4 +1,4 @@
-// Copyright 2022 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -14,6 +14,8 @@
 
 package jsonrpc
 
+import "encoding/json"
+
 // ParamName can be used to define a parameter name.
 type ParamName string
 
@@ -38,8 +40,17 @@ type Request struct {
 	ID      int    `json:"id"`
 	Params  any    `json:"params"`
 
+	// RequestID, if set, is sent as the request ID instead of ID. If empty,
+	// HTTPHandler sets it before the request is sent; see
+	// HTTPHandler.RequestIDGenerator and WithRequestIDRecorder.
+	RequestID string `json:"-"`
+
 	// Transport layer parameters.
 	APIVersion string `json:"-"`
+
+	// UserAgent, if set, is appended to the default User-Agent header value
+	// by transports that support it.
+	UserAgent string `json:"-"`
 }
 
 func NewRequest(method string, params ...Param) Request {
@@ -55,3 +66,39 @@ func NewRequest(method string, params ...Param) Request {
 		APIVersion: defaultAPIVersion,
 	}
 }
+
+// MarshalJSON encodes req, using RequestID as the request ID when it's set.
+func (req Request) MarshalJSON() ([]byte, error) {
+	var id any = req.ID
+	if req.RequestID != "" {
+		id = req.RequestID
+	}
+	return json.Marshal(struct {
+		JSONRPC string `json:"jsonrpc"`
+		Method  string `json:"method"`
+		ID      any    `json:"id"`
+		Params  any    `json:"params"`
+	}{req.JSONRPC, req.Method, id, req.Params})
+}
+
+// UnmarshalJSON decodes a request, where a numeric request ID is decoded into
+// ID, and a string request ID is decoded into RequestID.
+func (req *Request) UnmarshalJSON(data []byte) error {
+	var target struct {
+		JSONRPC string          `json:"jsonrpc"`
+		Method  string          `json:"method"`
+		ID      json.RawMessage `json:"id"`
+		Params  any             `json:"params"`
+	}
+	if err := json.Unmarshal(data, &target); err != nil {
+		return err
+	}
+	*req = Request{JSONRPC: target.JSONRPC, Method: target.Method, Params: target.Params}
+	switch {
+	case len(target.ID) > 0 && target.ID[0] == '"':
+		return json.Unmarshal(target.ID, &req.RequestID)
+	case len(target.ID) > 0 && string(target.ID) != "null":
+		return json.Unmarshal(target.ID, &req.ID)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// NewRequestID returns a new request ID on the UUID version 7 format. The ID
+// is time-ordered, which makes it easy to correlate with logs. It's the default
+// ID generator for HTTPHandler.
+func NewRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[6:])
+	ms := uint64(time.Now().UnixMilli())
+	binary.BigEndian.PutUint16(b[0:2], uint16(ms>>32))
+	binary.BigEndian.PutUint32(b[2:6], uint32(ms))
+	b[6] = b[6]&0x0f | 0x70 // Version 7.
+	b[8] = b[8]&0x3f | 0x80 // Variant RFC 9562.
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// RequestIDRecorder records the IDs of requests performed by HTTPHandler with
+// a context returned by WithRequestIDRecorder. This allows callers to get the
+// ID of successful requests, and not only of failed ones. A recorder is safe
+// for concurrent use.
+type RequestIDRecorder struct {
+	lock sync.Mutex
+	ids  []string
+}
+
+// WithRequestIDRecorder returns a context where HTTPHandler records the ID of
+// each request it performs to r.
+func WithRequestIDRecorder(ctx context.Context, r *RequestIDRecorder) context.Context {
+	return context.WithValue(ctx, requestIDRecorderKey{}, r)
+}
+
+type requestIDRecorderKey struct{}
+
+func requestIDRecorderFromContext(ctx context.Context) *RequestIDRecorder {
+	r, _ := ctx.Value(requestIDRecorderKey{}).(*RequestIDRecorder)
+	return r
+}
+
+func (r *RequestIDRecorder) record(id string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.ids = append(r.ids, id)
+}
+
+// IDs returns the recorded request IDs in the order the requests were sent.
+func (r *RequestIDRecorder) IDs() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return slices.Clone(r.ids)
+}
+
+// Last returns the ID of the last request that was sent, or an empty string if
+// no requests are recorded.
+func (r *RequestIDRecorder) Last() string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.ids) == 0 {
+		return ""
+	}
+	return r.ids[len(r.ids)-1]
+}
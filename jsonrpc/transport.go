@@ -0,0 +1,97 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"time"
+)
+
+// TransportConfig describe tuning options for the HTTP transport used to
+// communicate with Clarify. See Transport.
+type TransportConfig struct {
+	// DisableHTTP2, if set, restricts the transport to HTTP/1.1. By default,
+	// HTTP/2 is negotiated for TLS connections when the server supports it.
+	// Disabling HTTP/2 can be useful when diagnosing connection reuse, or
+	// when a proxy doesn't handle HTTP/2 correctly.
+	DisableHTTP2 bool
+
+	// MaxConnsPerHost, if set, limits the total number of connections per
+	// host, including connections in the dialing, active, and idle states.
+	MaxConnsPerHost int
+
+	// MaxIdleConnsPerHost, if set, controls the maximum number of idle
+	// connections to keep per host. The net/http default is 2, which can make
+	// high-throughput clients with many concurrent requests re-dial
+	// connections constantly.
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout, if set, is the maximum amount of time an idle
+	// connection remains open before closing itself.
+	IdleConnTimeout time.Duration
+}
+
+// Transport returns a clone of http.DefaultTransport with the configuration
+// from cfg applied.
+func (cfg TransportConfig) Transport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.DisableHTTP2 {
+		// A non-nil, empty map disables HTTP/2; see the net/http docs.
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+	if cfg.MaxConnsPerHost > 0 {
+		t.MaxConnsPerHost = cfg.MaxConnsPerHost
+	}
+	if cfg.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+		t.MaxIdleConns = max(t.MaxIdleConns, cfg.MaxIdleConnsPerHost)
+	}
+	if cfg.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = cfg.IdleConnTimeout
+	}
+	return t
+}
+
+// ConnInfo describe the connection used for a request.
+type ConnInfo struct {
+	// Reused is true if the connection was previously used for another
+	// request, and false if the connection was newly dialed.
+	Reused bool
+
+	// WasIdle is true if the connection was obtained from an idle pool.
+	WasIdle bool
+
+	// IdleTime reports how long the connection was idle, if WasIdle is true.
+	IdleTime time.Duration
+
+	// Proto holds the protocol of the response, such as "HTTP/2.0". The value
+	// is empty if no response was received.
+	Proto string
+}
+
+// withConnTrace returns a request that records connection info in info.
+func withConnTrace(req *http.Request, info *ConnInfo) *http.Request {
+	trace := &httptrace.ClientTrace{
+		GotConn: func(ci httptrace.GotConnInfo) {
+			info.Reused = ci.Reused
+			info.WasIdle = ci.WasIdle
+			info.IdleTime = ci.IdleTime
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+}
@@ -0,0 +1,292 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clarify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+// ErrPayloadTooLarge is returned for write methods performed by a client
+// configured with WithPayloadLimit and PayloadChunk, when a single entry can
+// not be fit within the limit.
+const ErrPayloadTooLarge strError = "payload too large"
+
+// DefaultMaxPayloadSize is the default maximum size in bytes of the encoded
+// parameters for write requests, as used by WithPayloadLimit. The value is a
+// conservative client-side default, and not a documented limit of the API;
+// pass an explicit limit to WithPayloadLimit to match a known server limit.
+const DefaultMaxPayloadSize = 4 << 20
+
+// PayloadPolicy describe how a client handles write requests where the
+// encoded parameters exceeds the configured limit.
+type PayloadPolicy int
+
+// Payload policies.
+const (
+	// PayloadWarn logs a warning via slog.Default(), and sends the request
+	// unchanged.
+	PayloadWarn PayloadPolicy = iota
+
+	// PayloadChunk splits the request into multiple requests that are each
+	// within the limit, performs them in sequence, and merges the results. If
+	// any of the requests fail, a *BatchError holding the merged result of the
+	// successful requests is returned.
+	PayloadChunk
+)
+
+// WithPayloadLimit returns an option that estimates the encoded size of the
+// parameters for Insert and SaveSignals requests before they are sent. When
+// the size exceeds maxSize, the request is handled according to policy. If
+// maxSize is 0 or less, DefaultMaxPayloadSize is used.
+//
+// This helps avoid opaque request size errors from the server in the middle of
+// a long run.
+func WithPayloadLimit(maxSize int, policy PayloadPolicy) ClientOption {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxPayloadSize
+	}
+	return func(opts *clientOptions) {
+		opts.payloadLimit = &payloadLimitHandler{maxSize: maxSize, policy: policy}
+	}
+}
+
+// EstimatePayloadSize returns the size in bytes of v when encoded as JSON.
+func EstimatePayloadSize(v any) (int, error) {
+	var w countWriter
+	if err := json.NewEncoder(&w).Encode(v); err != nil {
+		return 0, err
+	}
+	// Don't count the trailing newline written by the encoder.
+	return int(w) - 1, nil
+}
+
+type countWriter int
+
+func (w *countWriter) Write(p []byte) (int, error) {
+	*w += countWriter(len(p))
+	return len(p), nil
+}
+
+// payloadLimitHandler wraps a handler to warn about or split write requests
+// with large payloads.
+type payloadLimitHandler struct {
+	jsonrpc.Handler
+	maxSize int
+	policy  PayloadPolicy
+}
+
+func (h payloadLimitHandler) Do(ctx context.Context, req jsonrpc.Request, result any) error {
+	params, ok := req.Params.(map[string]any)
+	if !ok {
+		return h.Handler.Do(ctx, req, result)
+	}
+	var param jsonrpc.ParamName
+	switch req.Method {
+	case methodInsert.Method:
+		param = paramData
+	case methodSaveSignals.Method:
+		param = paramSignalsByInput
+	default:
+		return h.Handler.Do(ctx, req, result)
+	}
+
+	// Encode the potentially large parameter value only once, and estimate
+	// the size of the remaining parameters separately.
+	value := params[string(param)]
+	valueSize, err := EstimatePayloadSize(value)
+	if err != nil {
+		return h.Handler.Do(ctx, req, result)
+	}
+	other := maps.Clone(params)
+	delete(other, string(param))
+	otherSize, err := EstimatePayloadSize(other)
+	if err != nil {
+		return h.Handler.Do(ctx, req, result)
+	}
+	// Add the size of the encoded parameter name, quotes, colon and comma.
+	otherSize += len(param) + 4
+
+	size := otherSize + valueSize
+	if size <= h.maxSize {
+		return h.Handler.Do(ctx, req, result)
+	}
+	if h.policy != PayloadChunk {
+		slog.Default().LogAttrs(ctx, slog.LevelWarn, "Request payload exceeds limit",
+			slog.String("method", req.Method),
+			slog.Int("size", size),
+			slog.Int("max_size", h.maxSize),
+		)
+		return h.Handler.Do(ctx, req, result)
+	}
+	budget := h.maxSize - otherSize
+	if budget <= 0 {
+		return fmt.Errorf("%w: other parameters exceed %d bytes", ErrPayloadTooLarge, h.maxSize)
+	}
+
+	switch res := result.(type) {
+	case *InsertResult:
+		data, _ := value.(views.DataFrame)
+		parts, err := splitDataFrame(data, valueSize, budget)
+		if err != nil {
+			return err
+		}
+		res.SignalsByInput = make(map[string]views.CreateSummary)
+		return doPayloadParts(ctx, h.Handler, req, param, parts, res, func(r *InsertResult) {
+			views.MergeCreateSummaries(res.SignalsByInput, r.SignalsByInput)
+		})
+	case *SaveSignalsResult:
+		inputs, _ := value.(map[string]views.SignalSave)
+		parts, err := splitMap(inputs, valueSize, budget)
+		if err != nil {
+			return err
+		}
+		res.SignalsByInput = make(map[string]views.SaveSummary)
+		return doPayloadParts(ctx, h.Handler, req, param, parts, res, func(r *SaveSignalsResult) {
+			maps.Copy(res.SignalsByInput, r.SignalsByInput)
+		})
+	}
+	return h.Handler.Do(ctx, req, result)
+}
+
+// doPayloadParts performs one request per part, where the param parameter of
+// req is replaced by the part, and passes each successful result to merge. If
+// any request fails, a *BatchError holding result is returned after all parts
+// are attempted.
+func doPayloadParts[P ~map[string]V, V, R any](ctx context.Context, h jsonrpc.Handler, req jsonrpc.Request, param jsonrpc.ParamName, parts []P, result *R, merge func(*R)) error {
+	params := req.Params.(map[string]any)
+	batchErr := BatchError{Total: len(parts), Result: result}
+	for _, part := range parts {
+		sub := req
+		sub.RequestID = ""
+		subParams := maps.Clone(params)
+		subParams[string(param)] = part
+		sub.Params = subParams
+
+		var r R
+		err := ctx.Err()
+		if err == nil {
+			err = h.Do(ctx, sub, &r)
+		}
+		if err != nil {
+			batchErr.Errors = append(batchErr.Errors, ChunkError{
+				Keys: slices.Sorted(maps.Keys(part)),
+				Err:  err,
+			})
+			continue
+		}
+		merge(&r)
+	}
+	if len(batchErr.Errors) > 0 {
+		return &batchErr
+	}
+	return nil
+}
+
+// splitDataFrame splits df, where the encoded size is size, into data frames
+// where the encoded size of each is at most maxSize. Data frames are split by
+// series first, and then by time, into the number of parts suggested by size.
+// The size of each part is estimated once, and parts that still exceed maxSize
+// are split further.
+func splitDataFrame(df views.DataFrame, size, maxSize int) ([]views.DataFrame, error) {
+	if size <= maxSize {
+		return []views.DataFrame{df}, nil
+	}
+	n := (size + maxSize - 1) / maxSize
+
+	var parts []views.DataFrame
+	switch {
+	case len(df) > 1:
+		keys := slices.Sorted(maps.Keys(df))
+		n = min(n, len(keys))
+		for i := range n {
+			part := make(views.DataFrame)
+			for _, k := range keys[i*len(keys)/n : (i+1)*len(keys)/n] {
+				part[k] = df[k]
+			}
+			parts = append(parts, part)
+		}
+	default:
+		for k, s := range df {
+			if len(s) < 2 {
+				return nil, fmt.Errorf("%w: series %q: single value exceeds %d bytes", ErrPayloadTooLarge, k, maxSize)
+			}
+			times := slices.Sorted(maps.Keys(s))
+			n = min(n, len(times))
+			for i := range n {
+				chunk := times[i*len(times)/n : (i+1)*len(times)/n]
+				series := make(views.DataSeries, len(chunk))
+				for _, t := range chunk {
+					series[t] = s[t]
+				}
+				parts = append(parts, views.DataFrame{k: series})
+			}
+		}
+	}
+
+	var result []views.DataFrame
+	for _, part := range parts {
+		partSize, err := EstimatePayloadSize(part)
+		if err != nil {
+			return nil, err
+		}
+		sub, err := splitDataFrame(part, partSize, maxSize)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, sub...)
+	}
+	return result, nil
+}
+
+// splitMap splits m, where the encoded size is size, into maps where the
+// encoded size of each is at most maxSize. The size of each entry is estimated
+// once.
+func splitMap[V any](m map[string]V, size, maxSize int) ([]map[string]V, error) {
+	if size <= maxSize {
+		return []map[string]V{m}, nil
+	}
+
+	var result []map[string]V
+	part, partSize := make(map[string]V), 1
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		// Count the entry with a separator instead of the enclosing braces.
+		entrySize, err := EstimatePayloadSize(map[string]V{k: m[k]})
+		if err != nil {
+			return nil, err
+		}
+		entrySize--
+		if entrySize+1 > maxSize {
+			return nil, fmt.Errorf("%w: %q: single entry exceeds %d bytes", ErrPayloadTooLarge, k, maxSize)
+		}
+		if len(part) > 0 && partSize+entrySize > maxSize {
+			result = append(result, part)
+			part, partSize = make(map[string]V), 1
+		}
+		part[k] = m[k]
+		partSize += entrySize
+	}
+	if len(part) > 0 {
+		result = append(result, part)
+	}
+	return result, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clarify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// SecretProvider describe a source of secrets, such as a secrets manager.
+type SecretProvider interface {
+	// Secret returns the secret value for the passed in provider specific
+	// reference.
+	Secret(ctx context.Context, ref string) ([]byte, error)
+}
+
+// SecretProviderFunc allows a function to be used as a SecretProvider. This
+// can be used to adapt the SDK for a secrets manager without adding a
+// dependency to this module. E.g. for AWS Secrets Manager:
+//
+//	provider := clarify.SecretProviderFunc(func(ctx context.Context, ref string) ([]byte, error) {
+//		out, err := sm.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: &ref})
+//		if err != nil {
+//			return nil, err
+//		}
+//		return []byte(*out.SecretString), nil
+//	})
+//
+// Or for GCP Secret Manager:
+//
+//	provider := clarify.SecretProviderFunc(func(ctx context.Context, ref string) ([]byte, error) {
+//		resp, err := sm.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: ref})
+//		if err != nil {
+//			return nil, err
+//		}
+//		return resp.Payload.Data, nil
+//	})
+type SecretProviderFunc func(ctx context.Context, ref string) ([]byte, error)
+
+func (f SecretProviderFunc) Secret(ctx context.Context, ref string) ([]byte, error) {
+	return f(ctx, ref)
+}
+
+// CredentialsFromSecret parse Clarify Credentials from the secret with the
+// passed in reference, and return either valid credentials or an error. The
+// secret value must hold the credentials JSON, as downloaded from Clarify.
+// This allows production deployments to avoid credential files on disk.
+func CredentialsFromSecret(ctx context.Context, provider SecretProvider, ref string) (*Credentials, error) {
+	b, err := provider.Secret(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("secret %q: %w", ref, err)
+	}
+	return CredentialsFromReader(bytes.NewReader(b))
+}
+
+// VaultSecrets is a SecretProvider for HashiCorp Vault KV secrets engines,
+// using the Vault HTTP API.
+//
+// References are on the format "<path>" or "<path>#<key>", where path is the
+// API path of the secret, such as "secret/data/clarify" for KV version 2. If a
+// key is specified, the secret value is the value stored at that key, which
+// must be a string. Otherwise, the secret value is all key-value pairs of the
+// secret encoded as a JSON object. This allows storing the fields of the
+// credentials JSON directly in Vault.
+type VaultSecrets struct {
+	// Address holds the Vault server address. If empty, the value of the
+	// VAULT_ADDR environment variable is used.
+	Address string
+
+	// Token holds the Vault token. If empty, the value of the VAULT_TOKEN
+	// environment variable is used.
+	Token string
+
+	// Client holds the HTTP client to use. If nil, http.DefaultClient is used.
+	Client *http.Client
+}
+
+var _ SecretProvider = VaultSecrets{}
+
+func (v VaultSecrets) Secret(ctx context.Context, ref string) ([]byte, error) {
+	addr := v.Address
+	if addr == "" {
+		addr = os.Getenv("VAULT_ADDR")
+	}
+	token := v.Token
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	c := v.Client
+	if c == nil {
+		c = http.DefaultClient
+	}
+	path, key, hasKey := strings.Cut(ref, "#")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(addr, "/")+"/v1/"+strings.TrimLeft(path, "/"), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("vault: %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+
+	var result struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("vault: %w", err)
+	}
+	data := result.Data
+	// KV version 2 nests the secret data inside a data field, next to a
+	// metadata field.
+	if _, ok := data["metadata"]; ok {
+		var nested map[string]json.RawMessage
+		if err := json.Unmarshal(data["data"], &nested); err != nil {
+			return nil, fmt.Errorf("vault: %w", err)
+		}
+		data = nested
+	}
+
+	if !hasKey {
+		return json.Marshal(data)
+	}
+	raw, ok := data[key]
+	if !ok {
+		return nil, fmt.Errorf("vault: key %q not found", key)
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, fmt.Errorf("vault: key %q: value must be a string", key)
+	}
+	return []byte(s), nil
+}
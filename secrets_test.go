@@ -0,0 +1,77 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clarify_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/clarify/clarify-go"
+)
+
+func TestCredentialsFromVault(t *testing.T) {
+	const creds = `{"apiUrl":"https://api.clarify.io/v1/","integration":"i1","credentials":{"type":"basic-auth","clientId":"i1","clientSecret":"s3cret"}}`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/clarify":
+			w.Write([]byte(`{"data":{"data":` + creds + `,"metadata":{"version":1}}}`))
+		case "/v1/kv/clarify":
+			w.Write([]byte(`{"data":{"credentials":` + string(mustQuote(creds)) + `}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	test := func(v clarify.VaultSecrets, ref string, expectErr bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			result, err := clarify.CredentialsFromSecret(context.Background(), v, ref)
+			if expectErr {
+				if err == nil {
+					t.Fatalf("Expected error, got: %+v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result.Integration != "i1" || result.Credentials.ClientSecret != "s3cret" {
+				t.Errorf("Unexpected credentials: %+v", result)
+			}
+		}
+	}
+	v := clarify.VaultSecrets{Address: srv.URL, Token: "token"}
+	t.Run("KVv2", test(v, "secret/data/clarify", false))
+	t.Run("KVv1Key", test(v, "kv/clarify#credentials", false))
+	t.Run("MissingKey", test(v, "kv/clarify#other", true))
+	t.Run("NotFound", test(v, "secret/data/other", true))
+	t.Run("Forbidden", test(clarify.VaultSecrets{Address: srv.URL, Token: "bad"}, "secret/data/clarify", true))
+}
+
+func mustQuote(s string) []byte {
+	b, err := json.Marshal(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
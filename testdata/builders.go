@@ -0,0 +1,243 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testdata
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+// Default values used by the fixture builders. The values match the static
+// JSON results in this package.
+const (
+	DefaultIntegrationID = "c8ktonqsahsmemfs7lv0"
+	DefaultSignalID      = "c8keagasahsp3cpvma20"
+	DefaultItemID        = "c8l95d2sahsh22imiabg"
+)
+
+// DefaultTime is used for the createdAt and updatedAt meta fields.
+var DefaultTime = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// SignalOption describe a function for modifying a signal fixture.
+type SignalOption func(*views.Signal)
+
+// ItemOption describe a function for modifying an item fixture.
+type ItemOption func(*views.Item)
+
+// NewSignal returns a signal fixture with realistic defaults, modified by the
+// passed in options.
+func NewSignal(opts ...SignalOption) views.Signal {
+	signal := views.Signal{
+		Identifier: views.Identifier{Type: "signals", ID: DefaultSignalID},
+		Meta: views.Meta{
+			Annotations: fields.Annotations{},
+			CreatedAt:   DefaultTime,
+			UpdatedAt:   DefaultTime,
+		},
+		Attributes: views.SignalAttributes{
+			SignalSaveAttributes: views.SignalSaveAttributes{
+				Name:         "Temperature",
+				Description:  "Inside temperature",
+				ValueType:    views.Numeric,
+				SourceType:   views.Measurement,
+				EngUnit:      "°C",
+				GapDetection: fields.AsFixedDurationNullZero(time.Hour),
+				Labels:       fields.Labels{"location": {"Living room", "inside"}},
+				EnumValues:   fields.EnumValues{},
+			},
+			SignalReadOnlyAttributes: views.SignalReadOnlyAttributes{
+				Input: "temp",
+			},
+		},
+		Relationships: views.SignalRelationships{
+			Integration: views.ToOne{Data: views.NullIdentifier{Type: "integrations", ID: DefaultIntegrationID}},
+		},
+	}
+	for _, opt := range opts {
+		opt(&signal)
+	}
+	return signal
+}
+
+// SignalID returns an option that sets the signal ID.
+func SignalID(id string) SignalOption {
+	return func(s *views.Signal) { s.ID = id }
+}
+
+// SignalInput returns an option that sets the signal input key.
+func SignalInput(input string) SignalOption {
+	return func(s *views.Signal) { s.Attributes.Input = input }
+}
+
+// SignalName returns an option that sets the signal name.
+func SignalName(name string) SignalOption {
+	return func(s *views.Signal) { s.Attributes.Name = name }
+}
+
+// SignalLabels returns an option that replaces the signal labels.
+func SignalLabels(labels fields.Labels) SignalOption {
+	return func(s *views.Signal) { s.Attributes.Labels = labels }
+}
+
+// SignalItem returns an option that sets the item relationship, marking the
+// signal as published.
+func SignalItem(itemID string) SignalOption {
+	return func(s *views.Signal) {
+		s.Relationships.Item = views.ToOne{Data: views.NullIdentifier{Type: "items", ID: itemID}}
+	}
+}
+
+// NewItem returns an item fixture with realistic defaults, modified by the
+// passed in options.
+func NewItem(opts ...ItemOption) views.Item {
+	item := views.Item{
+		Identifier: views.Identifier{Type: "items", ID: DefaultItemID},
+		Meta: views.Meta{
+			Annotations: fields.Annotations{},
+			CreatedAt:   DefaultTime,
+			UpdatedAt:   DefaultTime,
+		},
+		Attributes: views.ItemAttributes{
+			ItemSaveAttributes: views.ItemSaveAttributes{
+				Name:         "Temperature",
+				Description:  "Inside temperature",
+				ValueType:    views.Numeric,
+				SourceType:   views.Measurement,
+				EngUnit:      "°C",
+				GapDetection: fields.AsFixedDurationNullZero(time.Hour),
+				Labels:       fields.Labels{"location": {"Living room", "inside"}},
+				EnumValues:   fields.EnumValues{},
+				Visible:      true,
+			},
+		},
+	}
+	for _, opt := range opts {
+		opt(&item)
+	}
+	return item
+}
+
+// ItemID returns an option that sets the item ID.
+func ItemID(id string) ItemOption {
+	return func(item *views.Item) { item.ID = id }
+}
+
+// ItemName returns an option that sets the item name.
+func ItemName(name string) ItemOption {
+	return func(item *views.Item) { item.Attributes.Name = name }
+}
+
+// ItemLabels returns an option that replaces the item labels.
+func ItemLabels(labels fields.Labels) ItemOption {
+	return func(item *views.Item) { item.Attributes.Labels = labels }
+}
+
+// ItemVisible returns an option that sets the item visibility.
+func ItemVisible(visible bool) ItemOption {
+	return func(item *views.Item) { item.Attributes.Visible = visible }
+}
+
+// ItemSampleInterval returns an option that sets the item sample interval.
+func ItemSampleInterval(d time.Duration) ItemOption {
+	return func(item *views.Item) { item.Attributes.SampleInterval = fields.AsFixedDurationNullZero(d) }
+}
+
+// ItemAnnotation returns an option that sets an item annotation.
+func ItemAnnotation(key, value string) ItemOption {
+	return func(item *views.Item) { item.Meta.Annotations.Set(key, value) }
+}
+
+// NewSelectSignals returns a selection result for signals, matching the
+// result format of admin.selectSignals.
+func NewSelectSignals(signals []views.Signal, included []views.Item) views.Selection[[]views.Signal, views.SignalInclude] {
+	return views.Selection[[]views.Signal, views.SignalInclude]{
+		Meta:     selectionMeta(len(signals), true),
+		Data:     signals,
+		Included: views.SignalInclude{Items: included},
+	}
+}
+
+// NewSelectItems returns a selection result for items, matching the result
+// format of clarify.selectItems.
+func NewSelectItems(items []views.Item) views.Selection[[]views.Item, views.ItemInclude] {
+	return views.Selection[[]views.Item, views.ItemInclude]{
+		Meta: selectionMeta(len(items), true),
+		Data: items,
+	}
+}
+
+// NewDataFrameRollup returns a data frame selection result with one bucket per
+// rollup duration in the time range [gte,lt), matching the result format of
+// clarify.dataFrame with a fixed duration rollup. For each item, the series
+// "<id>_sum", "<id>_avg", "<id>_min", "<id>_max" and "<id>_count" are
+// included. Values are deterministic, and the passed in items are included
+// in the result. If rollup is not positive, a single window bucket is used.
+func NewDataFrameRollup(items []views.Item, gte, lt time.Time, rollup time.Duration) views.Selection[views.DataFrame, views.DataFrameInclude] {
+	if rollup <= 0 {
+		rollup = max(lt.Sub(gte), 1)
+	}
+	df := make(views.DataFrame, len(items)*5)
+	for j, item := range items {
+		series := map[string]views.DataSeries{
+			"sum":   {},
+			"avg":   {},
+			"min":   {},
+			"max":   {},
+			"count": {},
+		}
+		i := 0
+		for t := gte; t.Before(lt); t = t.Add(rollup) {
+			ts := fields.AsTimestamp(t)
+			avg := float64(10*(j+1) + i)
+			series["sum"][ts] = 2 * avg
+			series["avg"][ts] = avg
+			series["min"][ts] = avg - 1
+			series["max"][ts] = avg + 1
+			series["count"][ts] = 2
+			i++
+		}
+		for k, s := range series {
+			df[item.ID+"_"+k] = s
+		}
+	}
+	return views.Selection[views.DataFrame, views.DataFrameInclude]{
+		Meta:     selectionMeta(len(items), false),
+		Data:     df,
+		Included: views.DataFrameInclude{Items: items},
+	}
+}
+
+// JSON returns the JSON encoding of v, panicking on error. It's useful for
+// producing raw results for mock request handlers.
+func JSON(v any) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func selectionMeta(total int, dataAsArray bool) views.SelectionMeta {
+	return views.SelectionMeta{
+		Total: total,
+		Format: views.SelectionFormat{
+			DataAsArray:         dataAsArray,
+			GroupIncludedByType: true,
+		},
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testdata_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/clarify/clarify-go/testdata"
+	"github.com/clarify/clarify-go/views"
+)
+
+func ExampleNewDataFrameRollup() {
+	item := testdata.NewItem(testdata.ItemID("i1"))
+	gte := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	raw := testdata.JSON(testdata.NewDataFrameRollup([]views.Item{item}, gte, gte.Add(2*time.Hour), time.Hour))
+
+	var result views.Selection[views.DataFrame, views.DataFrameInclude]
+	if err := json.Unmarshal(raw, &result); err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	fmt.Println("len(data):", len(result.Data))
+	fmt.Println("data.i1_avg:", result.Data["i1_avg"])
+	fmt.Println("included.items.0.id:", result.Included.Items[0].ID)
+
+	// Output:
+	// len(data): 5
+	// data.i1_avg: map[1704067200000000:10 1704070800000000:11]
+	// included.items.0.id: i1
+}
+
+func ExampleNewSelectSignals() {
+	signal := testdata.NewSignal(
+		testdata.SignalID("s1"),
+		testdata.SignalItem("i1"),
+	)
+	raw := testdata.JSON(testdata.NewSelectSignals([]views.Signal{signal}, nil))
+
+	var result views.Selection[[]views.Signal, views.SignalInclude]
+	if err := json.Unmarshal(raw, &result); err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	itemID, _ := result.Data[0].Relationships.ItemID()
+	fmt.Println("data.0.id:", result.Data[0].ID)
+	fmt.Println("data.0.relationships.item:", itemID)
+	fmt.Println("data.0.meta.attributesHash set:", result.Data[0].Meta.AttributesHash != nil)
+
+	// Output:
+	// data.0.id: s1
+	// data.0.relationships.item: i1
+	// data.0.meta.attributesHash set: true
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clarify
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/jsonrpc"
+	"github.com/clarify/clarify-go/views"
+)
+
+// ErrBadTimestamp is returned for Insert requests performed by a client
+// configured with WithTimestampValidation and TimestampReject, when the data
+// contain timestamps outside of the accepted range.
+const ErrBadTimestamp strError = "timestamp out of range"
+
+// TimestampAction describe how a client handles Insert requests where the data
+// contain timestamps outside of the accepted range.
+type TimestampAction int
+
+// Timestamp actions.
+const (
+	// TimestampReject fails the request with an error wrapping ErrBadRequest,
+	// ErrBadTimestamp and PathErrors. No data is sent.
+	TimestampReject TimestampAction = iota
+
+	// TimestampDrop removes out of range samples before the request is sent.
+	TimestampDrop
+
+	// TimestampClamp merges samples that are too far into the future into a
+	// single sample at the latest accepted time, and removes samples that are
+	// too old. The merged sample holds the value of the sample with the latest
+	// original timestamp, and is only added if the series has no sample at the
+	// latest accepted time already. Other future samples are dropped.
+	TimestampClamp
+)
+
+// TimestampValidation describe the accepted range of timestamps for Insert
+// requests. This protects against silently accepting data from devices with a
+// misconfigured clock. See WithTimestampValidation.
+type TimestampValidation struct {
+	// MaxSkew sets how far into the future, relative to the client clock, a
+	// timestamp is accepted. If 0, future timestamps are not checked.
+	MaxSkew time.Duration
+
+	// MaxAge sets how far into the past, relative to the client clock, a
+	// timestamp is accepted. If 0, the age of timestamps is not checked.
+	MaxAge time.Duration
+
+	// Action describe how to handle out of range timestamps.
+	Action TimestampAction
+}
+
+// WithTimestampValidation returns an option that validates the timestamps of
+// Insert requests client-side against the range described by v, where the
+// current time is given by the client clock; see WithClock. Samples that are
+// dropped are counted per series in InsertResult.DroppedByInput.
+func WithTimestampValidation(v TimestampValidation) ClientOption {
+	return func(opts *clientOptions) {
+		opts.timestamps = &v
+	}
+}
+
+// timestampHandler wraps a handler to validate timestamps for Insert requests.
+type timestampHandler struct {
+	jsonrpc.Handler
+	TimestampValidation
+	now func() time.Time
+}
+
+func (h timestampHandler) Do(ctx context.Context, req jsonrpc.Request, result any) error {
+	params, ok := req.Params.(map[string]any)
+	if !ok || req.Method != methodInsert.Method {
+		return h.Handler.Do(ctx, req, result)
+	}
+	data, _ := params[string(paramData)].(views.DataFrame)
+
+	now := time.Now()
+	if h.now != nil {
+		now = h.now()
+	}
+	var gte, lte time.Time
+	if h.MaxAge > 0 {
+		gte = now.Add(-h.MaxAge)
+	}
+	if h.MaxSkew > 0 {
+		lte = now.Add(h.MaxSkew)
+	}
+
+	issues := make(PathErrors)
+	dropped := make(map[string]int)
+	var out views.DataFrame
+	for _, k := range slices.Sorted(maps.Keys(data)) {
+		s, old, future := h.filter(data[k], gte, lte)
+		if old == 0 && future == 0 {
+			continue
+		}
+		if h.Action == TimestampReject {
+			if old > 0 {
+				issues["data."+k] = append(issues["data."+k], fmt.Sprintf("%d timestamps before %s", old, gte.Format(time.RFC3339)))
+			}
+			if future > 0 {
+				issues["data."+k] = append(issues["data."+k], fmt.Sprintf("%d timestamps after %s", future, lte.Format(time.RFC3339)))
+			}
+			continue
+		}
+		if out == nil {
+			out = maps.Clone(data)
+		}
+		out[k] = s
+		if n := len(data[k]) - len(s); n > 0 {
+			dropped[k] = n
+		}
+	}
+	if len(issues) > 0 {
+		return joinErrors(ErrBadRequest, joinErrors(ErrBadTimestamp, issues, ": "), ": ")
+	}
+	if out != nil {
+		subParams := maps.Clone(params)
+		subParams[string(paramData)] = out
+		req.Params = subParams
+	}
+
+	// Dropped samples are reported also when the request fails, as they were
+	// not sent regardless of the outcome.
+	err := h.Handler.Do(ctx, req, result)
+	if res, ok := result.(*InsertResult); ok && len(dropped) > 0 {
+		res.DroppedByInput = dropped
+	}
+	return err
+}
+
+// filter returns s with out of range samples handled according to the
+// configured action, and the number of samples that are too old or too far
+// into the future. A zero gte or lte is not checked.
+func (h timestampHandler) filter(s views.DataSeries, gte, lte time.Time) (out views.DataSeries, old, future int) {
+	lo, hi := fields.AsTimestamp(gte), fields.AsTimestamp(lte)
+	out = make(views.DataSeries, len(s))
+	var latest fields.Timestamp
+	for t, v := range s {
+		switch {
+		case !gte.IsZero() && t < lo:
+			old++
+		case !lte.IsZero() && t > hi:
+			future++
+			latest = max(latest, t)
+		default:
+			out[t] = v
+		}
+	}
+	if _, ok := out[hi]; h.Action == TimestampClamp && future > 0 && !ok {
+		out[hi] = s[latest]
+	}
+	return out, old, future
+}
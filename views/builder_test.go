@@ -0,0 +1,76 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestSignalBuilder(t *testing.T) {
+	base := views.NewSignal("Status").Label("location", "pier")
+	result, err := base.Enum(0, "ok").Enum(1, "alarm").SampleInterval(15 * time.Minute).Build()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var expect views.SignalSave
+	expect.Name = "Status"
+	expect.Labels = fields.Labels{"location": {"pier"}}
+	expect.ValueType = views.Enum
+	expect.EnumValues = fields.EnumValues{0: "ok", 1: "alarm"}
+	expect.SampleInterval = fields.FixedDurationNullZero{Duration: 15 * time.Minute}
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("Unexpected result:\n got: %+v\nwant: %+v", result, expect)
+	}
+
+	// The base builder must not be affected by derived builders.
+	other, err := base.Label("location", "dock").Build()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if l := other.Labels.Get("location"); !reflect.DeepEqual(l, []string{"dock", "pier"}) {
+		t.Errorf("Unexpected labels:\n got: %v\nwant: %v", l, []string{"dock", "pier"})
+	}
+	if l := result.Labels.Get("location"); !reflect.DeepEqual(l, []string{"pier"}) {
+		t.Errorf("Unexpected labels for first result:\n got: %v\nwant: %v", l, []string{"pier"})
+	}
+
+	_, err = views.NewSignal(strings.Repeat("x", views.MaxNameLength+1)).Build()
+	if !errors.Is(err, views.ErrBadResource) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, views.ErrBadResource)
+	}
+}
+
+func TestItemBuilder(t *testing.T) {
+	result, err := views.NewItem("Temperature").EngUnit("degC").Visible(false).Build()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Name != "Temperature" || result.EngUnit != "degC" || result.Visible {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+
+	_, err = views.NewItem("x").SampleInterval(time.Minute).GapDetection(time.Second).Build()
+	if !errors.Is(err, views.ErrBadResource) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, views.ErrBadResource)
+	}
+}
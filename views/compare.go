@@ -0,0 +1,90 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views
+
+import (
+	"math"
+	"time"
+)
+
+// CompareFunc describe a function for comparing a current value against a
+// previous value. See Delta and Ratio.
+type CompareFunc func(current, previous float64) float64
+
+// Delta returns current - previous.
+func Delta(current, previous float64) float64 {
+	return current - previous
+}
+
+// Ratio returns current / previous.
+func Ratio(current, previous float64) float64 {
+	return current / previous
+}
+
+// Shift returns a new series where all timestamps in s are moved by d.
+func (s DataSeries) Shift(d time.Duration) DataSeries {
+	out := make(DataSeries, len(s))
+	for t, v := range s {
+		out[t.Add(d)] = v
+	}
+	return out
+}
+
+// Shift returns a new data frame where the Shift method is applied to each
+// series in df. This can be used to align the result of a request against a
+// previous time window, such as the same window last week, with the current
+// one.
+func (df DataFrame) Shift(d time.Duration) DataFrame {
+	out := make(DataFrame, len(df))
+	for k, s := range df {
+		out[k] = s.Shift(d)
+	}
+	return out
+}
+
+// Compare returns a new series where f is applied to all values in s that has
+// a value with the same timestamp in previous. Results that are NaN or
+// infinite, such as from division by zero, are omitted.
+func (s DataSeries) Compare(previous DataSeries, f CompareFunc) DataSeries {
+	out := make(DataSeries, len(s))
+	for t, v := range s {
+		pv, ok := previous[t]
+		if !ok {
+			continue
+		}
+		r := f(v, pv)
+		if math.IsNaN(r) || math.IsInf(r, 0) {
+			continue
+		}
+		out[t] = r
+	}
+	return out
+}
+
+// Compare returns a new data frame where the Compare method is applied
+// series-by-series for series keys present in both current and previous. The
+// previous data frame should be shifted in time to align with current before
+// comparing; see DataFrame.Shift.
+func Compare(current, previous DataFrame, f CompareFunc) DataFrame {
+	out := make(DataFrame, len(current))
+	for k, s := range current {
+		ps, ok := previous[k]
+		if !ok {
+			continue
+		}
+		out[k] = s.Compare(ps, f)
+	}
+	return out
+}
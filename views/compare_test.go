@@ -0,0 +1,56 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestCompare(t *testing.T) {
+	t0 := fields.AsTimestamp(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC))
+	week := 7 * 24 * time.Hour
+	at := func(hours int) fields.Timestamp {
+		return t0.Add(time.Duration(hours) * time.Hour)
+	}
+
+	current := views.DataFrame{
+		"a": {at(0): 10, at(1): 20, at(2): 30},
+		"b": {at(0): 1},
+	}
+	previous := views.DataFrame{
+		"a": {at(0).Add(-week): 5, at(1).Add(-week): 0},
+		"c": {at(0).Add(-week): 1},
+	}
+
+	test := func(f views.CompareFunc, expect views.DataFrame) func(t *testing.T) {
+		return func(t *testing.T) {
+			result := views.Compare(current, previous.Shift(week), f)
+			if !reflect.DeepEqual(result, expect) {
+				t.Errorf("Unexpected result:\n got: %v\nwant: %v", result, expect)
+			}
+		}
+	}
+	t.Run("Delta", test(views.Delta, views.DataFrame{
+		"a": {at(0): 5, at(1): 20},
+	}))
+	t.Run("Ratio", test(views.Ratio, views.DataFrame{
+		"a": {at(0): 2},
+	}))
+}
@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -16,6 +16,8 @@ package views
 
 import (
 	"encoding/json"
+	"iter"
+	"maps"
 	"math"
 	"slices"
 	"sort"
@@ -24,7 +26,12 @@ import (
 )
 
 type DataFrameInclude struct {
-	Items []Item
+	Items []Item `json:"items"`
+}
+
+// ItemByID returns the included item with the given ID.
+func (inc DataFrameInclude) ItemByID(id string) (Item, bool) {
+	return itemByID(inc.Items, id)
 }
 
 // DataSeries contain a map of timestamps in micro seconds since the epoch to
@@ -32,7 +39,7 @@ type DataFrameInclude struct {
 type DataSeries map[fields.Timestamp]float64
 
 // Timestamps returns an ordered set of all timestamps in the data-series where
-// there is at least one NaN value.
+// the value is non-empty (not NaN).
 func (s DataSeries) Timestamps() []fields.Timestamp {
 	ordered := make([]fields.Timestamp, 0, len(s))
 	for t, v := range s {
@@ -45,6 +52,38 @@ func (s DataSeries) Timestamps() []fields.Timestamp {
 	return ordered
 }
 
+// Sample describe a single data-point in a data series.
+type Sample struct {
+	Time  fields.Timestamp
+	Value float64
+}
+
+// Latest returns the non-empty (not NaN) sample with the greatest timestamp in
+// s. If there is no such sample, ok is false.
+func (s DataSeries) Latest() (_ Sample, ok bool) {
+	var latest Sample
+	for t, v := range s {
+		if math.IsNaN(v) || (ok && t <= latest.Time) {
+			continue
+		}
+		latest, ok = Sample{Time: t, Value: v}, true
+	}
+	return latest, ok
+}
+
+// All returns an iterator over all timestamp and value pairs in s where the
+// value is non-empty (not NaN), ordered by timestamp. The timestamps match the
+// ones returned by Timestamps.
+func (s DataSeries) All() iter.Seq2[fields.Timestamp, float64] {
+	return func(yield func(fields.Timestamp, float64) bool) {
+		for _, t := range s.Timestamps() {
+			if !yield(t, s[t]) {
+				return
+			}
+		}
+	}
+}
+
 // DataFrame provides JSON encoding and decoding for a map of series identified
 // by a series key.
 type DataFrame map[string]DataSeries
@@ -74,6 +113,17 @@ func (df DataFrame) Timestamps() []fields.Timestamp {
 	return ordered
 }
 
+// Series returns an iterator over all series in df, ordered by series key.
+func (df DataFrame) Series() iter.Seq2[string, DataSeries] {
+	return func(yield func(string, DataSeries) bool) {
+		for _, k := range slices.Sorted(maps.Keys(df)) {
+			if !yield(k, df[k]) {
+				return
+			}
+		}
+	}
+}
+
 // ordered returns a valid and ordered RawDataFrame with duplicated entries
 // removed.
 func (df DataFrame) ordered() rawDataFrame {
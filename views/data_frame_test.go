@@ -0,0 +1,68 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestIterators(t *testing.T) {
+	t0 := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	t1 := t0.Add(time.Second)
+	t2 := t0.Add(2 * time.Second)
+	df := views.DataFrame{
+		"b": {t2: 3, t0: 1, t1: 2},
+		"a": {t1: 4, t2: math.NaN()},
+		"c": {},
+	}
+
+	var keys []string
+	var values []float64
+	for k, s := range df.Series() {
+		keys = append(keys, k)
+		for _, v := range s.All() {
+			values = append(values, v)
+		}
+	}
+	if expect := []string{"a", "b", "c"}; !slices.Equal(keys, expect) {
+		t.Errorf("Unexpected keys:\n got: %v\nwant: %v", keys, expect)
+	}
+	if expect := []float64{4, 1, 2, 3}; !slices.Equal(values, expect) {
+		t.Errorf("Unexpected values:\n got: %v\nwant: %v", values, expect)
+	}
+
+	// All skips NaN values, like Timestamps.
+	var times []fields.Timestamp
+	for ts := range df["a"].All() {
+		times = append(times, ts)
+	}
+	if expect := df["a"].Timestamps(); !slices.Equal(times, expect) {
+		t.Errorf("Unexpected timestamps:\n got: %v\nwant: %v", times, expect)
+	}
+
+	// Break early.
+	for k := range df.Series() {
+		if k != "a" {
+			t.Errorf("Unexpected first key:\n got: %v\nwant: %v", k, "a")
+		}
+		break
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views
+
+import (
+	"math"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+)
+
+// Derivative returns a new series with the rate of change per second between
+// each pair of consecutive values in s. The result is stored at the timestamp
+// of the latter value, and the first timestamp in s is therefore omitted.
+// NaN values are ignored.
+func (s DataSeries) Derivative() DataSeries {
+	times := s.Timestamps()
+	out := make(DataSeries, max(len(times)-1, 0))
+	for i := 1; i < len(times); i++ {
+		t0, t1 := times[i-1], times[i]
+		out[t1] = (s[t1] - s[t0]) / t1.Sub(t0).Seconds()
+	}
+	return out
+}
+
+// Integral returns a new series with the cumulative integral of s over time in
+// value-seconds, calculated using the trapezoidal rule. The result starts at 0
+// at the first timestamp in s. NaN values are ignored.
+func (s DataSeries) Integral() DataSeries {
+	times := s.Timestamps()
+	out := make(DataSeries, len(times))
+	var sum float64
+	for i, t1 := range times {
+		if i > 0 {
+			t0 := times[i-1]
+			sum += (s[t0] + s[t1]) / 2 * t1.Sub(t0).Seconds()
+		}
+		out[t1] = sum
+	}
+	return out
+}
+
+// EWMA returns a new series with the exponentially weighted moving average of
+// s, using the smoothing factor alpha in range (0,1]. A higher alpha discounts
+// older values faster. The first value of the result is equal to the first
+// value in s. NaN values are ignored.
+//
+// Note that the smoothing factor is applied per value, and not per time unit.
+// For irregularly sampled series, consider resampling the series first.
+func (s DataSeries) EWMA(alpha float64) DataSeries {
+	times := s.Timestamps()
+	out := make(DataSeries, len(times))
+	var avg float64
+	for i, t := range times {
+		switch i {
+		case 0:
+			avg = s[t]
+		default:
+			avg = alpha*s[t] + (1-alpha)*avg
+		}
+		out[t] = avg
+	}
+	return out
+}
+
+// RollingMean returns a new series where each value is the mean of all values
+// in s within the time range (t-window,t]. The value at t is always included,
+// also when window is zero or negative. NaN values are ignored.
+func (s DataSeries) RollingMean(window time.Duration) DataSeries {
+	times := s.Timestamps()
+	out := make(DataSeries, len(times))
+	var sum float64
+	start := 0
+	for i, t := range times {
+		sum += s[t]
+		for start < i && times[start].Add(window) <= t {
+			sum -= s[times[start]]
+			start++
+		}
+		out[t] = sum / float64(i+1-start)
+	}
+	return out
+}
+
+// Deadband returns a new series where values that have not changed by more than
+// absDelta since the last kept value are dropped. The first value is always
+// kept. If maxInterval is positive, a value is also kept when at least
+// maxInterval has passed since the last kept value, so that a heartbeat sample
+// is stored for slowly-changing series. NaN values are ignored.
+func (s DataSeries) Deadband(absDelta float64, maxInterval time.Duration) DataSeries {
+	times := s.Timestamps()
+	out := make(DataSeries)
+	var lastT fields.Timestamp
+	var lastV float64
+	for i, t := range times {
+		v := s[t]
+		keep := i == 0 ||
+			math.Abs(v-lastV) > absDelta ||
+			(maxInterval > 0 && t.Sub(lastT) >= maxInterval)
+		if keep {
+			out[t] = v
+			lastT, lastV = t, v
+		}
+	}
+	return out
+}
+
+// Deadband returns a new data frame where the Deadband method is applied to
+// each series in df. This can be used before Insert to reduce the ingested
+// data volume for slowly-changing series.
+func Deadband(df DataFrame, absDelta float64, maxInterval time.Duration) DataFrame {
+	out := make(DataFrame, len(df))
+	for k, s := range df {
+		out[k] = s.Deadband(absDelta, maxInterval)
+	}
+	return out
+}
+
+// Downsample returns a new series with at most n values selected from s using
+// the Largest-Triangle-Three-Buckets (LTTB) algorithm. The algorithm keeps the
+// first and last value, and picks the value in each bucket in between that
+// forms the largest triangle with its neighbors. This preserves the visual
+// shape of the series, and is useful for plotting large series. If n is less
+// than 3 or not less than the number of values in s, a copy of s is returned.
+// NaN values are ignored.
+func (s DataSeries) Downsample(n int) DataSeries {
+	times := s.Timestamps()
+	if n < 3 || n >= len(times) {
+		out := make(DataSeries, len(times))
+		for _, t := range times {
+			out[t] = s[t]
+		}
+		return out
+	}
+
+	out := make(DataSeries, n)
+	a := times[0]
+	out[a] = s[a]
+	bucketSize := float64(len(times)-2) / float64(n-2)
+	for i := range n - 2 {
+		// Calculate the average point of the next bucket.
+		next := times[int(float64(i+1)*bucketSize)+1 : min(int(float64(i+2)*bucketSize)+1, len(times))]
+		var avgX, avgY float64
+		for _, t := range next {
+			avgX += float64(t)
+			avgY += s[t]
+		}
+		avgX /= float64(len(next))
+		avgY /= float64(len(next))
+
+		// Select the point in the current bucket that forms the largest
+		// triangle with the previously selected point and the average point.
+		maxArea := -1.0
+		var selected fields.Timestamp
+		for _, t := range times[int(float64(i)*bucketSize)+1 : int(float64(i+1)*bucketSize)+1] {
+			area := math.Abs((float64(a)-avgX)*(s[t]-s[a]) - (float64(a)-float64(t))*(avgY-s[a]))
+			if area > maxArea {
+				maxArea, selected = area, t
+			}
+		}
+		out[selected] = s[selected]
+		a = selected
+	}
+	last := times[len(times)-1]
+	out[last] = s[last]
+	return out
+}
+
+// Downsample returns a new data frame where the Downsample method is applied to
+// each series in df. This can be used to reduce the size of large series
+// before plotting them.
+func Downsample(df DataFrame, n int) DataFrame {
+	out := make(DataFrame, len(df))
+	for k, s := range df {
+		out[k] = s.Downsample(n)
+	}
+	return out
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views_test
+
+import (
+	"maps"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestDataSeriesHelpers(t *testing.T) {
+	t0 := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	t1 := t0.Add(10 * time.Second)
+	t2 := t0.Add(20 * time.Second)
+	t3 := t0.Add(40 * time.Second)
+	tNaN := t0.Add(5 * time.Second)
+	s := views.DataSeries{
+		t0:   0,
+		t1:   10,
+		t2:   10,
+		t3:   30,
+		tNaN: math.NaN(),
+	}
+
+	test := func(result, expect views.DataSeries) func(t *testing.T) {
+		return func(t *testing.T) {
+			if !maps.Equal(result, expect) {
+				t.Errorf("Unexpected result:\n got: %v\nwant: %v", result, expect)
+			}
+		}
+	}
+
+	t.Run("Derivative", test(s.Derivative(), views.DataSeries{
+		t1: 1,
+		t2: 0,
+		t3: 1,
+	}))
+	t.Run("Integral", test(s.Integral(), views.DataSeries{
+		t0: 0,
+		t1: 50,
+		t2: 150,
+		t3: 550,
+	}))
+	t.Run("EWMA", test(s.EWMA(0.5), views.DataSeries{
+		t0: 0,
+		t1: 5,
+		t2: 7.5,
+		t3: 18.75,
+	}))
+	t.Run("RollingMean", test(s.RollingMean(20*time.Second), views.DataSeries{
+		t0: 0,
+		t1: 5,
+		t2: 10,
+		t3: 30,
+	}))
+	t.Run("Deadband", test(s.Deadband(5, 0), views.DataSeries{
+		t0: 0,
+		t1: 10,
+		t3: 30,
+	}))
+	t.Run("Deadband heartbeat", test(s.Deadband(50, 20*time.Second), views.DataSeries{
+		t0: 0,
+		t2: 10,
+		t3: 30,
+	}))
+	t.Run("Downsample", test(s.Downsample(3), views.DataSeries{
+		t0: 0,
+		t2: 10,
+		t3: 30,
+	}))
+	t.Run("Downsample no-op", test(s.Downsample(10), views.DataSeries{
+		t0: 0,
+		t1: 10,
+		t2: 10,
+		t3: 30,
+	}))
+}
+
+func TestDownsample(t *testing.T) {
+	t0 := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	s := make(views.DataSeries, 1000)
+	for i := range 1000 {
+		s[t0.Add(time.Duration(i)*time.Second)] = math.Sin(float64(i) / 50)
+	}
+	df := views.Downsample(views.DataFrame{"a": s}, 100)
+
+	result := df["a"]
+	if len(result) != 100 {
+		t.Errorf("Unexpected length:\n got: %d\nwant: %d", len(result), 100)
+	}
+	for _, ts := range []fields.Timestamp{t0, t0.Add(999 * time.Second)} {
+		if _, ok := result[ts]; !ok {
+			t.Errorf("Expected result to contain %v", ts)
+		}
+	}
+	for ts, v := range result {
+		if s[ts] != v {
+			t.Errorf("Unexpected value at %v:\n got: %v\nwant: %v", ts, v, s[ts])
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/clarify/clarify-go/fields"
+)
+
+// FrameBuilder builds a data frame from samples added by any number of
+// goroutines. This is useful for parallel collectors that feed Insert
+// requests. The zero-value is ready for use. A FrameBuilder must not be
+// copied after first use.
+type FrameBuilder struct {
+	lock    sync.Mutex
+	df      DataFrame
+	samples int
+}
+
+// Add sets the value for series at time t. Adding a value for a timestamp
+// that is already set replaces the previous value.
+func (b *FrameBuilder) Add(series string, t fields.Timestamp, v float64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.df == nil {
+		b.df = make(DataFrame)
+	}
+	s, ok := b.df[series]
+	if !ok {
+		s = make(DataSeries)
+		b.df[series] = s
+	}
+	if _, ok := s[t]; !ok {
+		b.samples++
+	}
+	s[t] = v
+}
+
+// Len returns the number of samples in the builder.
+func (b *FrameBuilder) Len() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.samples
+}
+
+// Snapshot returns a copy of the data frame built so far. The returned data
+// frame is not affected by later calls to Add.
+func (b *FrameBuilder) Snapshot() DataFrame {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	out := make(DataFrame, len(b.df))
+	for k, s := range b.df {
+		out[k] = maps.Clone(s)
+	}
+	return out
+}
+
+// Take returns the data frame built so far, and resets the builder. Unlike
+// calling Snapshot followed by a reset, no samples added concurrently are
+// lost.
+func (b *FrameBuilder) Take() DataFrame {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	df := b.df
+	if df == nil {
+		df = make(DataFrame)
+	}
+	b.df, b.samples = nil, 0
+	return df
+}
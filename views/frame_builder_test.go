@@ -0,0 +1,66 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestFrameBuilder(t *testing.T) {
+	t0 := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var b views.FrameBuilder
+
+	var wg sync.WaitGroup
+	for _, series := range []string{"a", "b", "c", "d"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				b.Add(series, t0.Add(time.Duration(i)*time.Second), float64(i))
+			}
+		}()
+	}
+	wg.Wait()
+	b.Add("a", t0, 42) // Replace an existing value.
+
+	if n := b.Len(); n != 400 {
+		t.Errorf("Unexpected Len:\n got: %d\nwant: %d", n, 400)
+	}
+	snapshot := b.Snapshot()
+	b.Add("e", t0, 1)
+	if _, ok := snapshot["e"]; ok {
+		t.Errorf("Snapshot affected by later Add")
+	}
+	if v := snapshot["a"][t0]; v != 42 {
+		t.Errorf("Unexpected value:\n got: %v\nwant: %v", v, 42.0)
+	}
+
+	df := b.Take()
+	if len(df) != 5 || len(df["d"]) != 100 {
+		t.Errorf("Unexpected result from Take: %d series", len(df))
+	}
+	if n := b.Len(); n != 0 {
+		t.Errorf("Unexpected Len after Take:\n got: %d\nwant: %d", n, 0)
+	}
+	if df := b.Take(); !reflect.DeepEqual(df, views.DataFrame{}) {
+		t.Errorf("Unexpected result from empty Take:\n got: %v\nwant: %v", df, views.DataFrame{})
+	}
+}
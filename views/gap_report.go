@@ -0,0 +1,90 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views
+
+import (
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+)
+
+// Gap describe a period where a series has no values.
+type Gap struct {
+	Start    fields.Timestamp     `json:"start"`
+	End      fields.Timestamp     `json:"end"`
+	Duration fields.FixedDuration `json:"duration"`
+}
+
+// SeriesQuality describe the gaps and coverage of a single series.
+type SeriesQuality struct {
+	Gaps []Gap `json:"gaps"`
+
+	// Coverage holds the percentage of the time range that is not covered by
+	// gaps, in range [0,100]. As a value is expected every expected interval,
+	// only the part of each gap that exceeds the expected interval counts as
+	// not covered.
+	Coverage float64 `json:"coverage"`
+}
+
+// GapReport returns the gaps and coverage for each series in df, relative to
+// the time range spanned by all series in df. See GapReportRange for details.
+func GapReport(df DataFrame, expectedInterval time.Duration) map[string]SeriesQuality {
+	times := df.Timestamps()
+	if len(times) == 0 {
+		return GapReportRange(df, 0, 0, expectedInterval)
+	}
+	return GapReportRange(df, times[0], times[len(times)-1], expectedInterval)
+}
+
+// GapReportRange returns the gaps and coverage for each series in df within
+// the time range [gte,lte]. A gap is reported when the duration between two
+// consecutive values, or between the range boundaries and the first or last
+// value, exceeds expectedInterval. A series without values within the range is
+// reported as a single gap. NaN values are ignored.
+func GapReportRange(df DataFrame, gte, lte fields.Timestamp, expectedInterval time.Duration) map[string]SeriesQuality {
+	report := make(map[string]SeriesQuality, len(df))
+	span := lte.Sub(gte)
+	for key, s := range df {
+		var q SeriesQuality
+		var gapSum time.Duration
+		prev := gte
+		addGap := func(end fields.Timestamp) {
+			if d := end.Sub(prev); d > expectedInterval {
+				q.Gaps = append(q.Gaps, Gap{Start: prev, End: end, Duration: fields.AsFixedDuration(d)})
+				gapSum += d - max(expectedInterval, 0)
+			}
+		}
+		var count int
+		for _, t := range s.Timestamps() {
+			if t < gte || t > lte {
+				continue
+			}
+			addGap(t)
+			prev = t
+			count++
+		}
+		switch {
+		case count == 0:
+			q.Gaps = []Gap{{Start: gte, End: lte, Duration: fields.AsFixedDuration(span)}}
+		case span > 0:
+			addGap(lte)
+			q.Coverage = 100 * float64(span-gapSum) / float64(span)
+		default:
+			q.Coverage = 100
+		}
+		report[key] = q
+	}
+	return report
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestGapReport(t *testing.T) {
+	t0 := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	at := func(minutes int) fields.Timestamp {
+		return t0.Add(time.Duration(minutes) * time.Minute)
+	}
+	gap := func(start, end int) views.Gap {
+		return views.Gap{Start: at(start), End: at(end), Duration: fields.AsFixedDuration(at(end).Sub(at(start)))}
+	}
+
+	df := views.DataFrame{
+		"full": {at(0): 1, at(1): 1, at(2): 1, at(3): 1, at(4): 1},
+		"gap":  {at(0): 1, at(1): 1, at(3): 1, at(4): math.NaN()},
+		"none": {},
+	}
+
+	result := views.GapReport(df, time.Minute)
+	expect := map[string]views.SeriesQuality{
+		"full": {Coverage: 100},
+		"gap":  {Gaps: []views.Gap{gap(1, 3)}, Coverage: 75},
+		"none": {Gaps: []views.Gap{gap(0, 4)}},
+	}
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("Unexpected report:\n got: %+v\nwant: %+v", result, expect)
+	}
+
+	result = views.GapReportRange(df, at(0), at(8), time.Minute)
+	if q := result["full"]; !reflect.DeepEqual(q.Gaps, []views.Gap{gap(4, 8)}) || q.Coverage != 62.5 {
+		t.Errorf("Unexpected result for range:\n got: %+v\nwant: gaps=[%+v] coverage=62.5", q, gap(4, 8))
+	}
+}
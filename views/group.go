@@ -0,0 +1,105 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views
+
+import (
+	"math"
+
+	"github.com/clarify/clarify-go/fields"
+)
+
+// AggregateFunc describe a function for combining the values of multiple
+// series at a single timestamp into one value. Values are never empty. See
+// Sum, Avg, Min, Max and Count.
+type AggregateFunc func(values []float64) float64
+
+// Sum returns the sum of values.
+func Sum(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
+
+// Avg returns the arithmetic mean of values.
+func Avg(values []float64) float64 {
+	return Sum(values) / float64(len(values))
+}
+
+// Min returns the smallest of values.
+func Min(values []float64) float64 {
+	m := values[0]
+	for _, v := range values[1:] {
+		m = math.Min(m, v)
+	}
+	return m
+}
+
+// Max returns the largest of values.
+func Max(values []float64) float64 {
+	m := values[0]
+	for _, v := range values[1:] {
+		m = math.Max(m, v)
+	}
+	return m
+}
+
+// Count returns the number of values.
+func Count(values []float64) float64 {
+	return float64(len(values))
+}
+
+// GroupByLabel returns a new data frame where series in df belonging to items
+// that share the same value for the label key are combined using f. The
+// result is keyed by label value. This complement server-side grouping for
+// when the grouping key is not known until after selection.
+//
+// Series in df are matched to items by item ID, which is the series key for
+// data frames without rollup. Series without a matching item, or where the
+// item has no value for key, are omitted. Items with multiple values for key
+// contribute to each group. NaN values are ignored.
+func GroupByLabel(df DataFrame, items []Item, key string, f AggregateFunc) DataFrame {
+	groups := make(map[string]map[fields.Timestamp][]float64)
+	for _, item := range items {
+		s, ok := df[item.ID]
+		if !ok {
+			continue
+		}
+		for _, group := range item.Attributes.Labels[key] {
+			values := groups[group]
+			if values == nil {
+				values = make(map[fields.Timestamp][]float64, len(s))
+				groups[group] = values
+			}
+			for t, v := range s {
+				if math.IsNaN(v) {
+					continue
+				}
+				values[t] = append(values[t], v)
+			}
+		}
+	}
+
+	out := make(DataFrame, len(groups))
+	for group, values := range groups {
+		s := make(DataSeries, len(values))
+		for t, vs := range values {
+			s[t] = f(vs)
+		}
+		out[group] = s
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestGroupByLabel(t *testing.T) {
+	t0 := fields.AsTimestamp(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC))
+	t1 := t0.Add(time.Hour)
+
+	item := func(id string, locations ...string) views.Item {
+		var item views.Item
+		item.ID = id
+		if len(locations) > 0 {
+			item.Attributes.Labels = fields.Labels{"location": locations}
+		}
+		return item
+	}
+	items := []views.Item{
+		item("a", "pier"),
+		item("b", "pier"),
+		item("c", "dock", "pier"),
+		item("d"),
+	}
+	df := views.DataFrame{
+		"a": {t0: 1, t1: 2},
+		"b": {t0: 3, t1: math.NaN()},
+		"c": {t0: 5},
+		"d": {t0: 7},
+		"e": {t0: 9},
+	}
+
+	test := func(f views.AggregateFunc, expect views.DataFrame) func(t *testing.T) {
+		return func(t *testing.T) {
+			result := views.GroupByLabel(df, items, "location", f)
+			if !reflect.DeepEqual(result, expect) {
+				t.Errorf("Unexpected result:\n got: %v\nwant: %v", result, expect)
+			}
+		}
+	}
+	t.Run("Sum", test(views.Sum, views.DataFrame{
+		"pier": {t0: 9, t1: 2},
+		"dock": {t0: 5},
+	}))
+	t.Run("Avg", test(views.Avg, views.DataFrame{
+		"pier": {t0: 3, t1: 2},
+		"dock": {t0: 5},
+	}))
+	t.Run("Min", test(views.Min, views.DataFrame{
+		"pier": {t0: 1, t1: 2},
+		"dock": {t0: 5},
+	}))
+	t.Run("Max", test(views.Max, views.DataFrame{
+		"pier": {t0: 5, t1: 2},
+		"dock": {t0: 5},
+	}))
+	t.Run("Count", test(views.Count, views.DataFrame{
+		"pier": {t0: 3, t1: 1},
+		"dock": {t0: 1},
+	}))
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ItemsByID returns a map of the passed in items keyed by ID.
+func ItemsByID(items []Item) map[string]Item {
+	m := make(map[string]Item, len(items))
+	for _, item := range items {
+		m[item.ID] = item
+	}
+	return m
+}
+
+// ItemSeries holds a data series together with the item it was resolved to.
+type ItemSeries struct {
+	// Key holds the data frame series key.
+	Key string
+
+	// Item holds the item that the series key refers to, or nil if there is no
+	// matching item.
+	Item *Item
+
+	// Series holds the data series.
+	Series DataSeries
+}
+
+// JoinItems returns the series in df ordered by key, where each series is
+// resolved to the item it belongs to. Series keys are expected to either match
+// an item ID, or be prefixed by an item ID followed by an underscore (_), as
+// is the case for aggregated series such as "<id>_sum".
+func JoinItems(df DataFrame, items []Item) []ItemSeries {
+	byID := ItemsByID(items)
+	result := make([]ItemSeries, 0, len(df))
+	for _, k := range slices.Sorted(maps.Keys(df)) {
+		entry := ItemSeries{Key: k, Series: df[k]}
+		id, _, _ := strings.Cut(k, "_")
+		if item, ok := byID[id]; ok {
+			entry.Item = &item
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+func itemByID(items []Item, id string) (Item, bool) {
+	for _, item := range items {
+		if item.ID == id {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views_test
+
+import (
+	"testing"
+
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestJoinItems(t *testing.T) {
+	items := []views.Item{
+		{Identifier: views.Identifier{Type: "items", ID: "a"}},
+		{Identifier: views.Identifier{Type: "items", ID: "b"}},
+	}
+	df := views.DataFrame{
+		"b_sum":   {1: 1},
+		"a":       {1: 2},
+		"unknown": {1: 3},
+	}
+
+	result := views.JoinItems(df, items)
+	expect := []struct {
+		key, itemID string
+	}{
+		{"a", "a"},
+		{"b_sum", "b"},
+		{"unknown", ""},
+	}
+	if len(result) != len(expect) {
+		t.Fatalf("Unexpected result length:\n got: %d\nwant: %d", len(result), len(expect))
+	}
+	for i, e := range expect {
+		r := result[i]
+		var itemID string
+		if r.Item != nil {
+			itemID = r.Item.ID
+		}
+		if r.Key != e.key || itemID != e.itemID {
+			t.Errorf("Unexpected result[%d]:\n got: (%s, %s)\nwant: (%s, %s)", i, r.Key, itemID, e.key, e.itemID)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Input key limits. The limits are set to match the Clarify API documentation.
+const (
+	MaxInputKeyLength = 128
+
+	// InputKeySeparator separates the parts of keys composed by InputKey.
+	InputKeySeparator = "/"
+)
+
+var (
+	reInputKey     = regexp.MustCompile(`^[A-Za-z0-9_\-:.#+/]{1,128}$`)
+	reInputKeyPart = regexp.MustCompile(`^[A-Za-z0-9_\-:.#+]+$`)
+)
+
+// ValidateInputKey returns an error wrapping ErrBadInputKey if key is not a
+// valid signal input key.
+func ValidateInputKey(key string) error {
+	if !reInputKey.MatchString(key) {
+		return fmt.Errorf("%w: %q must match %s", ErrBadInputKey, key, reInputKey)
+	}
+	return nil
+}
+
+// InputKey returns an input key composed of parts joined by
+// InputKeySeparator. This can be used to namespace keys per sub-system, to
+// avoid collisions. An error wrapping ErrBadInputKey is returned if any of the
+// parts are empty, contain the separator or other disallowed characters, or if
+// the final key is too long. See ParseInputKey for the reverse operation.
+func InputKey(parts ...string) (string, error) {
+	for _, p := range parts {
+		if !reInputKeyPart.MatchString(p) {
+			return "", fmt.Errorf("%w: part %q must match %s", ErrBadInputKey, p, reInputKeyPart)
+		}
+	}
+	key := strings.Join(parts, InputKeySeparator)
+	if err := ValidateInputKey(key); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// ParseInputKey splits key into the parts it was composed from by InputKey.
+func ParseInputKey(key string) []string {
+	return strings.Split(key, InputKeySeparator)
+}
+
+// KeyPrefix describe a namespace for input keys. The prefix may itself be
+// composed of multiple parts joined by InputKeySeparator.
+type KeyPrefix string
+
+// Key returns an input key composed of the prefix and parts. See InputKey.
+func (p KeyPrefix) Key(parts ...string) (string, error) {
+	return InputKey(append(ParseInputKey(string(p)), parts...)...)
+}
+
+// Parse returns the parts of key following the prefix, or false if key is not
+// within the prefix namespace.
+func (p KeyPrefix) Parse(key string) ([]string, bool) {
+	rest, ok := strings.CutPrefix(key, string(p)+InputKeySeparator)
+	if !ok {
+		return nil, false
+	}
+	return ParseInputKey(rest), true
+}
+
+// DataFrame returns a copy of df where each series key is prefixed, e.g. for
+// use with Insert. An error wrapping ErrBadInputKey is returned if any of the
+// resulting keys are invalid.
+func (p KeyPrefix) DataFrame(df DataFrame) (DataFrame, error) {
+	return prefixKeys(p, df)
+}
+
+// Signals returns a copy of signals where each input key is prefixed, e.g.
+// for use with SaveSignals. An error wrapping ErrBadInputKey is returned if
+// any of the resulting keys are invalid.
+func (p KeyPrefix) Signals(signals map[string]SignalSave) (map[string]SignalSave, error) {
+	return prefixKeys(p, signals)
+}
+
+func prefixKeys[M ~map[string]V, V any](p KeyPrefix, m M) (M, error) {
+	out := make(M, len(m))
+	for k, v := range m {
+		key, err := p.Key(ParseInputKey(k)...)
+		if err != nil {
+			return nil, err
+		}
+		out[key] = v
+	}
+	return out, nil
+}
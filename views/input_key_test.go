@@ -0,0 +1,79 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views_test
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestInputKey(t *testing.T) {
+	test := func(parts []string, expect string, expectErr error) func(t *testing.T) {
+		return func(t *testing.T) {
+			key, err := views.InputKey(parts...)
+			if !errors.Is(err, expectErr) {
+				t.Fatalf("Unexpected error:\n got: %v\nwant: %v", err, expectErr)
+			}
+			if key != expect {
+				t.Errorf("Unexpected key:\n got: %q\nwant: %q", key, expect)
+			}
+			if err == nil && !slices.Equal(views.ParseInputKey(key), parts) {
+				t.Errorf("Unexpected parse result:\n got: %v\nwant: %v", views.ParseInputKey(key), parts)
+			}
+		}
+	}
+
+	t.Run("single", test([]string{"temp"}, "temp", nil))
+	t.Run("multiple", test([]string{"mqtt", "site-1", "temp.c"}, "mqtt/site-1/temp.c", nil))
+	t.Run("empty part", test([]string{"mqtt", ""}, "", views.ErrBadInputKey))
+	t.Run("separator in part", test([]string{"a/b"}, "", views.ErrBadInputKey))
+	t.Run("bad character", test([]string{"a b"}, "", views.ErrBadInputKey))
+	t.Run("too long", test([]string{strings.Repeat("a", 100), strings.Repeat("b", 28)}, "", views.ErrBadInputKey))
+}
+
+func TestKeyPrefix(t *testing.T) {
+	p := views.KeyPrefix("opcua/line-1")
+
+	key, err := p.Key("temp")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expect := "opcua/line-1/temp"; key != expect {
+		t.Errorf("Unexpected key:\n got: %q\nwant: %q", key, expect)
+	}
+	if parts, ok := p.Parse(key); !ok || !slices.Equal(parts, []string{"temp"}) {
+		t.Errorf("Unexpected parse result:\n got: %v, %t\nwant: [temp], true", parts, ok)
+	}
+	if _, ok := p.Parse("opcua/line-10/temp"); ok {
+		t.Errorf("Expected key outside of namespace to not parse")
+	}
+
+	df, err := p.DataFrame(views.DataFrame{"a": {}, "b/c": {}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	keys := slices.Sorted(maps.Keys(df))
+	if expect := []string{"opcua/line-1/a", "opcua/line-1/b/c"}; !slices.Equal(keys, expect) {
+		t.Errorf("Unexpected data frame keys:\n got: %v\nwant: %v", keys, expect)
+	}
+	if _, err := p.Signals(map[string]views.SignalSave{"a b": {}}); !errors.Is(err, views.ErrBadInputKey) {
+		t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, views.ErrBadInputKey)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views
+
+import (
+	"github.com/clarify/clarify-go/fields"
+)
+
+// Integration describe the select view for an integration.
+type Integration = Resource[IntegrationAttributes, IntegrationRelationships]
+
+// IntegrationInclude describe the included resources for an integration
+// selection. Integrations currently have no relationships that can be
+// included.
+type IntegrationInclude struct{}
+
+// IntegrationAttributes contains attributes for the integration select view.
+type IntegrationAttributes struct {
+	Name   string        `json:"name"`
+	Labels fields.Labels `json:"labels"`
+}
+
+// IntegrationRelationships declare the available relationships for the
+// integration model.
+type IntegrationRelationships struct{}
@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -54,6 +54,21 @@ func PublishedItem(signal Signal, transforms ...func(dest *ItemSave)) ItemSave {
 	return item
 }
 
+// SavedItem returns a save view for the passed in item select view. Attributes
+// and annotations are cloned, so that the result can be modified without
+// affecting item.
+func SavedItem(item Item) ItemSave {
+	attrs := item.Attributes.ItemSaveAttributes
+	attrs.Labels = attrs.Labels.Clone()
+	attrs.EnumValues = attrs.EnumValues.Clone()
+	return ItemSave{
+		ItemSaveAttributes: attrs,
+		MetaSave: MetaSave{
+			Annotations: item.Meta.Annotations.Clone(),
+		},
+	}
+}
+
 // ItemAttributes contains attributes for the item select view.
 type ItemAttributes struct {
 	ItemSaveAttributes
@@ -79,3 +94,8 @@ type ItemRelationships struct {
 	UpdatedBy    ToOne `json:"updatedBy"`
 	Organization ToOne `json:"organization"`
 }
+
+// OrganizationID returns the ID of the organization the item belongs to.
+func (r ItemRelationships) OrganizationID() (string, bool) {
+	return r.Organization.ID()
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views
+
+import (
+	"bufio"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	lineProtocolMeasurementEscaper = strings.NewReplacer(`,`, `\,`, ` `, `\ `)
+	lineProtocolKeyEscaper         = strings.NewReplacer(`,`, `\,`, `=`, `\=`, ` `, `\ `)
+)
+
+// WriteLineProtocol writes df to w in the InfluxDB line protocol, with one
+// line per sample. This allows Clarify query results to be mirrored into
+// InfluxDB or Telegraf based stacks.
+//
+// For each series in df, measurementFor is called with the series key, and
+// should return the measurement name and tags to use. Series where the
+// returned name is empty are skipped. If measurementFor is nil, the series key
+// is used as the measurement name without any tags. Values are written to the
+// field "value", and timestamps are written with nanosecond precision. Series
+// are written in key order, and samples in time order. Tags with empty values
+// are omitted, and so are NaN and infinite values, as they are not supported
+// by the line protocol. Names must not contain line breaks.
+func WriteLineProtocol(w io.Writer, df DataFrame, measurementFor func(series string) (name string, tags map[string]string)) error {
+	bw := bufio.NewWriter(w)
+	var buf []byte
+	for _, k := range slices.Sorted(maps.Keys(df)) {
+		name, tags := k, map[string]string(nil)
+		if measurementFor != nil {
+			name, tags = measurementFor(k)
+		}
+		if name == "" {
+			continue
+		}
+
+		// Encode the series key once; tags are sorted as recommended for
+		// write performance.
+		prefix := []byte(lineProtocolMeasurementEscaper.Replace(name))
+		for _, tk := range slices.Sorted(maps.Keys(tags)) {
+			if tags[tk] == "" {
+				continue
+			}
+			prefix = append(prefix, ',')
+			prefix = append(prefix, lineProtocolKeyEscaper.Replace(tk)...)
+			prefix = append(prefix, '=')
+			prefix = append(prefix, lineProtocolKeyEscaper.Replace(tags[tk])...)
+		}
+		prefix = append(prefix, " value="...)
+
+		for t, v := range df[k].All() {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			buf = append(buf[:0], prefix...)
+			buf = strconv.AppendFloat(buf, v, 'g', -1, 64)
+			buf = append(buf, ' ')
+			buf = strconv.AppendInt(buf, t.Time().UnixNano(), 10)
+			buf = append(buf, '\n')
+			if _, err := bw.Write(buf); err != nil {
+				return err
+			}
+		}
+	}
+	return bw.Flush()
+}
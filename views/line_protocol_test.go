@@ -0,0 +1,63 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestWriteLineProtocol(t *testing.T) {
+	t0 := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	df := views.DataFrame{
+		"b":    {t0: 1.5, t0.Add(time.Second): math.NaN()},
+		"a":    {t0.Add(time.Second): 2, t0: 1e6},
+		"skip": {t0: 1},
+	}
+
+	test := func(measurementFor func(string) (string, map[string]string), expect string) func(t *testing.T) {
+		return func(t *testing.T) {
+			var sb strings.Builder
+			if err := views.WriteLineProtocol(&sb, df, measurementFor); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := sb.String(); got != expect {
+				t.Errorf("Unexpected output:\n got: %s\nwant: %s", got, expect)
+			}
+		}
+	}
+
+	t.Run("Default", test(nil, ""+
+		"a value=1e+06 1704067200000000000\n"+
+		"a value=2 1704067201000000000\n"+
+		"b value=1.5 1704067200000000000\n"+
+		"skip value=1 1704067200000000000\n",
+	))
+	t.Run("Measurement", test(func(series string) (string, map[string]string) {
+		if series == "skip" {
+			return "", nil
+		}
+		return "my temp", map[string]string{"site": "pier, 1", "id=": series, "empty": ""}
+	}, ""+
+		`my\ temp,id\==a,site=pier\,\ 1 value=1e+06 1704067200000000000`+"\n"+
+		`my\ temp,id\==a,site=pier\,\ 1 value=2 1704067201000000000`+"\n"+
+		`my\ temp,id\==b,site=pier\,\ 1 value=1.5 1704067200000000000`+"\n",
+	))
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/clarify/clarify-go/fields"
+)
+
+// NullDataSeries contain a map of timestamps to optional values. Unlike
+// DataSeries, a null value is kept as an entry that is not valid, and can thus
+// be distinguished from a missing timestamp as well as from NaN.
+type NullDataSeries map[fields.Timestamp]fields.NullNumber
+
+// Timestamps returns an ordered set of all timestamps in the series, including
+// timestamps with null values.
+func (s NullDataSeries) Timestamps() []fields.Timestamp {
+	ordered := make([]fields.Timestamp, 0, len(s))
+	for t := range s {
+		ordered = append(ordered, t)
+	}
+	slices.Sort(ordered)
+	return ordered
+}
+
+// DataSeries converts s to a DataSeries, where null values are dropped.
+func (s NullDataSeries) DataSeries() DataSeries {
+	out := make(DataSeries, len(s))
+	for t, v := range s {
+		if v.Valid {
+			out[t] = v.Value
+		}
+	}
+	return out
+}
+
+// NullDataFrame provides JSON encoding and decoding for a map of series where
+// null values are preserved. It uses the same JSON format as DataFrame.
+type NullDataFrame map[string]NullDataSeries
+
+var (
+	_ json.Marshaler   = NullDataFrame{}
+	_ json.Unmarshaler = (*NullDataFrame)(nil)
+)
+
+// Timestamps returns an ordered set of all timestamps in the data-frame,
+// including timestamps where all values are null.
+func (df NullDataFrame) Timestamps() []fields.Timestamp {
+	m := make(map[fields.Timestamp]struct{})
+	for _, s := range df {
+		for t := range s {
+			m[t] = struct{}{}
+		}
+	}
+	ordered := make([]fields.Timestamp, 0, len(m))
+	for t := range m {
+		ordered = append(ordered, t)
+	}
+	slices.Sort(ordered)
+	return ordered
+}
+
+// DataFrame converts df to a DataFrame, where null values are dropped.
+func (df NullDataFrame) DataFrame() DataFrame {
+	out := make(DataFrame, len(df))
+	for k, s := range df {
+		out[k] = s.DataSeries()
+	}
+	return out
+}
+
+// MarshalJSON encodes df using the same JSON format as DataFrame. As all series
+// share the same times array, a missing entry is encoded as null when a series
+// has later entries, and omitted otherwise. Data decoded by UnmarshalJSON is
+// thus re-encoded without adding null entries.
+func (df NullDataFrame) MarshalJSON() ([]byte, error) {
+	type rawNullDataFrame struct {
+		Times  []fields.Timestamp             `json:"times"`
+		Series map[string][]fields.NullNumber `json:"series"`
+	}
+	out := rawNullDataFrame{
+		Times:  df.Timestamps(),
+		Series: make(map[string][]fields.NullNumber, len(df)),
+	}
+	for k, s := range df {
+		values := make([]fields.NullNumber, 0, len(out.Times))
+		l := 0
+		for _, t := range out.Times {
+			v, ok := s[t]
+			values = append(values, v)
+			if ok {
+				l = len(values)
+			}
+		}
+		out.Series[k] = values[:l]
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes data at best effort, similar to DataFrame. Values that
+// are null are kept, while values beyond the length of the times array are
+// dropped. A series with fewer values than the times array is left without
+// entries for the remaining timestamps.
+func (df *NullDataFrame) UnmarshalJSON(b []byte) error {
+	var in struct {
+		Times  []fields.Timestamp             `json:"times"`
+		Series map[string][]fields.NullNumber `json:"series"`
+	}
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+
+	out := make(NullDataFrame, len(in.Series))
+	for k, values := range in.Series {
+		l := min(len(values), len(in.Times))
+		series := make(NullDataSeries, l)
+		for i := range l {
+			series[in.Times[i]] = values[i]
+		}
+		out[k] = series
+	}
+	*df = out
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestNullDataFrameJSON(t *testing.T) {
+	const data = `{"times":["2024-01-01T00:00:00Z","2024-01-01T00:01:00Z","2024-01-01T00:02:00Z"],"series":{"a":[1,null,"NaN"],"b":[null,2]}}`
+
+	var df views.NullDataFrame
+	if err := json.Unmarshal([]byte(data), &df); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	times := df.Timestamps()
+	if len(times) != 3 {
+		t.Fatalf("Unexpected timestamp count:\n got: %d\nwant: 3", len(times))
+	}
+	if v := df["a"][times[1]]; v.Valid {
+		t.Errorf("Expected a[1] to be null, got: %v", v)
+	}
+	if v := df["a"][times[2]]; !v.Valid || !math.IsNaN(v.Value) {
+		t.Errorf("Expected a[2] to be a valid NaN, got: %v", v)
+	}
+	if _, ok := df["b"][times[2]]; ok {
+		t.Errorf("Expected b[2] to be missing")
+	}
+	if n, ok := df["b"][times[1]].Int64(); !ok || n != 2 {
+		t.Errorf("Unexpected b[1].Int64():\n got: %d, %t\nwant: 2, true", n, ok)
+	}
+
+	b, err := json.Marshal(df)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(b) != data {
+		t.Errorf("Unexpected JSON:\n got: %s\nwant: %s", b, data)
+	}
+
+	var roundTrip views.NullDataFrame
+	if err := json.Unmarshal(b, &roundTrip); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for k, s := range df {
+		if got, want := len(roundTrip[k]), len(s); got != want {
+			t.Errorf("Unexpected round trip length for %s:\n got: %d\nwant: %d", k, got, want)
+		}
+	}
+
+	if s := df.DataFrame()["a"]; len(s) != 2 {
+		t.Errorf("Unexpected DataFrame length for a:\n got: %d\nwant: 2", len(s))
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -19,6 +19,8 @@ import (
 	"crypto/sha1"
 	"encoding/json"
 	"io"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/clarify/clarify-go/fields"
@@ -30,6 +32,9 @@ type MetaSave struct {
 }
 
 // Normalizer describes a type that should be normalized before encoding.
+// Normalize is called on a copy of the value, and must therefore not modify
+// maps or slices in place; replace them with normalized clones, e.g. using
+// maps.Clone and slices.Clone, instead.
 type Normalizer interface {
 	Normalize()
 }
@@ -55,31 +60,38 @@ func (e Resource[A, R]) MarshalJSON() ([]byte, error) {
 		Meta:       e.Meta,
 	}
 
-	hash := sha1.New()
-	if n, ok := any(&e.Attributes).(Normalizer); ok {
-		n.Normalize()
+	var err error
+	target.Attributes, target.Meta.AttributesHash, err = encodeHashed(e.Attributes)
+	if err != nil {
+		return nil, err
 	}
-	var buf bytes.Buffer
-	enc := json.NewEncoder(io.MultiWriter(hash, &buf))
-	if err := enc.Encode(e.Attributes); err != nil {
+	target.Relationships, target.Meta.RelationshipsHash, err = encodeHashed(e.Relationships)
+	if err != nil {
 		return nil, err
 	}
-	target.Attributes = buf.Bytes()
-	target.Meta.AttributesHash = fields.Hexadecimal(hash.Sum(nil))
+	return json.Marshal(target)
+}
 
-	hash = sha1.New()
-	if n, ok := any(&e.Attributes).(Normalizer); ok {
+// encodeHashed returns the JSON encoding of v together with a SHA-1 hash of the
+// encoding. If *T implements Normalizer, the copy v is normalized first.
+func encodeHashed[T any](v T) ([]byte, fields.Hexadecimal, error) {
+	if n, ok := any(&v).(Normalizer); ok {
 		n.Normalize()
 	}
-	buf = bytes.Buffer{}
-	enc = json.NewEncoder(io.MultiWriter(hash, &buf))
-	if err := enc.Encode(e.Relationships); err != nil {
-		return nil, err
+	hash := sha1.New()
+	var buf bytes.Buffer
+	enc := json.NewEncoder(io.MultiWriter(hash, &buf))
+	if err := enc.Encode(v); err != nil {
+		return nil, nil, err
 	}
-	target.Relationships = buf.Bytes()
-	target.Meta.RelationshipsHash = fields.Hexadecimal(hash.Sum(nil))
+	return buf.Bytes(), fields.Hexadecimal(hash.Sum(nil)), nil
+}
 
-	return json.Marshal(target)
+// AttributesHash returns the attributes hash that Resource.MarshalJSON sets
+// for attrs.
+func AttributesHash[A any](attrs A) fields.Hexadecimal {
+	_, hash, _ := encodeHashed(attrs)
+	return hash
 }
 
 // ToOne describes a to-one relationship entry.
@@ -88,12 +100,57 @@ type ToOne struct {
 	Data NullIdentifier             `json:"data"`
 }
 
+// ID returns the ID of the related resource, or false if the relationship is
+// not set.
+func (r ToOne) ID() (string, bool) {
+	return r.Data.ID, r.Data.ID != ""
+}
+
 // ToMany describes a to-many relationship entry.
 type ToMany struct {
 	Meta map[string]json.RawMessage `json:"meta,omitempty"`
 	Data []Identifier               `json:"data"`
 }
 
+// IDs returns the IDs of the related resources.
+func (r ToMany) IDs() []string {
+	ids := make([]string, 0, len(r.Data))
+	for _, id := range r.Data {
+		ids = append(ids, id.ID)
+	}
+	return ids
+}
+
+// Relationship returns the identifiers for the named relationship of e, where
+// name matches the JSON name of the relationship, such as "item" for a signal.
+// The function works for all resource views where relationships are declared
+// as struct fields of type ToOne or ToMany. If the relationship is not
+// declared, false is returned. An unset to-one relationship returns an empty
+// list.
+func Relationship[A, R any](e Resource[A, R], name string) ([]Identifier, bool) {
+	v := reflect.ValueOf(e.Relationships)
+	if v.Kind() != reflect.Struct {
+		return nil, false
+	}
+	t := v.Type()
+	for i := range t.NumField() {
+		tag, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if tag != name {
+			continue
+		}
+		switch rel := v.Field(i).Interface().(type) {
+		case ToOne:
+			if rel.Data.ID == "" {
+				return []Identifier{}, true
+			}
+			return []Identifier{Identifier(rel.Data)}, true
+		case ToMany:
+			return rel.Data, true
+		}
+	}
+	return nil, false
+}
+
 // Identifier uniquely identifies a resource entry.
 type Identifier struct {
 	Type string `json:"type"`
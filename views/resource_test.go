@@ -0,0 +1,150 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views_test
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestRelationship(t *testing.T) {
+	signals := []views.Signal{
+		{
+			Identifier: views.Identifier{Type: "signals", ID: "s1"},
+			Relationships: views.SignalRelationships{
+				Item: views.ToOne{Data: views.NullIdentifier{Type: "items", ID: "i1"}},
+			},
+		},
+		{Identifier: views.Identifier{Type: "signals", ID: "s2"}},
+		{
+			Identifier: views.Identifier{Type: "signals", ID: "s3"},
+			Relationships: views.SignalRelationships{
+				Item: views.ToOne{Data: views.NullIdentifier{Type: "items", ID: "i1"}},
+			},
+		},
+	}
+
+	if id, ok := signals[0].Relationships.ItemID(); !ok || id != "i1" {
+		t.Errorf("Unexpected ItemID:\n got: %q, %t\nwant: %q, true", id, ok, "i1")
+	}
+	if id, ok := signals[1].Relationships.ItemID(); ok {
+		t.Errorf("Unexpected ItemID:\n got: %q, %t\nwant: \"\", false", id, ok)
+	}
+
+	ids, ok := views.Relationship(signals[0], "item")
+	if expect := []views.Identifier{{Type: "items", ID: "i1"}}; !ok || !slices.Equal(ids, expect) {
+		t.Errorf("Unexpected relationship:\n got: %v, %t\nwant: %v, true", ids, ok, expect)
+	}
+	if ids, ok := views.Relationship(signals[1], "item"); !ok || len(ids) != 0 {
+		t.Errorf("Unexpected relationship:\n got: %v, %t\nwant: [], true", ids, ok)
+	}
+	if _, ok := views.Relationship(signals[0], "unknown"); ok {
+		t.Errorf("Expected no relationship for unknown name")
+	}
+
+	byItem := views.SignalIDsByItem(signals)
+	if expect := []string{"s1", "s3"}; !slices.Equal(byItem["i1"], expect) || len(byItem) != 1 {
+		t.Errorf("Unexpected SignalIDsByItem:\n got: %v\nwant: map[i1:%v]", byItem, expect)
+	}
+}
+
+func TestSignalAttributesHash(t *testing.T) {
+	signal := views.Signal{
+		Identifier: views.Identifier{Type: "signals", ID: "s1"},
+		Attributes: views.SignalAttributes{
+			SignalSaveAttributes: views.SignalSaveAttributes{
+				Name:      "Temperature",
+				ValueType: views.Numeric,
+				Labels:    fields.Labels{"site": {"oslo"}},
+			},
+			SignalReadOnlyAttributes: views.SignalReadOnlyAttributes{Input: "temp"},
+		},
+	}
+
+	// Encoding a resource sets the attributes hash; decode it to get it.
+	b, err := json.Marshal(signal)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded views.Signal
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if h := signal.Attributes.Hash(); h.String() != decoded.Meta.AttributesHash.String() {
+		t.Errorf("Unexpected attributes hash:\n got: %s\nwant: %s", h, decoded.Meta.AttributesHash)
+	}
+
+	save := views.SavedSignal(signal).SignalSaveAttributes
+	if h1, h2 := save.Hash(), signal.Attributes.SignalSaveAttributes.Hash(); h1.String() != h2.String() {
+		t.Errorf("Expected equal hashes for equal attributes:\n got: %s\nwant: %s", h1, h2)
+	}
+	save.Labels = fields.Labels{"site": {"bergen"}}
+	if h1, h2 := save.Hash(), signal.Attributes.SignalSaveAttributes.Hash(); h1.String() == h2.String() {
+		t.Errorf("Expected different hashes for changed attributes, got: %s", h1)
+	}
+}
+
+// sortedLabelsAttributes is an attribute type that sorts label values on
+// normalization, without modifying the label values of the original.
+type sortedLabelsAttributes struct {
+	Name   string        `json:"name"`
+	Labels fields.Labels `json:"labels"`
+}
+
+func (a *sortedLabelsAttributes) Normalize() {
+	labels := maps.Clone(a.Labels)
+	for k, values := range labels {
+		labels[k] = slices.Sorted(slices.Values(values))
+	}
+	a.Labels = labels
+}
+
+func (a sortedLabelsAttributes) Hash() fields.Hexadecimal {
+	return views.AttributesHash(a)
+}
+
+func TestAttributesHashNormalizer(t *testing.T) {
+	attrs := sortedLabelsAttributes{
+		Name:   "a",
+		Labels: fields.Labels{"site": {"oslo", "bergen"}},
+	}
+	sorted := sortedLabelsAttributes{
+		Name:   "a",
+		Labels: fields.Labels{"site": {"bergen", "oslo"}},
+	}
+
+	b, err := json.Marshal(views.Resource[sortedLabelsAttributes, struct{}]{Attributes: attrs})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var check views.Resource[sortedLabelsAttributes, struct{}]
+	if err := json.Unmarshal(b, &check); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if hash, expect := attrs.Hash().String(), check.Meta.AttributesHash.String(); hash != expect {
+		t.Errorf("Unexpected Hash:\n got: %s\nwant: %s", hash, expect)
+	}
+	if hash, expect := attrs.Hash().String(), sorted.Hash().String(); hash != expect {
+		t.Errorf("Expected Hash to match normalized attributes:\n got: %s\nwant: %s", hash, expect)
+	}
+	if values, expect := attrs.Labels["site"], []string{"oslo", "bergen"}; !slices.Equal(values, expect) {
+		t.Errorf("Expected caller labels to be unchanged:\n got: %v\nwant: %v", values, expect)
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -29,6 +29,18 @@ type CreateSummary struct {
 	Created bool   `json:"created"`
 }
 
+// MergeCreateSummaries merges the summaries in src into dst, such as when the
+// same inputs are reported by multiple insert requests. A merged summary is
+// reported as created if it's reported as created by either.
+func MergeCreateSummaries(dst, src map[string]CreateSummary) {
+	for k, s := range src {
+		if prev, ok := dst[k]; ok {
+			s.Created = s.Created || prev.Created
+		}
+		dst[k] = s
+	}
+}
+
 // Selection holds resource selection results.
 type Selection[D, I any] struct {
 	Meta     SelectionMeta `json:"meta"`
@@ -49,3 +61,27 @@ type SelectionFormat struct {
 	DataAsArray         bool `json:"dataAsArray"`
 	GroupIncludedByType bool `json:"groupIncludedByType"`
 }
+
+// DefaultSelectionFormat returns the selection format used by default for
+// select methods, where data is returned as an array, and included resources
+// are grouped by type.
+func DefaultSelectionFormat() SelectionFormat {
+	return SelectionFormat{
+		DataAsArray:         true,
+		GroupIncludedByType: true,
+	}
+}
+
+// WithDataAsArray returns a copy of f where data is returned as an array if
+// v is true, or as a method specific object if v is false.
+func (f SelectionFormat) WithDataAsArray(v bool) SelectionFormat {
+	f.DataAsArray = v
+	return f
+}
+
+// WithGroupIncludedByType returns a copy of f where included resources are
+// grouped by type if v is true, or returned as a single list if v is false.
+func (f SelectionFormat) WithGroupIncludedByType(v bool) SelectionFormat {
+	f.GroupIncludedByType = v
+	return f
+}
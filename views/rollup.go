@@ -0,0 +1,58 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views
+
+import "strings"
+
+// RollupSeries holds the aggregated series for a single item in a rollup data
+// frame. Series that are not present in the data frame are nil.
+type RollupSeries struct {
+	Count DataSeries
+	Min   DataSeries
+	Max   DataSeries
+	Sum   DataSeries
+	Avg   DataSeries
+}
+
+// SplitRollups returns the series in df grouped by item ID. Rollup results
+// contain one series per aggregate, keyed by "<id>_<aggregate>", such as
+// "<id>_sum". Series keys that do not match a known aggregate are ignored.
+func SplitRollups(df DataFrame) map[string]RollupSeries {
+	m := make(map[string]RollupSeries)
+	for k, s := range df {
+		i := strings.LastIndexByte(k, '_')
+		if i < 0 {
+			continue
+		}
+		id, agg := k[:i], k[i+1:]
+		rs := m[id]
+		switch agg {
+		case "count":
+			rs.Count = s
+		case "min":
+			rs.Min = s
+		case "max":
+			rs.Max = s
+		case "sum":
+			rs.Sum = s
+		case "avg":
+			rs.Avg = s
+		default:
+			continue
+		}
+		m[id] = rs
+	}
+	return m
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestSplitRollups(t *testing.T) {
+	t0 := fields.AsTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	df := views.DataFrame{
+		"a_count": {t0: 2},
+		"a_avg":   {t0: 1.5},
+		"b_sum":   {t0: 3},
+		"b_max":   {t0: 2},
+		"b_other": {t0: 9},
+		"c":       {t0: 1},
+	}
+	expect := map[string]views.RollupSeries{
+		"a": {Count: views.DataSeries{t0: 2}, Avg: views.DataSeries{t0: 1.5}},
+		"b": {Sum: views.DataSeries{t0: 3}, Max: views.DataSeries{t0: 2}},
+	}
+	result := views.SplitRollups(df)
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("Unexpected result:\n got: %v\nwant: %v", result, expect)
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright 2022-2023 Searis AS
+// Copyright 2022-2026 Searis AS
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -25,18 +25,45 @@ type SignalInclude struct {
 	Items []Item `json:"items"`
 }
 
+// ItemByID returns the included item with the given ID.
+func (inc SignalInclude) ItemByID(id string) (Item, bool) {
+	return itemByID(inc.Items, id)
+}
+
 // SignalSave describe the save view for a signal.
 type SignalSave struct {
 	MetaSave
 	SignalSaveAttributes
 }
 
+// SavedSignal returns a save view for the passed in signal select view.
+// Attributes and annotations are cloned, so that the result can be modified
+// without affecting signal.
+func SavedSignal(signal Signal) SignalSave {
+	attrs := signal.Attributes.SignalSaveAttributes
+	attrs.Labels = attrs.Labels.Clone()
+	attrs.EnumValues = attrs.EnumValues.Clone()
+	return SignalSave{
+		SignalSaveAttributes: attrs,
+		MetaSave: MetaSave{
+			Annotations: signal.Meta.Annotations.Clone(),
+		},
+	}
+}
+
 // SignalAttributes contains attributes for the signal select view.
 type SignalAttributes struct {
 	SignalSaveAttributes
 	SignalReadOnlyAttributes
 }
 
+// Hash returns a SHA-1 hash of the JSON encoding of a, computed the same way as
+// Resource.MarshalJSON computes the attributes hash of encoded resources. The
+// hash is not guaranteed to match the attributes hash calculated by the server.
+func (a SignalAttributes) Hash() fields.Hexadecimal {
+	return AttributesHash(a)
+}
+
 // SignalReadOnlyAttributes contains read-only signal attributes.
 type SignalReadOnlyAttributes struct {
 	Input string `json:"input"`
@@ -56,12 +83,46 @@ type SignalSaveAttributes struct {
 	EnumValues     fields.EnumValues            `json:"enumValues"`
 }
 
+// Hash returns a SHA-1 hash of the JSON encoding of a. Comparing the hash of
+// attributes about to be saved with the hash of the last saved attributes,
+// e.g. as recorded in a state store, allows callers to skip SaveSignals
+// requests for unchanged signals. The hash is not guaranteed to match the
+// attributes hash calculated by the server.
+func (a SignalSaveAttributes) Hash() fields.Hexadecimal {
+	return AttributesHash(a)
+}
+
 // SignalRelationships declare the available relationships for the signal model.
 type SignalRelationships struct {
 	Integration ToOne `json:"integration"`
 	Item        ToOne `json:"item"`
 }
 
+// IntegrationID returns the ID of the integration the signal belongs to.
+func (r SignalRelationships) IntegrationID() (string, bool) {
+	return r.Integration.ID()
+}
+
+// ItemID returns the ID of the item the signal is published as, or false if
+// the signal is not published.
+func (r SignalRelationships) ItemID() (string, bool) {
+	return r.Item.ID()
+}
+
+// SignalIDsByItem returns the IDs of the passed in signals keyed by the ID of
+// the item they are published as. Signals that are not published are omitted.
+// As the API does not expose a signals relationship for items, this is the
+// recommended way to find the signals that belong to an item.
+func SignalIDsByItem(signals []Signal) map[string][]string {
+	m := make(map[string][]string)
+	for _, signal := range signals {
+		if id, ok := signal.Relationships.ItemID(); ok {
+			m[id] = append(m[id], signal.ID)
+		}
+	}
+	return m
+}
+
 // ValueType determine how data values should be interpreted.
 type ValueType string
 
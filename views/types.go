@@ -0,0 +1,87 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/clarify/clarify-go/fields"
+)
+
+// ValueTypes returns all known value types.
+func ValueTypes() []ValueType {
+	return []ValueType{Numeric, Enum}
+}
+
+// ParseValueType returns the value type matching s, ignoring case and
+// surrounding white-space. An error wrapping ErrBadType is returned if s does
+// not match a known value type.
+func ParseValueType(s string) (ValueType, error) {
+	t := ValueType(strings.ToLower(strings.TrimSpace(s)))
+	if !t.Valid() {
+		return "", fmt.Errorf("%w: value type %q not in %v", ErrBadType, s, ValueTypes())
+	}
+	return t, nil
+}
+
+// Valid returns true if t is a known value type.
+func (t ValueType) Valid() bool {
+	return slices.Contains(ValueTypes(), t)
+}
+
+// Filter returns a resource filter matching resources with value type t.
+func (t ValueType) Filter() fields.Comparisons {
+	return fields.CompareField("valueType", fields.Equal(t))
+}
+
+// ValueTypeIn returns a resource filter matching resources with one of the
+// given value types.
+func ValueTypeIn(types ...ValueType) fields.Comparisons {
+	return fields.CompareField("valueType", fields.In(types...))
+}
+
+// SourceTypes returns all known source types.
+func SourceTypes() []SourceType {
+	return []SourceType{Measurement, Aggregation, Prediction}
+}
+
+// ParseSourceType returns the source type matching s, ignoring case and
+// surrounding white-space. An error wrapping ErrBadType is returned if s does
+// not match a known source type.
+func ParseSourceType(s string) (SourceType, error) {
+	t := SourceType(strings.ToLower(strings.TrimSpace(s)))
+	if !t.Valid() {
+		return "", fmt.Errorf("%w: source type %q not in %v", ErrBadType, s, SourceTypes())
+	}
+	return t, nil
+}
+
+// Valid returns true if t is a known source type.
+func (t SourceType) Valid() bool {
+	return slices.Contains(SourceTypes(), t)
+}
+
+// Filter returns a resource filter matching resources with source type t.
+func (t SourceType) Filter() fields.Comparisons {
+	return fields.CompareField("sourceType", fields.Equal(t))
+}
+
+// SourceTypeIn returns a resource filter matching resources with one of the
+// given source types.
+func SourceTypeIn(types ...SourceType) fields.Comparisons {
+	return fields.CompareField("sourceType", fields.In(types...))
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestParseValueType(t *testing.T) {
+	test := func(s string, expect views.ValueType, expectErr error) func(t *testing.T) {
+		return func(t *testing.T) {
+			result, err := views.ParseValueType(s)
+			if !errors.Is(err, expectErr) {
+				t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, expectErr)
+			}
+			if result != expect {
+				t.Errorf("Unexpected result:\n got: %q\nwant: %q", result, expect)
+			}
+		}
+	}
+	t.Run("numeric", test("numeric", views.Numeric, nil))
+	t.Run(" Enum ", test(" Enum ", views.Enum, nil))
+	t.Run("numerc", test("numerc", "", views.ErrBadType))
+	t.Run("empty", test("", "", views.ErrBadType))
+}
+
+func TestParseSourceType(t *testing.T) {
+	test := func(s string, expect views.SourceType, expectErr error) func(t *testing.T) {
+		return func(t *testing.T) {
+			result, err := views.ParseSourceType(s)
+			if !errors.Is(err, expectErr) {
+				t.Errorf("Unexpected error:\n got: %v\nwant: %v", err, expectErr)
+			}
+			if result != expect {
+				t.Errorf("Unexpected result:\n got: %q\nwant: %q", result, expect)
+			}
+		}
+	}
+	t.Run("measurement", test("measurement", views.Measurement, nil))
+	t.Run("PREDICTION", test("PREDICTION", views.Prediction, nil))
+	t.Run("measurment", test("measurment", "", views.ErrBadType))
+}
+
+func TestTypeFilters(t *testing.T) {
+	test := func(f fields.ResourceFilterType, expect string) func(t *testing.T) {
+		return func(t *testing.T) {
+			b, err := json.Marshal(fields.And(f))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(b) != expect {
+				t.Errorf("Unexpected JSON:\n got: %s\nwant: %s", b, expect)
+			}
+		}
+	}
+	t.Run("ValueType.Filter", test(views.Enum.Filter(), `{"valueType":{"$in":["enum"]}}`))
+	t.Run("SourceTypeIn", test(
+		views.SourceTypeIn(views.Measurement, views.Prediction),
+		`{"sourceType":{"$in":["measurement","prediction"]}}`,
+	))
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/clarify/clarify-go/fields"
+)
+
+// Client-side limits for resource attributes, as checked by SignalSave.Issues.
+// The limits are conservative best effort values, and are not taken from the
+// API specification; the server may accept larger values.
+const (
+	MaxNameLength        = 100
+	MaxDescriptionLength = 1000
+	MaxEngUnitLength     = 255
+	MaxEnumValues        = 1000
+	MaxEnumValueLength   = 100
+	MaxEnumValueIndex    = 9999
+)
+
+var reKey = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_\-./]{0,99}$`)
+
+// Issues returns a map of issues found in the signal save view keyed by field
+// path, or nil if no issues are found. Field paths are camelCased to match the
+// JSON encoding. Annotation keys must be prefixed by a namespace, such as
+// fields.AnnotationKeyPrefix, followed by a name.
+//
+// Validation is performed at best effort; a signal without issues may still be
+// rejected by the server, and a signal with issues may be accepted.
+func (s SignalSave) Issues() map[string][]string {
+	issues := make(map[string][]string)
+	add := func(path, format string, a ...any) {
+		issues[path] = append(issues[path], fmt.Sprintf(format, a...))
+	}
+
+	for k := range s.Annotations {
+		if !reKey.MatchString(k) {
+			add("annotations", "key %q must match %s", k, reKey)
+		}
+		if i := strings.LastIndexByte(k, '/'); i <= 0 || i == len(k)-1 {
+			add("annotations", "key %q must be on the format <prefix>/<name>, such as %q", k, fields.AnnotationKeyPrefix+"name")
+		}
+	}
+
+	a := s.SignalSaveAttributes
+	if n := utf8.RuneCountInString(a.Name); n > MaxNameLength {
+		add("name", "must be at most %d characters, got %d", MaxNameLength, n)
+	}
+	if n := utf8.RuneCountInString(a.Description); n > MaxDescriptionLength {
+		add("description", "must be at most %d characters, got %d", MaxDescriptionLength, n)
+	}
+	if n := utf8.RuneCountInString(a.EngUnit); n > MaxEngUnitLength {
+		add("engUnit", "must be at most %d characters, got %d", MaxEngUnitLength, n)
+	}
+	if a.ValueType != "" && !a.ValueType.Valid() {
+		add("valueType", "not in %v", ValueTypes())
+	}
+	if a.SourceType != "" && !a.SourceType.Valid() {
+		add("sourceType", "not in %v", SourceTypes())
+	}
+	for k := range a.Labels {
+		if !reKey.MatchString(k) {
+			add("labels", "key %q must match %s", k, reKey)
+		}
+	}
+	if n := len(a.EnumValues); n > MaxEnumValues {
+		add("enumValues", "must contain at most %d values, got %d", MaxEnumValues, n)
+	}
+	for i, v := range a.EnumValues {
+		if i < 0 || i > MaxEnumValueIndex {
+			add("enumValues", "index %d not in range [0,%d]", i, MaxEnumValueIndex)
+		}
+		if n := utf8.RuneCountInString(v); n > MaxEnumValueLength {
+			add("enumValues", "value for index %d must be at most %d characters, got %d", i, MaxEnumValueLength, n)
+		}
+	}
+	if a.SampleInterval.Duration < 0 {
+		add("sampleInterval", "must not be negative")
+	}
+	switch {
+	case a.GapDetection.Duration < 0:
+		add("gapDetection", "must not be negative")
+	case a.GapDetection.Duration > 0 && a.GapDetection.Duration < a.SampleInterval.Duration:
+		add("gapDetection", "must be greater than or equal to sampleInterval")
+	}
+
+	if len(issues) == 0 {
+		return nil
+	}
+	return issues
+}
+
+// Issues returns a map of issues found in the item save view keyed by field
+// path, or nil if no issues are found. The same rules as for SignalSave.Issues
+// apply.
+func (item ItemSave) Issues() map[string][]string {
+	a := item.ItemSaveAttributes
+	return SignalSave{
+		MetaSave: item.MetaSave,
+		SignalSaveAttributes: SignalSaveAttributes{
+			Name:           a.Name,
+			Description:    a.Description,
+			ValueType:      a.ValueType,
+			SourceType:     a.SourceType,
+			EngUnit:        a.EngUnit,
+			SampleInterval: a.SampleInterval,
+			GapDetection:   a.GapDetection,
+			Labels:         a.Labels,
+			EnumValues:     a.EnumValues,
+		},
+	}.Issues()
+}
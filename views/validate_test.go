@@ -0,0 +1,73 @@
+// Copyright 2026 Searis AS
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clarify/clarify-go/fields"
+	"github.com/clarify/clarify-go/views"
+)
+
+func TestSignalSaveIssues(t *testing.T) {
+	test := func(s views.SignalSave, expectPaths ...string) func(t *testing.T) {
+		return func(t *testing.T) {
+			issues := s.Issues()
+			var paths []string
+			for p := range issues {
+				paths = append(paths, p)
+			}
+			slices.Sort(paths)
+			if !slices.Equal(paths, expectPaths) {
+				t.Errorf("Unexpected issue paths:\n got: %v\nwant: %v\nissues: %v", paths, expectPaths, issues)
+			}
+		}
+	}
+
+	t.Run("valid", test(views.SignalSave{
+		MetaSave: views.MetaSave{
+			Annotations: fields.Annotations{"clarify/clarify-go/example": "1"},
+		},
+		SignalSaveAttributes: views.SignalSaveAttributes{
+			Name:           "Temperature",
+			ValueType:      views.Numeric,
+			Labels:         fields.Labels{"location": {"Oslo"}},
+			EnumValues:     fields.EnumValues{0: "off", 1: "on"},
+			SampleInterval: fields.FixedDurationNullZero{Duration: time.Minute},
+			GapDetection:   fields.FixedDurationNullZero{Duration: time.Hour},
+		},
+	}))
+	t.Run("invalid", test(views.SignalSave{
+		MetaSave: views.MetaSave{
+			Annotations: fields.Annotations{"-bad key": "1"},
+		},
+		SignalSaveAttributes: views.SignalSaveAttributes{
+			Name:           strings.Repeat("x", views.MaxNameLength+1),
+			ValueType:      "string",
+			SourceType:     "unknown",
+			EnumValues:     fields.EnumValues{views.MaxEnumValueIndex + 1: "x"},
+			SampleInterval: fields.FixedDurationNullZero{Duration: time.Hour},
+			GapDetection:   fields.FixedDurationNullZero{Duration: time.Minute},
+		},
+	}, "annotations", "enumValues", "gapDetection", "name", "sourceType", "valueType"))
+	t.Run("annotation without prefix", test(views.SignalSave{
+		MetaSave: views.MetaSave{
+			Annotations: fields.Annotations{"example": "1"},
+		},
+	}, "annotations"))
+}